@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# Make version 3 of the "security-scan" attestation type the latest version
+# again, for example after a bad schema or rules change.
+# Run with: terraform apply -invoke=action.kosli_custom_attestation_type_rollback.security_scan
+action "kosli_custom_attestation_type_rollback" "security_scan" {
+  config {
+    name    = "security-scan"
+    version = 3
+  }
+}
@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+variable "git_commit" {
+  description = "Commit being released, used as the trail name"
+  type        = string
+}
+
+variable "backend_fingerprint" {
+  description = "SHA256 fingerprint of the backend image"
+  type        = string
+}
+
+# Latest risk review reported to the trail
+data "kosli_attestation" "risk_review" {
+  flow  = "backend"
+  trail = var.git_commit
+  name  = "risk-review"
+}
+
+# Latest unit test results of the backend artifact
+data "kosli_attestation" "unit_tests" {
+  flow                 = "backend"
+  trail                = var.git_commit
+  name                 = "unit-tests"
+  artifact_fingerprint = var.backend_fingerprint
+}
+
+locals {
+  # Only roll out to every region when the risk review says so
+  risk_level     = try(jsondecode(data.kosli_attestation.risk_review.user_data).risk_level, "high")
+  rollout_canary = local.risk_level == "high" || !data.kosli_attestation.unit_tests.compliant
+}
+
+output "rollout_canary" {
+  value = local.rollout_canary
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# List the active security attestation types
+data "kosli_custom_attestation_types" "security" {
+  name_prefix = "security-"
+  archived    = false
+}
+
+output "security_attestation_type_names" {
+  description = "Names of the active security attestation types"
+  value       = data.kosli_custom_attestation_types.security.custom_attestation_types[*].name
+}
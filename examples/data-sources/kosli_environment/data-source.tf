@@ -34,6 +34,11 @@ output "production_last_reported" {
   value       = data.kosli_environment.production.last_reported_at
 }
 
+output "production_latest_snapshot" {
+  description = "Index of the latest snapshot reported by production"
+  value       = data.kosli_environment.production.latest_snapshot_index
+}
+
 output "production_type" {
   description = "Type of the production environment"
   value       = data.kosli_environment.production.type
@@ -50,6 +55,12 @@ output "production_tags" {
   value       = data.kosli_environment.production.tags
 }
 
+# Access policies attached to the environment
+output "production_policies" {
+  description = "Policies attached to the production environment"
+  value       = data.kosli_environment.production.policies
+}
+
 # Check if a specific tag exists
 output "production_managed_by" {
   description = "Who manages the production environment (from tags)"
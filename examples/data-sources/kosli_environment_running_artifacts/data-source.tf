@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+variable "expected_fingerprints" {
+  description = "Fingerprints of the artifacts Terraform deployed to production"
+  type        = set(string)
+}
+
+# Artifacts running in production according to its latest snapshot
+data "kosli_environment_running_artifacts" "production" {
+  environment = "production-k8s"
+}
+
+locals {
+  running_fingerprints = toset([for a in data.kosli_environment_running_artifacts.production.artifacts : a.fingerprint])
+}
+
+# Drift: artifacts running that Terraform did not deploy, and the reverse
+output "unexpected_artifacts" {
+  value = [for a in data.kosli_environment_running_artifacts.production.artifacts : a.name if !contains(var.expected_fingerprints, a.fingerprint)]
+}
+
+output "missing_fingerprints" {
+  value = setsubtract(var.expected_fingerprints, local.running_fingerprints)
+}
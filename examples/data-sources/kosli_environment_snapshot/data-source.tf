@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+data "kosli_environment" "production" {
+  name = "production-k8s"
+}
+
+# The latest snapshot, pinned by index so that every lookup in this plan
+# sees the same snapshot even if the environment reports again meanwhile
+data "kosli_environment_snapshot" "current" {
+  selector = "${data.kosli_environment.production.name}#${data.kosli_environment.production.latest_snapshot_index}"
+}
+
+# What ran three snapshots ago
+data "kosli_environment_snapshot" "previous" {
+  selector = "production-k8s~3"
+}
+
+# What was running at a point in time
+data "kosli_environment_snapshot" "release_day" {
+  selector = "production-k8s@{2026-01-15T12:00:00}"
+}
+
+output "artifacts_changed_since_previous" {
+  value = setsubtract(
+    [for a in data.kosli_environment_snapshot.current.artifacts : a.fingerprint],
+    [for a in data.kosli_environment_snapshot.previous.artifacts : a.fingerprint],
+  )
+}
+
+output "release_day_compliant" {
+  value = data.kosli_environment_snapshot.release_day.compliant
+}
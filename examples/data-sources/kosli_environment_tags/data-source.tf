@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# Read the tags of an existing environment
+data "kosli_environment_tags" "production" {
+  name = "production-k8s"
+}
+
+# Label a related environment the same way
+resource "kosli_environment" "production_dr" {
+  name = "production-k8s-dr"
+  type = "K8S"
+  tags = merge(data.kosli_environment_tags.production.tags, {
+    role = "disaster-recovery"
+  })
+}
+
+output "production_team" {
+  value = lookup(data.kosli_environment_tags.production.tags, "team", null)
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# List the production Kubernetes environments
+data "kosli_environments" "production_k8s" {
+  type = "K8S"
+  tags = {
+    stage = "production"
+  }
+}
+
+# Attach a policy to each of them without intermediate locals
+resource "kosli_policy_attachment" "provenance" {
+  for_each = data.kosli_environments.production_k8s.environments_by_name
+
+  environment_name = each.key
+  policy_name      = "require-provenance"
+}
+
+# Count environments of each type across the organization
+data "kosli_environments" "all" {}
+
+output "environment_counts_by_type" {
+  description = "Number of environments of each type"
+  value       = { for type, names in data.kosli_environments.all.environments_by_type : type => length(names) }
+}
@@ -32,3 +32,8 @@ output "flow_tags" {
   description = "The tags of the flow"
   value       = data.kosli_flow.example.tags
 }
+
+output "flow_artifacts" {
+  description = "The artifacts expected on every trail of the flow"
+  value       = [for a in data.kosli_flow.example.trail_template.artifacts : a.name]
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+variable "git_commit" {
+  description = "Commit being released, used as the trail name"
+  type        = string
+}
+
+# Fail the plan unless the release's trail has all its evidence
+data "kosli_flow_compliance" "release" {
+  flow                  = "backend"
+  trail                 = var.git_commit
+  fail_on_non_compliant = true
+}
+
+# Inspect the most recent trail of a flow without failing
+data "kosli_flow_compliance" "latest" {
+  flow = "backend"
+}
+
+output "latest_trail_compliant" {
+  value = data.kosli_flow_compliance.latest.compliant
+}
+
+output "latest_trail_missing_attestations" {
+  value = data.kosli_flow_compliance.latest.missing_attestations
+}
+
+# Fingerprints of the artifacts of the most recent trail, keyed by name
+output "latest_trail_artifact_fingerprints" {
+  value = { for a in data.kosli_flow_compliance.latest.artifacts : a.name => a.fingerprint }
+}
@@ -0,0 +1,13 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# Fail the plan with the line and column of every problem if the policy
+# document is malformed, e.g. in a terraform test run in CI.
+output "production_policy_valid" {
+  value = provider::kosli::validate_policy(file("${path.module}/policies/production.yaml"))
+}
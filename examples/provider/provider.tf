@@ -20,4 +20,53 @@ provider "kosli" {
 
   # Optional: HTTP client timeout in seconds (defaults to 30)
   # timeout = 60
+
+  # Optional: deadlines in seconds for whole read/write operations, including retries
+  # read_timeout  = 120
+  # write_timeout = 300
+
+  # Optional: retry behavior for transient failures (429, 5xx, connection errors)
+  # retry = {
+  #   max_retries         = 5
+  #   min_wait            = 1
+  #   max_wait            = 60
+  #   respect_retry_after = true # Honor Retry-After on 429/503 responses
+  # }
+
+  # Optional: cap concurrent API requests, e.g. for smaller organizations
+  # max_parallel_requests = 4
+
+  # Optional: items per page when listing, e.g. environments (defaults to 100)
+  # default_page_size = 500
+
+  # Optional: client-side rate limit, useful for very large applies
+  # rate_limit = {
+  #   requests_per_second = 10
+  #   burst               = 20
+  # }
+
+  # Optional: fail fast during a Kosli API outage
+  # circuit_breaker = {
+  #   failure_threshold = 5
+  #   cooldown          = 30
+  # }
+
+  # Optional: disable the in-memory read cache (enabled by default)
+  # read_cache = false
+
+  # Optional: skip the read-back after creates and updates in large applies
+  # skip_read_after_write = true
+
+  # Optional: keep description = "" as an empty string rather than null
+  # keep_empty_descriptions = true
+
+  # Optional: days before the API token expires to start warning (defaults to 14)
+  # token_expiry_warning_days = 30
+
+  # Optional: tags applied to every kosli_environment
+  # default_tags = {
+  #   tags = {
+  #     managed-by = "terraform"
+  #   }
+  # }
 }
@@ -6,12 +6,14 @@ terraform {
   }
 }
 
-# Action that fires on non-compliant environment events
+# Action that fires on non-compliant environment events. verify_on_create
+# sends a test event first, so a mistyped webhook URL fails the apply.
 resource "kosli_action" "compliance_alerts" {
-  name         = "compliance-alerts"
-  environments = ["production-k8s"]
-  triggers     = ["ON_NON_COMPLIANT_ENV", "ON_COMPLIANT_ENV"]
-  webhook_url  = "https://hooks.slack.com/services/T00000000/B00000000/XXXXXXXX"
+  name             = "compliance-alerts"
+  environments     = ["production-k8s"]
+  triggers         = ["ON_NON_COMPLIANT_ENV", "ON_COMPLIANT_ENV"]
+  webhook_url      = "https://hooks.slack.com/services/T00000000/B00000000/XXXXXXXX"
+  verify_on_create = true
 }
 
 # Action that fires on scaling events
@@ -21,3 +23,20 @@ resource "kosli_action" "scaling_alerts" {
   triggers     = ["ON_SCALED_ARTIFACT"]
   webhook_url  = "https://outlook.office.com/webhook/XXXX"
 }
+
+variable "incident_webhook_url" {
+  description = "Incident webhook URL, e.g. read from a secrets manager"
+  type        = string
+  sensitive   = true
+  ephemeral   = true
+}
+
+# Action whose webhook URL is never stored in the Terraform state (Terraform 1.11+).
+# Bump webhook_url_wo_version to send a changed URL to Kosli.
+resource "kosli_action" "incident_alerts" {
+  name                   = "incident-alerts"
+  environments           = ["production-k8s"]
+  triggers               = ["ON_NON_COMPLIANT_ENV"]
+  webhook_url_wo         = var.incident_webhook_url
+  webhook_url_wo_version = 1
+}
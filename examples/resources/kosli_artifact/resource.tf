@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+variable "git_commit" {
+  description = "Commit the pipeline is running for, used as the trail name"
+  type        = string
+}
+
+variable "build_url" {
+  description = "URL of the pipeline run"
+  type        = string
+}
+
+# Lambda package built from the commit, fingerprinted by its content
+resource "kosli_artifact" "lambda" {
+  flow          = "backend-service"
+  trail         = var.git_commit
+  name          = "backend.zip"
+  template_name = "backend-lambda"
+  fingerprint   = filesha256("${path.module}/build/backend.zip")
+  commit_sha    = var.git_commit
+  build_url     = var.build_url
+}
+
+# Container image, fingerprinted by its digest
+resource "kosli_artifact" "image" {
+  flow        = "backend-service"
+  trail       = var.git_commit
+  name        = "acme/backend:1.4.2"
+  fingerprint = "3b7f9a1c0e5d2f8b6a4c9e7d1f3b5a8c2e6d4f0a9b7c5e3d1f8a6b4c2e0d9f7a"
+  commit_sha  = var.git_commit
+  build_url   = var.build_url
+  commit_url  = "https://github.com/acme/backend/commit/${var.git_commit}"
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+variable "git_commit" {
+  description = "Commit the pipeline is running for, used as the trail name"
+  type        = string
+}
+
+# Quality gate result for the whole trail
+resource "kosli_attestation_sonar" "code_quality" {
+  flow                = "backend-service"
+  trail               = var.git_commit
+  name                = "code-quality"
+  project_key         = "acme_backend-service"
+  quality_gate_status = "OK"
+  scan_url            = "https://sonarcloud.io/dashboard?id=acme_backend-service"
+}
+
+# Quality gate result for an artifact in the trail
+resource "kosli_attestation_sonar" "image_quality" {
+  flow                 = "backend-service"
+  trail                = var.git_commit
+  name                 = "image-quality"
+  artifact_fingerprint = "3b7f9a1c0e5d2f8b6a4c9e7d1f3b5a8c2e6d4f0a9b7c5e3d1f8a6b4c2e0d9f7a"
+  project_key          = "acme_backend-service"
+  quality_gate_status  = "WARN"
+}
+
+output "code_quality_compliant" {
+  description = "Whether the trail's quality gate passed"
+  value       = kosli_attestation_sonar.code_quality.compliant
+}
@@ -0,0 +1,59 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+resource "kosli_environment" "cluster" {
+  name = "production-k8s"
+  type = "K8S"
+}
+
+variable "pods" {
+  description = "Running pods and the image digests of their containers, e.g. built from kubernetes provider data"
+  type        = map(map(string))
+}
+
+# Report the running pods whenever they change
+resource "kosli_environment_snapshot_report" "cluster" {
+  environment = kosli_environment.cluster.name
+  type        = kosli_environment.cluster.type
+
+  artifacts = [
+    for pod, images in var.pods : {
+      name    = pod
+      digests = images
+    }
+  ]
+}
+
+# Report the tasks of an ECS service from AWS provider data
+data "aws_ecs_task_definition" "api" {
+  task_definition = "api"
+}
+
+variable "api_task_arns" {
+  description = "ARNs of the running tasks of the api service"
+  type        = list(string)
+}
+
+variable "api_image_digest" {
+  description = "SHA256 digest of the api image"
+  type        = string
+}
+
+resource "kosli_environment_snapshot_report" "ecs" {
+  environment = "production-ecs"
+  type        = "ECS"
+
+  artifacts = [
+    for arn in var.api_task_arns : {
+      name = arn
+      digests = {
+        (jsondecode(data.aws_ecs_task_definition.api.container_definitions)[0].image) = var.api_image_digest
+      }
+    }
+  ]
+}
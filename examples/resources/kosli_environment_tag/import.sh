@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Import an existing tag by <environment>/<key>
+terraform import kosli_environment_tag.cost_center production-k8s/cost-center
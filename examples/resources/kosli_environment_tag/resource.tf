@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# Shared environment, owned by the platform team. Its tags are left to the
+# teams, so tags is not set.
+resource "kosli_environment" "production" {
+  name = "production-k8s"
+  type = "K8S"
+}
+
+# Each team contributes its own tags, e.g. from its own configuration
+resource "kosli_environment_tag" "cost_center" {
+  environment = kosli_environment.production.name
+  key         = "cost-center"
+  value       = "payments"
+}
+
+resource "kosli_environment_tag" "on_call" {
+  environment = "production-k8s"
+  key         = "on-call"
+  value       = "payments-team"
+}
@@ -51,3 +51,10 @@ resource "kosli_flow" "tagged" {
     environment = "production"
   }
 }
+
+# Flow in a test organization, deleted instead of archived on destroy so
+# archived flows don't accumulate
+resource "kosli_flow" "ephemeral" {
+  name              = "pr-1234-preview"
+  delete_on_destroy = true
+}
@@ -79,3 +79,16 @@ resource "kosli_logical_environment" "tagged" {
     team        = "platform"
   }
 }
+
+# Shared logical environment that other configurations add members to, e.g.
+# with kosli_logical_environment_membership. Listed members are added, but
+# members contributed elsewhere are never removed.
+resource "kosli_logical_environment" "shared" {
+  name              = "production-shared"
+  description       = "Production environments contributed by every team"
+  manage_membership = false
+
+  included_environments = [
+    kosli_environment.production_k8s.name,
+  ]
+}
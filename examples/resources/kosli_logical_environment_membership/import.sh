@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Import an existing membership by <logical_environment>/<environment>
+terraform import kosli_logical_environment_membership.payments production-aggregate/payments-production
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+# Shared logical environment, owned by the platform team. Its membership is
+# left to the teams, so included_environments is not set.
+resource "kosli_logical_environment" "production_all" {
+  name        = "production-aggregate"
+  description = "All production environments, contributed by each team"
+}
+
+# Each team contributes its own environment, e.g. from its own configuration
+resource "kosli_environment" "payments" {
+  name = "payments-production"
+  type = "K8S"
+}
+
+resource "kosli_logical_environment_membership" "payments" {
+  logical_environment = kosli_logical_environment.production_all.name
+  environment         = kosli_environment.payments.name
+}
+
+resource "kosli_logical_environment_membership" "search" {
+  logical_environment = "production-aggregate"
+  environment         = "search-production"
+}
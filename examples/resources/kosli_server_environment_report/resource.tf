@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    kosli = {
+      source = "kosli-dev/kosli"
+    }
+  }
+}
+
+resource "kosli_environment" "functions" {
+  name = "production-functions"
+  type = "lambda"
+}
+
+variable "functions" {
+  description = "Deployed lambda functions and the SHA256 fingerprints of their code"
+  type        = map(string)
+}
+
+# Report the deployed functions whenever they change
+resource "kosli_server_environment_report" "functions" {
+  environment = kosli_environment.functions.name
+  type        = kosli_environment.functions.type
+
+  artifacts = [
+    for name, fingerprint in var.functions : {
+      name        = name
+      fingerprint = fingerprint
+    }
+  ]
+}
+
+# Report the files deployed to a server environment
+resource "kosli_server_environment_report" "app_servers" {
+  environment = "production-servers"
+  type        = "server"
+
+  artifacts = [
+    {
+      name        = "/opt/app/app.jar"
+      fingerprint = filesha256("${path.module}/build/app.jar")
+    },
+  ]
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &customAttestationTypeRollbackAction{}
+var _ action.ActionWithConfigure = &customAttestationTypeRollbackAction{}
+
+// NewCustomAttestationTypeRollbackAction creates a new custom attestation type rollback action.
+func NewCustomAttestationTypeRollbackAction() action.Action {
+	return &customAttestationTypeRollbackAction{}
+}
+
+// customAttestationTypeRollbackAction defines the action implementation.
+type customAttestationTypeRollbackAction struct {
+	client *client.Client
+}
+
+// customAttestationTypeRollbackActionModel describes the action data model.
+type customAttestationTypeRollbackActionModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+// Metadata returns the action type name.
+func (a *customAttestationTypeRollbackAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_attestation_type_rollback"
+}
+
+// Schema defines the schema for the action.
+func (a *customAttestationTypeRollbackAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Rolls a custom attestation type back to an earlier version by publishing that version's schema and jq rules as a new version. Kosli always evaluates attestations against the latest version, so this reverts the effective version without having to reconstruct the old content by hand. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the custom attestation type to roll back.",
+				Required:            true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "Version whose schema and jq rules become the latest version again. Must be an existing version of the type.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *customAttestationTypeRollbackAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+// Invoke republishes the requested version as the latest version.
+func (a *customAttestationTypeRollbackAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data customAttestationTypeRollbackActionModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	version := data.Version.ValueInt64()
+	if version < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Invalid Version",
+			fmt.Sprintf("version must be at least 1, got %d.", version),
+		)
+		return
+	}
+
+	// Fetch the version to restore
+	attestationType, err := a.client.GetCustomAttestationType(ctx, name, &client.GetCustomAttestationTypeOptions{
+		Version: strconv.FormatInt(version, 10),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Custom Attestation Type Version",
+			fmt.Sprintf("Could not read version %d of custom attestation type %q: %s", version, name, apiErrorDetail(err)),
+		)
+		return
+	}
+
+	schemaValue, jqRules, err := attestationType.VersionContent(int(version))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Custom Attestation Type Version Not Found",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Publishing version %d of custom attestation type %q as its latest version", version, name),
+	})
+
+	// Updates create a new version, so re-creating with the old content
+	// makes it the latest version again
+	createReq := &client.CreateCustomAttestationTypeRequest{
+		Name:        name,
+		Description: attestationType.Description,
+		Schema:      schemaValue,
+		JqRules:     jqRules,
+	}
+	if err := a.client.CreateCustomAttestationType(ctx, createReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Rolling Back Custom Attestation Type",
+			fmt.Sprintf("Could not roll back custom attestation type %q to version %d: %s", name, version, apiErrorDetail(err)),
+		)
+		return
+	}
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestCustomAttestationTypeRollbackAction_Metadata(t *testing.T) {
+	a := NewCustomAttestationTypeRollbackAction()
+	resp := &action.MetadataResponse{}
+
+	a.Metadata(context.Background(), action.MetadataRequest{ProviderTypeName: "kosli"}, resp)
+
+	if resp.TypeName != "kosli_custom_attestation_type_rollback" {
+		t.Errorf("Expected TypeName 'kosli_custom_attestation_type_rollback', got %q", resp.TypeName)
+	}
+}
+
+func TestCustomAttestationTypeRollbackAction_Schema(t *testing.T) {
+	a := NewCustomAttestationTypeRollbackAction()
+	resp := &action.SchemaResponse{}
+
+	a.Schema(context.Background(), action.SchemaRequest{}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema method returned errors: %v", resp.Diagnostics.Errors())
+	}
+	for _, name := range []string{"name", "version"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("Expected %q attribute to exist", name)
+		}
+		if !attr.IsRequired() {
+			t.Errorf("Expected %q attribute to be required", name)
+		}
+	}
+}
+
+func TestCustomAttestationTypeRollbackAction_Configure_WrongType(t *testing.T) {
+	a := &customAttestationTypeRollbackAction{}
+	resp := &action.ConfigureResponse{}
+
+	a.Configure(context.Background(), action.ConfigureRequest{ProviderData: "wrong type"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected error when provider data is wrong type")
+	}
+	if a.client != nil {
+		t.Error("Expected client to remain nil when provider data is wrong type")
+	}
+}
+
+func TestCustomAttestationTypeRollbackAction_Invoke(t *testing.T) {
+	var published map[string]any
+	var publishedSchema string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.URL.Query().Get("version"); got != "1" {
+				t.Errorf("Expected version query '1', got %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{
+				"name": "coverage",
+				"description": "Coverage check",
+				"versions": [
+					{"version": 2, "type_schema": {"type": "array"}, "evaluator": {"content_type": "jq", "rules": [".broken"]}},
+					{"version": 1, "type_schema": {"type": "object"}, "evaluator": {"content_type": "jq", "rules": [".coverage >= 80"]}}
+				]
+			}`))
+		case http.MethodPost:
+			if err := json.Unmarshal([]byte(r.FormValue("data_json")), &published); err != nil {
+				t.Errorf("Failed to parse data_json: %v", err)
+			}
+			if f, _, err := r.FormFile("type_schema"); err == nil {
+				b, _ := io.ReadAll(f)
+				publishedSchema = string(b)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`"OK"`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var progress []string
+	resp := invokeRollback(t, c, 1, &progress)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Invoke returned errors: %v", resp.Diagnostics.Errors())
+	}
+	if published["description"] != "Coverage check" {
+		t.Errorf("Expected description to be preserved, got %v", published["description"])
+	}
+	rules, _ := published["evaluator"].(map[string]any)["rules"].([]any)
+	if len(rules) != 1 || rules[0] != ".coverage >= 80" {
+		t.Errorf("Expected rules of version 1 to be published, got %v", rules)
+	}
+	if publishedSchema != `{"type":"object"}` {
+		t.Errorf("Expected schema of version 1 to be published, got %q", publishedSchema)
+	}
+	if len(progress) != 1 || !strings.Contains(progress[0], "version 1") {
+		t.Errorf("Expected a progress message for version 1, got %v", progress)
+	}
+}
+
+func TestCustomAttestationTypeRollbackAction_Invoke_InvalidVersion(t *testing.T) {
+	var progress []string
+	resp := invokeRollback(t, nil, 0, &progress)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected error for version 0")
+	}
+}
+
+// invokeRollback runs the rollback action for the "coverage" type with c.
+func invokeRollback(t *testing.T, c *client.Client, version int64, progress *[]string) *action.InvokeResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	a := &customAttestationTypeRollbackAction{client: c}
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":    tftypes.String,
+			"version": tftypes.Number,
+		}}, map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, "coverage"),
+			"version": tftypes.NewValue(tftypes.Number, version),
+		}),
+	}
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			*progress = append(*progress, event.Message)
+		},
+	}
+	a.Invoke(ctx, action.InvokeRequest{Config: config}, resp)
+	return resp
+}
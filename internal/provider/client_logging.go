@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// cliDebugEnvVar is the Kosli CLI's debug switch. When it is true, the API
+// client's request and response details, which are otherwise TRACE entries,
+// are logged at DEBUG, so the same switch gets request details out of the CLI
+// and the provider.
+const cliDebugEnvVar = "KOSLI_DEBUG"
+
+// cliDebugEnabled reports whether KOSLI_DEBUG is true.
+func cliDebugEnabled() bool {
+	debug, _ := strconv.ParseBool(os.Getenv(cliDebugEnvVar))
+	return debug
+}
+
+// providerLogLevelEnvVars set the level of the provider's logs, most specific
+// first, as Terraform and terraform-plugin-go read them.
+var providerLogLevelEnvVars = []string{"TF_LOG_PROVIDER_KOSLI", "TF_LOG_PROVIDER", "TF_LOG"}
+
+// providerTraceEnabled reports whether Terraform keeps the provider's TRACE
+// logs, going by the same environment variables as Terraform.
+func providerTraceEnabled() bool {
+	for _, name := range providerLogLevelEnvVars {
+		if level := strings.ToUpper(os.Getenv(name)); level != "" {
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+	// Acceptance tests log at TRACE to TF_ACC_LOG_PATH
+	return os.Getenv("TF_ACC_LOG_PATH") != ""
+}
+
+// tflogHandler is a slog.Handler that forwards the API client's log entries
+// to tflog with the context they were logged with, so they appear in
+// Terraform's logs alongside the provider's own entries.
+type tflogHandler struct {
+	// trace enables client.LevelTrace entries, which buffer request and
+	// response bodies, so they are only built when Terraform keeps them.
+	trace bool
+
+	// debug logs client.LevelTrace entries at DEBUG. See KOSLI_DEBUG.
+	debug bool
+
+	attrs  []slog.Attr
+	prefix string // Group names joined with dots, each followed by a dot
+}
+
+var _ slog.Handler = &tflogHandler{}
+
+// newTFLogHandler returns a handler whose TRACE entries follow the
+// provider's log level, or are logged at DEBUG with KOSLI_DEBUG.
+func newTFLogHandler() *tflogHandler {
+	debug := cliDebugEnabled()
+	return &tflogHandler{trace: providerTraceEnabled() || debug, debug: debug}
+}
+
+// Enabled reports whether entries at level are forwarded. DEBUG and above
+// always are, since tflog filters them by level itself.
+func (h *tflogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level > client.LevelTrace || h.trace
+}
+
+// Handle forwards r to tflog at the matching level.
+func (h *tflogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make(map[string]any, len(h.attrs)+r.NumAttrs())
+	for _, a := range h.attrs {
+		fields[a.Key] = a.Value.Resolve().Any()
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fields[h.prefix+a.Key] = a.Value.Resolve().Any()
+		return true
+	})
+
+	switch {
+	case r.Level < slog.LevelDebug && !h.debug:
+		tflog.Trace(ctx, r.Message, fields)
+	case r.Level < slog.LevelInfo:
+		tflog.Debug(ctx, r.Message, fields)
+	case r.Level < slog.LevelWarn:
+		tflog.Info(ctx, r.Message, fields)
+	case r.Level < slog.LevelError:
+		tflog.Warn(ctx, r.Message, fields)
+	default:
+		tflog.Error(ctx, r.Message, fields)
+	}
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to every entry.
+func (h *tflogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		next.attrs = append(next.attrs, slog.Attr{Key: h.prefix + a.Key, Value: a.Value})
+	}
+	return &next
+}
+
+// WithGroup returns a handler that prefixes the keys of later attributes
+// with name.
+func (h *tflogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	next := *h
+	next.prefix = h.prefix + name + "."
+	return &next
+}
+
+// clientLoggingOptions returns client options that forward the client's logs
+// to tflog.
+func clientLoggingOptions() []client.ClientOption {
+	return []client.ClientOption{client.WithLogger(slog.New(newTFLogHandler()))}
+}
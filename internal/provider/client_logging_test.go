@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// clearProviderLogLevel unsets the environment variables that set the
+// provider's log level for the rest of the test.
+func clearProviderLogLevel(t *testing.T) {
+	for _, name := range append(providerLogLevelEnvVars, "TF_ACC_LOG_PATH") {
+		t.Setenv(name, "")
+	}
+}
+
+func TestProviderTraceEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"unset", nil, false},
+		{"TF_LOG trace", map[string]string{"TF_LOG": "trace"}, true},
+		{"TF_LOG json", map[string]string{"TF_LOG": "JSON"}, true},
+		{"TF_LOG debug", map[string]string{"TF_LOG": "DEBUG"}, false},
+		{"provider level overrides TF_LOG", map[string]string{"TF_LOG": "TRACE", "TF_LOG_PROVIDER": "DEBUG"}, false},
+		{"kosli level overrides provider level", map[string]string{"TF_LOG_PROVIDER": "DEBUG", "TF_LOG_PROVIDER_KOSLI": "TRACE"}, true},
+		{"acceptance test log", map[string]string{"TF_ACC_LOG_PATH": "/tmp/acc.log"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearProviderLogLevel(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			if got := providerTraceEnabled(); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTFLogHandler(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	logger := slog.New(&tflogHandler{trace: true}).With("client", "kosli")
+
+	logger.DebugContext(ctx, "Sending Kosli API request", "http_path", "/environments/acme")
+	logger.Log(ctx, client.LevelTrace, "Kosli API request details", "http_req_body", "{}")
+	logger.WarnContext(ctx, "Kosli API response contains a field the provider doesn't recognize")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d: %v", len(entries), entries)
+	}
+	for i, want := range []string{"debug", "trace", "warn"} {
+		if entries[i]["@level"] != want {
+			t.Errorf("Expected entry %d at level %s, got %v", i, want, entries[i]["@level"])
+		}
+		if entries[i]["client"] != "kosli" {
+			t.Errorf("Expected entry %d to carry the logger's attributes, got %v", i, entries[i])
+		}
+	}
+	if entries[0]["http_path"] != "/environments/acme" {
+		t.Errorf("Expected the entry's attributes as fields, got %v", entries[0])
+	}
+}
+
+func TestTFLogHandler_Debug(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	clearProviderLogLevel(t)
+	t.Setenv(cliDebugEnvVar, "true")
+	logger := slog.New(newTFLogHandler())
+
+	logger.Log(ctx, client.LevelTrace, "Kosli API request details", "http_req_body", "{}")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["@level"] != "debug" {
+		t.Errorf("Expected the details at DEBUG with %s, got %v", cliDebugEnvVar, entries)
+	}
+}
+
+func TestTFLogHandler_Enabled(t *testing.T) {
+	ctx := context.Background()
+
+	if h := (&tflogHandler{}); h.Enabled(ctx, client.LevelTrace) || !h.Enabled(ctx, slog.LevelDebug) {
+		t.Error("Expected only DEBUG and above to be enabled without trace")
+	}
+	if h := (&tflogHandler{trace: true}); !h.Enabled(ctx, client.LevelTrace) {
+		t.Error("Expected TRACE to be enabled with trace")
+	}
+}
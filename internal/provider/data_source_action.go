@@ -31,6 +31,9 @@ type actionDataSourceModel struct {
 	Number         types.Int64   `tfsdk:"number"`
 	CreatedBy      types.String  `tfsdk:"created_by"`
 	LastModifiedAt types.Float64 `tfsdk:"last_modified_at"`
+	FailIfNotFound types.Bool    `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool    `tfsdk:"exists"`
+	ID             types.String  `tfsdk:"id"`
 }
 
 // Metadata returns the data source type name.
@@ -48,6 +51,9 @@ func (d *actionDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Required:            true,
 				MarkdownDescription: "The name of the action to query.",
 			},
+			"fail_if_not_found": failIfNotFoundAttribute("action"),
+			"exists":            existsAttribute("action"),
+			"id":                idAttribute("the action name"),
 			"environments": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Computed:            true,
@@ -100,16 +106,25 @@ func (d *actionDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = lookupID(data.Name.ValueString())
 
 	action, err := d.client.GetActionByName(ctx, data.Name.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.Environments = types.ListNull(types.StringType)
+		data.Triggers = types.ListNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Action",
-			fmt.Sprintf("Could not read action %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read action %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
+	data.Exists = types.BoolValue(true)
 	data.Name = types.StringValue(action.Name)
 	data.Number = types.Int64Value(int64(action.Number))
 	data.CreatedBy = types.StringValue(action.CreatedBy)
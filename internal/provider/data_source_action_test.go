@@ -34,7 +34,7 @@ func TestActionDataSource_Schema(t *testing.T) {
 	}
 
 	attrs := resp.Schema.Attributes
-	expectedAttrs := []string{"name", "environments", "triggers", "number", "created_by", "last_modified_at"}
+	expectedAttrs := []string{"name", "fail_if_not_found", "exists", "id", "environments", "triggers", "number", "created_by", "last_modified_at"}
 	for _, attr := range expectedAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
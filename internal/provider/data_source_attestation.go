@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &attestationDataSource{}
+
+// NewAttestationDataSource creates a new attestation data source.
+func NewAttestationDataSource() datasource.DataSource {
+	return &attestationDataSource{}
+}
+
+// attestationDataSource defines the data source implementation.
+type attestationDataSource struct {
+	client *client.Client
+}
+
+// attestationDataSourceModel describes the data source data model.
+type attestationDataSourceModel struct {
+	Flow                types.String  `tfsdk:"flow"`
+	Trail               types.String  `tfsdk:"trail"`
+	Name                types.String  `tfsdk:"name"`
+	ArtifactFingerprint types.String  `tfsdk:"artifact_fingerprint"`
+	AttestationID       types.String  `tfsdk:"attestation_id"`
+	Type                types.String  `tfsdk:"type"`
+	Compliant           types.Bool    `tfsdk:"compliant"`
+	CreatedAt           types.Float64 `tfsdk:"created_at"`
+	URL                 types.String  `tfsdk:"url"`
+	UserData            types.String  `tfsdk:"user_data"`
+	Data                types.String  `tfsdk:"data"`
+	FailIfNotFound      types.Bool    `tfsdk:"fail_if_not_found"`
+	Exists              types.Bool    `tfsdk:"exists"`
+	ID                  types.String  `tfsdk:"id"`
+}
+
+// Metadata returns the data source type name.
+func (d *attestationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attestation"
+}
+
+// Schema defines the schema for the data source.
+func (d *attestationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the latest attestation with a given name on a trail, or on one of its artifacts, so configurations can branch on specific evidence. " +
+			"Use `jsondecode` on `user_data` or `data` to read the attestation's payload.",
+
+		Attributes: map[string]schema.Attribute{
+			"flow": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the flow.",
+			},
+			"trail": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the trail.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the attestation, e.g. its name in the flow template.",
+			},
+			"fail_if_not_found": failIfNotFoundAttribute("attestation"),
+			"exists":            existsAttribute("attestation"),
+			"id":                idAttribute("`<flow>/<trail>/<name>`"),
+			"artifact_fingerprint": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SHA256 fingerprint of the artifact whose attestation to fetch. If omitted, the attestation on the trail itself is fetched.",
+			},
+			"attestation_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the attestation.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The attestation type, e.g. `generic`, `junit` or the name of a custom attestation type.",
+			},
+			"compliant": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the attestation is compliant.",
+			},
+			"created_at": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unix timestamp of when the attestation was reported.",
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Link to the attestation in the Kosli app.",
+			},
+			"user_data": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "JSON-encoded custom data reported with the attestation. Null if none was reported.",
+			},
+			"data": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The complete JSON-encoded attestation, including the payload specific to its type (e.g. `sonar_results` or `junit_results`).",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *attestationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *attestationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data attestationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(data.Flow.ValueString(), data.Trail.ValueString(), data.Name.ValueString())
+
+	// Get the latest attestation from API
+	attestation, err := d.client.GetLatestAttestation(ctx, data.Flow.ValueString(), data.Trail.ValueString(), data.Name.ValueString(),
+		&client.GetLatestAttestationOptions{ArtifactFingerprint: data.ArtifactFingerprint.ValueString()})
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Attestation",
+			fmt.Sprintf("Could not read attestation %q on trail %q of flow %q: %s", data.Name.ValueString(), data.Trail.ValueString(), data.Flow.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	data.Exists = types.BoolValue(true)
+	mapAttestationToModel(attestation, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapAttestationToModel maps an API attestation into the data source model.
+func mapAttestationToModel(attestation *client.Attestation, data *attestationDataSourceModel) {
+	data.AttestationID = types.StringValue(attestation.ID)
+	data.Type = types.StringValue(attestation.Type)
+	data.Compliant = types.BoolValue(attestation.IsCompliant)
+	data.CreatedAt = types.Float64Value(attestation.CreatedAt)
+	data.URL = types.StringValue(attestation.HTMLURL)
+	data.Data = types.StringValue(string(attestation.Raw))
+
+	// Absent and JSON null user data are both "none reported"
+	data.UserData = types.StringNull()
+	if len(attestation.UserData) > 0 && string(attestation.UserData) != "null" {
+		data.UserData = types.StringValue(string(attestation.UserData))
+	}
+}
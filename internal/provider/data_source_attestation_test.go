@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestAttestationDataSource_Metadata(t *testing.T) {
+	d := &attestationDataSource{}
+
+	req := datasource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_attestation" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_attestation", resp.TypeName)
+	}
+}
+
+func TestAttestationDataSource_Schema(t *testing.T) {
+	d := &attestationDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	attrs := resp.Schema.Attributes
+	for _, name := range []string{"flow", "trail", "name"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsRequired() {
+			t.Errorf("Expected attribute %q to be required", name)
+		}
+	}
+	if !attrs["artifact_fingerprint"].IsOptional() {
+		t.Error("Expected 'artifact_fingerprint' to be optional")
+	}
+	for _, name := range []string{"attestation_id", "type", "compliant", "created_at", "url", "user_data", "data"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsComputed() {
+			t.Errorf("Expected attribute %q to be computed", name)
+		}
+	}
+}
+
+func TestAttestationDataSource_Configure(t *testing.T) {
+	d := &attestationDataSource{}
+
+	req := datasource.ConfigureRequest{ProviderData: nil}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = datasource.ConfigureRequest{ProviderData: "wrong-type"}
+	resp = &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected error when provider data is wrong type")
+	}
+}
+
+func TestMapAttestationToModel(t *testing.T) {
+	raw := json.RawMessage(`{"attestation_id":"a2","user_data":{"scanner":"sonarcloud"},"sonar_results":{}}`)
+
+	tests := []struct {
+		name         string
+		userData     json.RawMessage
+		wantUserData string // empty means null
+	}{
+		{"user data", json.RawMessage(`{"scanner":"sonarcloud"}`), `{"scanner":"sonarcloud"}`},
+		{"no user data", nil, ""},
+		{"null user data", json.RawMessage(`null`), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data attestationDataSourceModel
+			mapAttestationToModel(&client.Attestation{
+				ID:          "a2",
+				Type:        "sonar",
+				IsCompliant: true,
+				CreatedAt:   1700000200,
+				UserData:    tt.userData,
+				Raw:         raw,
+			}, &data)
+
+			if data.AttestationID.ValueString() != "a2" || data.Type.ValueString() != "sonar" || !data.Compliant.ValueBool() {
+				t.Errorf("Unexpected attestation fields: %+v", data)
+			}
+			if data.Data.ValueString() != string(raw) {
+				t.Errorf("Expected data %s, got %s", raw, data.Data.ValueString())
+			}
+			if tt.wantUserData == "" && !data.UserData.IsNull() {
+				t.Errorf("Expected null user_data, got %s", data.UserData.ValueString())
+			}
+			if tt.wantUserData != "" && data.UserData.ValueString() != tt.wantUserData {
+				t.Errorf("Expected user_data %s, got %s", tt.wantUserData, data.UserData.ValueString())
+			}
+		})
+	}
+}
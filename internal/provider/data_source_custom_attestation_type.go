@@ -26,11 +26,27 @@ type customAttestationTypeDataSource struct {
 
 // customAttestationTypeDataSourceModel describes the data source data model.
 type customAttestationTypeDataSourceModel struct {
-	Name        types.String         `tfsdk:"name"`
-	Description types.String         `tfsdk:"description"`
-	Schema      jsontypes.Normalized `tfsdk:"schema"`
-	JqRules     types.List           `tfsdk:"jq_rules"`
-	Archived    types.Bool           `tfsdk:"archived"`
+	Name        types.String                             `tfsdk:"name"`
+	Description types.String                             `tfsdk:"description"`
+	Schema      jsontypes.Normalized                     `tfsdk:"schema"`
+	JqRules     types.List                               `tfsdk:"jq_rules"`
+	Archived    types.Bool                               `tfsdk:"archived"`
+	Org         types.String                             `tfsdk:"org"`
+	Versions    []customAttestationTypeDataSourceVersion `tfsdk:"versions"`
+
+	FailIfNotFound types.Bool   `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool   `tfsdk:"exists"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// customAttestationTypeDataSourceVersion describes one version of the
+// custom attestation type.
+type customAttestationTypeDataSourceVersion struct {
+	Version   types.Int64          `tfsdk:"version"`
+	Timestamp types.Float64        `tfsdk:"timestamp"`
+	CreatedBy types.String         `tfsdk:"created_by"`
+	Schema    jsontypes.Normalized `tfsdk:"schema"`
+	JqRules   []types.String       `tfsdk:"jq_rules"`
 }
 
 // Metadata returns the data source type name.
@@ -48,6 +64,9 @@ func (d *customAttestationTypeDataSource) Schema(ctx context.Context, req dataso
 				Required:            true,
 				MarkdownDescription: "The name of the custom attestation type. Must start with a letter or number and contain only letters, numbers, periods, hyphens, underscores, and tildes.",
 			},
+			"fail_if_not_found": failIfNotFoundAttribute("custom attestation type"),
+			"exists":            existsAttribute("custom attestation type"),
+			"id":                idAttribute("the custom attestation type name"),
 			"description": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "A description of what this attestation type validates.",
@@ -66,6 +85,40 @@ func (d *customAttestationTypeDataSource) Schema(ctx context.Context, req dataso
 				Computed:            true,
 				MarkdownDescription: "Whether this attestation type has been archived.",
 			},
+			"org": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the organization the attestation type belongs to. Useful to confirm which organization was read in configurations with several provider aliases.",
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Every version of the attestation type, latest first. The top-level `schema` and `jq_rules` are those of the latest version.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The version number.",
+						},
+						"timestamp": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Unix timestamp (with fractional seconds) of when the version was created.",
+						},
+						"created_by": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "User who created the version.",
+						},
+						"schema": schema.StringAttribute{
+							Computed:            true,
+							CustomType:          jsontypes.NormalizedType{},
+							MarkdownDescription: "JSON Schema of the version.",
+						},
+						"jq_rules": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "jq evaluation rules of the version.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -99,22 +152,31 @@ func (d *customAttestationTypeDataSource) Read(ctx context.Context, req datasour
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = lookupID(data.Name.ValueString())
 
 	// Get attestation type from API
 	attestationType, err := d.client.GetCustomAttestationType(ctx, data.Name.ValueString(), nil)
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.JqRules = types.ListNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Custom Attestation Type",
-			fmt.Sprintf("Could not read custom attestation type %s: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read custom attestation type %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map response to model
+	data.Exists = types.BoolValue(true)
 	data.Name = types.StringValue(attestationType.Name)
 	data.Description = types.StringValue(attestationType.Description)
 	data.Schema = jsontypes.NewNormalizedValue(attestationType.Schema)
 	data.Archived = types.BoolValue(attestationType.Archived)
+	data.Org = types.StringValue(attestationType.Org)
 
 	// Convert jq_rules (API client already transformed from evaluator format)
 	jqRules := make([]types.String, 0, len(attestationType.JqRules))
@@ -129,6 +191,29 @@ func (d *customAttestationTypeDataSource) Read(ctx context.Context, req datasour
 	}
 	data.JqRules = jqRulesList
 
+	data.Versions = make([]customAttestationTypeDataSourceVersion, 0, len(attestationType.Versions))
+	for _, v := range attestationType.Versions {
+		versionSchema, rules, err := attestationType.VersionContent(v.Version)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Custom Attestation Type",
+				fmt.Sprintf("Could not read version %d of custom attestation type %s: %s", v.Version, data.Name.ValueString(), err),
+			)
+			return
+		}
+		version := customAttestationTypeDataSourceVersion{
+			Version:   types.Int64Value(int64(v.Version)),
+			Timestamp: types.Float64Value(v.Timestamp),
+			CreatedBy: types.StringValue(v.CreatedBy),
+			Schema:    jsontypes.NewNormalizedValue(versionSchema),
+			JqRules:   make([]types.String, 0, len(rules)),
+		}
+		for _, rule := range rules {
+			version.JqRules = append(version.JqRules, types.StringValue(rule))
+		}
+		data.Versions = append(data.Versions, version)
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -29,6 +29,10 @@ func TestAccCustomAttestationTypeDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSourceName, "jq_rules.0", resourceName, "jq_rules.0"),
 					// Verify archived is false
 					resource.TestCheckResourceAttr(dataSourceName, "archived", "false"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "org"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "versions.0.version", resourceName, "version"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "versions.0.jq_rules.#", resourceName, "jq_rules.#"),
 				),
 			},
 		},
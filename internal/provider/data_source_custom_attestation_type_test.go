@@ -41,7 +41,7 @@ func TestCustomAttestationTypeDataSource_Schema(t *testing.T) {
 
 	// Verify required attributes exist
 	attrs := resp.Schema.Attributes
-	requiredAttrs := []string{"name", "description", "schema", "jq_rules", "archived"}
+	requiredAttrs := []string{"name", "description", "schema", "jq_rules", "archived", "org", "versions"}
 	for _, attr := range requiredAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -77,6 +77,16 @@ func TestCustomAttestationTypeDataSource_Schema(t *testing.T) {
 	if archivedAttr.IsComputed() == false {
 		t.Error("Expected 'archived' attribute to be computed")
 	}
+
+	// Verify org is computed
+	if attrs["org"].IsComputed() == false {
+		t.Error("Expected 'org' attribute to be computed")
+	}
+
+	// Verify versions is computed
+	if attrs["versions"].IsComputed() == false {
+		t.Error("Expected 'versions' attribute to be computed")
+	}
 }
 
 func TestCustomAttestationTypeDataSource_Configure(t *testing.T) {
@@ -127,6 +137,7 @@ func TestCustomAttestationTypeDataSourceModel_Structure(t *testing.T) {
 		Schema:      jsontypes.NewNormalizedValue(`{"type": "object"}`),
 		JqRules:     types.ListNull(types.StringType),
 		Archived:    types.BoolValue(false),
+		Org:         types.StringValue("test-org"),
 	}
 
 	if model.Name.ValueString() != "test-attestation" {
@@ -144,6 +155,10 @@ func TestCustomAttestationTypeDataSourceModel_Structure(t *testing.T) {
 	if model.Archived.ValueBool() != false {
 		t.Error("Expected Archived to be false")
 	}
+
+	if model.Org.ValueString() != "test-org" {
+		t.Error("Expected Org to be set correctly")
+	}
 }
 
 func TestNewCustomAttestationTypeDataSource(t *testing.T) {
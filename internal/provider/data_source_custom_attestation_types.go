@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &customAttestationTypesDataSource{}
+
+// NewCustomAttestationTypesDataSource creates a new custom attestation types data source.
+func NewCustomAttestationTypesDataSource() datasource.DataSource {
+	return &customAttestationTypesDataSource{}
+}
+
+// customAttestationTypesDataSource defines the data source implementation.
+type customAttestationTypesDataSource struct {
+	client *client.Client
+}
+
+// customAttestationTypesDataSourceModel describes the data source data model.
+type customAttestationTypesDataSourceModel struct {
+	NamePrefix             types.String                           `tfsdk:"name_prefix"`
+	Archived               types.Bool                             `tfsdk:"archived"`
+	CustomAttestationTypes []customAttestationTypesDataSourceItem `tfsdk:"custom_attestation_types"`
+	Exists                 types.Bool                             `tfsdk:"exists"`
+	ID                     types.String                           `tfsdk:"id"`
+}
+
+// customAttestationTypesDataSourceItem describes one listed custom attestation type.
+type customAttestationTypesDataSourceItem struct {
+	Name        types.String         `tfsdk:"name"`
+	Description types.String         `tfsdk:"description"`
+	Schema      jsontypes.Normalized `tfsdk:"schema"`
+	JqRules     types.List           `tfsdk:"jq_rules"`
+	Archived    types.Bool           `tfsdk:"archived"`
+}
+
+// Metadata returns the data source type name.
+func (d *customAttestationTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_attestation_types"
+}
+
+// Schema defines the schema for the data source.
+func (d *customAttestationTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the custom attestation types in the Kosli organization, optionally filtered by name prefix and archived status.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list attestation types whose name starts with this prefix.",
+			},
+			"archived": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list archived (`true`) or active (`false`) attestation types. Lists both when unset.",
+			},
+			"exists": alwaysExistsAttribute(),
+			"id":     idAttribute("the organization name"),
+			"custom_attestation_types": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching custom attestation types, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the custom attestation type.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "A description of what this attestation type validates.",
+						},
+						"schema": schema.StringAttribute{
+							Computed:            true,
+							CustomType:          jsontypes.NormalizedType{},
+							MarkdownDescription: "JSON Schema of the latest version.",
+						},
+						"jq_rules": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "jq evaluation rules of the latest version.",
+						},
+						"archived": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether this attestation type has been archived.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *customAttestationTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *customAttestationTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data customAttestationTypesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(d.client.Organization())
+
+	// Archived types are requested unless only active ones are listed
+	opts := &client.ListCustomAttestationTypesOptions{
+		IncludeArchived: data.Archived.IsNull() || data.Archived.ValueBool(),
+		NamePrefix:      data.NamePrefix.ValueString(),
+	}
+	if !data.Archived.IsNull() {
+		archived := data.Archived.ValueBool()
+		opts.Archived = &archived
+	}
+
+	// List attestation types from API
+	attestationTypes, err := d.client.ListCustomAttestationTypes(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Custom Attestation Types",
+			fmt.Sprintf("Could not list custom attestation types: %s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response to model
+	data.CustomAttestationTypes = make([]customAttestationTypesDataSourceItem, 0, len(attestationTypes))
+	for _, at := range attestationTypes {
+		// Use an empty rather than null list for types without rules
+		rules := make([]string, 0, len(at.JqRules))
+		rules = append(rules, at.JqRules...)
+		jqRules, diags := types.ListValueFrom(ctx, types.StringType, rules)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.CustomAttestationTypes = append(data.CustomAttestationTypes, customAttestationTypesDataSourceItem{
+			Name:        types.StringValue(at.Name),
+			Description: types.StringValue(at.Description),
+			Schema:      jsontypes.NewNormalizedValue(at.Schema),
+			JqRules:     jqRules,
+			Archived:    types.BoolValue(at.Archived),
+		})
+	}
+
+	data.Exists = types.BoolValue(true)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
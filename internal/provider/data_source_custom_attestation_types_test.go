@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestCustomAttestationTypesDataSource_Metadata(t *testing.T) {
+	d := &customAttestationTypesDataSource{}
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "kosli",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	expectedTypeName := "kosli_custom_attestation_types"
+	if resp.TypeName != expectedTypeName {
+		t.Errorf("Expected TypeName %q, got %q", expectedTypeName, resp.TypeName)
+	}
+}
+
+func TestCustomAttestationTypesDataSource_Schema(t *testing.T) {
+	d := &customAttestationTypesDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema method returned errors: %v", resp.Diagnostics.Errors())
+	}
+
+	attrs := resp.Schema.Attributes
+
+	// Verify filters are optional
+	for _, name := range []string{"name_prefix", "archived"} {
+		attr, exists := attrs[name]
+		if !exists {
+			t.Fatalf("Expected attribute %q to exist in schema", name)
+		}
+		if !attr.IsOptional() {
+			t.Errorf("Expected %q attribute to be optional", name)
+		}
+	}
+
+	// Verify the result list is computed
+	listAttr, exists := attrs["custom_attestation_types"]
+	if !exists {
+		t.Fatal("Expected attribute \"custom_attestation_types\" to exist in schema")
+	}
+	if !listAttr.IsComputed() {
+		t.Error("Expected 'custom_attestation_types' attribute to be computed")
+	}
+}
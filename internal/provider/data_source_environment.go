@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -25,13 +26,25 @@ type environmentDataSource struct {
 
 // environmentDataSourceModel describes the data source data model.
 type environmentDataSourceModel struct {
-	Name           types.String  `tfsdk:"name"`
-	Type           types.String  `tfsdk:"type"`
-	Description    types.String  `tfsdk:"description"`
-	IncludeScaling types.Bool    `tfsdk:"include_scaling"`
-	LastModifiedAt types.Float64 `tfsdk:"last_modified_at"`
-	LastReportedAt types.Float64 `tfsdk:"last_reported_at"`
-	Tags           types.Map     `tfsdk:"tags"`
+	Name              types.String  `tfsdk:"name"`
+	Type              types.String  `tfsdk:"type"`
+	Description       types.String  `tfsdk:"description"`
+	IncludeScaling    types.Bool    `tfsdk:"include_scaling"`
+	RequireProvenance types.Bool    `tfsdk:"require_provenance"`
+	LastModifiedAt    types.Float64 `tfsdk:"last_modified_at"`
+	LastReportedAt    types.Float64 `tfsdk:"last_reported_at"`
+	Tags              types.Map     `tfsdk:"tags"`
+	Policies          types.List    `tfsdk:"policies"`
+	State             types.String  `tfsdk:"state"`
+
+	ComplianceStatus types.String  `tfsdk:"compliance_status"`
+	CompliantSince   types.Float64 `tfsdk:"compliant_since"`
+
+	LatestSnapshotIndex types.Int64 `tfsdk:"latest_snapshot_index"`
+
+	FailIfNotFound types.Bool   `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool   `tfsdk:"exists"`
+	ID             types.String `tfsdk:"id"`
 }
 
 // Metadata returns the data source type name.
@@ -49,6 +62,9 @@ func (d *environmentDataSource) Schema(ctx context.Context, req datasource.Schem
 				Required:            true,
 				MarkdownDescription: "The name of the environment to query.",
 			},
+			"fail_if_not_found": failIfNotFoundAttribute("environment"),
+			"exists":            existsAttribute("environment"),
+			"id":                idAttribute("the environment name"),
 			"type": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The environment type (e.g., K8S, ECS, S3, docker, server, lambda).",
@@ -61,6 +77,10 @@ func (d *environmentDataSource) Schema(ctx context.Context, req datasource.Schem
 				Computed:            true,
 				MarkdownDescription: "Whether the environment includes scaling events in snapshots.",
 			},
+			"require_provenance": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the environment requires artifacts to have provenance to be compliant.",
+			},
 			"last_modified_at": schema.Float64Attribute{
 				Computed:            true,
 				MarkdownDescription: "Unix timestamp (with fractional seconds) of when the environment was last modified.",
@@ -74,6 +94,27 @@ func (d *environmentDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "Key-value pairs tagging the environment.",
 				ElementType:         types.StringType,
 			},
+			"policies": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "Names of the policies attached to the environment.",
+				ElementType:         types.StringType,
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The environment state reported by Kosli, JSON-encoded. Use `jsondecode()` to inspect it. Null if the API reports no state.",
+			},
+			"compliance_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Compliance of the latest snapshot reported for the environment: `COMPLIANT`, `NON-COMPLIANT`, or `UNKNOWN` if the environment has never reported a snapshot or its snapshots could not be read.",
+			},
+			"compliant_since": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unix timestamp (with fractional seconds) of the first snapshot of the current unbroken run of compliant snapshots, looking back at most 10 pages of snapshots; for a longer run, the timestamp of the oldest snapshot in those pages. Null unless `compliance_status` is `COMPLIANT`.",
+			},
+			"latest_snapshot_index": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The 1-based index of the latest snapshot reported for the environment, read once per plan so that other lookups can target that exact snapshot even if the environment reports again meanwhile. Null if the environment has never reported a snapshot.",
+			},
 		},
 	}
 }
@@ -107,18 +148,27 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = lookupID(data.Name.ValueString())
 
 	// Get environment from API
 	env, err := d.client.GetEnvironment(ctx, data.Name.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.Tags = types.MapNull(types.StringType)
+		data.Policies = types.ListNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Environment",
-			fmt.Sprintf("Could not read environment %s: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read environment %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to data source model
+	data.Exists = types.BoolValue(true)
 	data.Name = types.StringValue(env.Name)
 	data.Type = types.StringValue(env.Type)
 
@@ -130,6 +180,7 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	data.IncludeScaling = types.BoolValue(env.IncludeScaling)
+	data.RequireProvenance = types.BoolValue(env.RequireProvenance)
 	data.LastModifiedAt = types.Float64Value(env.LastModifiedAt)
 
 	// Handle nullable LastReportedAt
@@ -151,6 +202,70 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 	data.Tags = tagsValue
 
+	// Flatten attached policies to their names
+	attached, err := env.AttachedPolicies()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment",
+			fmt.Sprintf("Could not read policies of environment %s: %s", data.Name.ValueString(), err),
+		)
+		return
+	}
+	policyNames := make([]string, 0, len(attached))
+	for _, p := range attached {
+		policyNames = append(policyNames, p.Name)
+	}
+	policiesValue, diags := types.ListValueFrom(ctx, types.StringType, policyNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Policies = policiesValue
+
+	// State can be any JSON value, so expose it JSON-encoded
+	if env.State == nil {
+		data.State = types.StringNull()
+	} else {
+		state, err := json.Marshal(env.State)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Environment",
+				fmt.Sprintf("Could not encode state of environment %s: %s", data.Name.ValueString(), err),
+			)
+			return
+		}
+		data.State = types.StringValue(string(state))
+	}
+
+	data.ComplianceStatus, data.CompliantSince = environmentCompliance(ctx, d.client, env, &resp.Diagnostics)
+
+	data.LatestSnapshotIndex, err = latestSnapshotIndex(ctx, d.client, env)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment Snapshot",
+			fmt.Sprintf("Could not read the latest snapshot of environment %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// latestSnapshotIndex returns the index of env's latest snapshot, or null if
+// the environment has never reported one.
+func latestSnapshotIndex(ctx context.Context, c *client.Client, env *client.Environment) (types.Int64, error) {
+	if env.LastReportedAt == nil {
+		return types.Int64Null(), nil
+	}
+
+	snapshot, err := c.GetLatestSnapshot(ctx, env.Name)
+	if client.IsNotFound(err) {
+		return types.Int64Null(), nil
+	}
+	if err != nil {
+		return types.Int64Null(), err
+	}
+
+	return types.Int64Value(int64(snapshot.Index)), nil
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &environmentRunningArtifactsDataSource{}
+
+// NewEnvironmentRunningArtifactsDataSource creates a new environment running
+// artifacts data source.
+func NewEnvironmentRunningArtifactsDataSource() datasource.DataSource {
+	return &environmentRunningArtifactsDataSource{}
+}
+
+// environmentRunningArtifactsDataSource defines the data source
+// implementation.
+type environmentRunningArtifactsDataSource struct {
+	client *client.Client
+}
+
+// environmentRunningArtifactsDataSourceModel describes the data source data
+// model.
+type environmentRunningArtifactsDataSourceModel struct {
+	Environment    types.String           `tfsdk:"environment"`
+	FailIfNotFound types.Bool             `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool             `tfsdk:"exists"`
+	ID             types.String           `tfsdk:"id"`
+	SnapshotIndex  types.Int64            `tfsdk:"snapshot_index"`
+	Artifacts      []runningArtifactModel `tfsdk:"artifacts"`
+}
+
+// runningArtifactModel describes one artifact running in the environment.
+type runningArtifactModel struct {
+	Name        types.String  `tfsdk:"name"`
+	Fingerprint types.String  `tfsdk:"fingerprint"`
+	Since       types.Float64 `tfsdk:"since"`
+	Instances   types.Int64   `tfsdk:"instances"`
+}
+
+// Metadata returns the data source type name.
+func (d *environmentRunningArtifactsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_running_artifacts"
+}
+
+// Schema defines the schema for the data source.
+func (d *environmentRunningArtifactsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the artifacts running in a Kosli environment according to its latest snapshot, e.g. for drift-detection jobs that compare what is deployed against what Terraform declares. " +
+			"An environment that has never reported a snapshot is treated as not found.",
+
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the environment to query.",
+			},
+			"fail_if_not_found": failIfNotFoundAttribute("environment"),
+			"exists":            existsAttribute("environment"),
+			"id":                idAttribute("the environment name"),
+			"snapshot_index": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The 1-based index of the latest snapshot in the environment's history.",
+			},
+			"artifacts": runningArtifactsAttribute("The artifacts running in the environment, ordered by name and fingerprint."),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *environmentRunningArtifactsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *environmentRunningArtifactsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentRunningArtifactsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(data.Environment.ValueString())
+
+	snapshot, err := d.client.GetLatestSnapshot(ctx, data.Environment.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.SnapshotIndex = types.Int64Null()
+		data.Artifacts = nil
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment Snapshot",
+			fmt.Sprintf("Could not read the latest snapshot of environment %s: %s", data.Environment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	mapRunningArtifactsToModel(snapshot, &data)
+	data.Exists = types.BoolValue(true)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapRunningArtifactsToModel maps the artifacts of a snapshot into the data
+// source model.
+func mapRunningArtifactsToModel(snapshot *client.Snapshot, data *environmentRunningArtifactsDataSourceModel) {
+	data.SnapshotIndex = types.Int64Value(int64(snapshot.Index))
+	data.Artifacts = runningArtifacts(snapshot)
+}
+
+// runningArtifactsAttribute returns the schema of a list of running artifacts
+// as built by runningArtifacts.
+func runningArtifactsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The name of the artifact, e.g. the container image or file path.",
+				},
+				"fingerprint": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The SHA-256 fingerprint of the artifact.",
+				},
+				"since": schema.Float64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Unix timestamp of when the oldest running instance of the artifact was started.",
+				},
+				"instances": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The number of running instances of the artifact, e.g. pod replicas.",
+				},
+			},
+		},
+	}
+}
+
+// runningArtifacts returns the artifacts of a snapshot ordered by name and
+// fingerprint, so that reads are stable.
+func runningArtifacts(snapshot *client.Snapshot) []runningArtifactModel {
+	artifacts := make([]runningArtifactModel, 0, len(snapshot.Artifacts))
+
+	for _, a := range snapshot.Artifacts {
+		since := types.Float64Null()
+		if len(a.CreationTimestamps) > 0 {
+			since = types.Float64Value(slices.Min(a.CreationTimestamps))
+		}
+		artifacts = append(artifacts, runningArtifactModel{
+			Name:        types.StringValue(a.Name),
+			Fingerprint: types.StringValue(a.Fingerprint),
+			Since:       since,
+			Instances:   types.Int64Value(int64(len(a.CreationTimestamps))),
+		})
+	}
+
+	slices.SortFunc(artifacts, func(a, b runningArtifactModel) int {
+		return cmp.Or(
+			cmp.Compare(a.Name.ValueString(), b.Name.ValueString()),
+			cmp.Compare(a.Fingerprint.ValueString(), b.Fingerprint.ValueString()),
+		)
+	})
+
+	return artifacts
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestEnvironmentRunningArtifactsDataSource_Metadata(t *testing.T) {
+	d := &environmentRunningArtifactsDataSource{}
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "kosli",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	expectedTypeName := "kosli_environment_running_artifacts"
+	if resp.TypeName != expectedTypeName {
+		t.Errorf("Expected TypeName %q, got %q", expectedTypeName, resp.TypeName)
+	}
+}
+
+func TestEnvironmentRunningArtifactsDataSource_Schema(t *testing.T) {
+	d := &environmentRunningArtifactsDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema method returned errors: %v", resp.Diagnostics.Errors())
+	}
+
+	attrs := resp.Schema.Attributes
+	if attr, exists := attrs["environment"]; !exists || !attr.IsRequired() {
+		t.Error("Expected 'environment' attribute to be required")
+	}
+	for _, name := range []string{"snapshot_index", "artifacts", "exists", "id"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsComputed() {
+			t.Errorf("Expected attribute %q to be computed", name)
+		}
+	}
+}
+
+func TestEnvironmentRunningArtifactsDataSource_Configure(t *testing.T) {
+	d := &environmentRunningArtifactsDataSource{}
+
+	req := datasource.ConfigureRequest{ProviderData: nil}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = datasource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
+
+func TestMapRunningArtifactsToModel(t *testing.T) {
+	snapshot := &client.Snapshot{
+		Index: 7,
+		Artifacts: []client.SnapshotArtifact{
+			{Name: "acme/frontend:2.0.0", Fingerprint: "b2", CreationTimestamps: []float64{1700000200}},
+			{Name: "acme/backend:1.4.2", Fingerprint: "a1", CreationTimestamps: []float64{1700000100, 1700000050, 1700000150}},
+			{Name: "acme/backend:1.4.1", Fingerprint: "a0"},
+		},
+	}
+
+	var data environmentRunningArtifactsDataSourceModel
+	mapRunningArtifactsToModel(snapshot, &data)
+
+	if data.SnapshotIndex.ValueInt64() != 7 {
+		t.Errorf("Expected snapshot index 7, got %v", data.SnapshotIndex)
+	}
+	if len(data.Artifacts) != 3 {
+		t.Fatalf("Expected 3 artifacts, got %d", len(data.Artifacts))
+	}
+	for i, want := range []string{"acme/backend:1.4.1", "acme/backend:1.4.2", "acme/frontend:2.0.0"} {
+		if got := data.Artifacts[i].Name.ValueString(); got != want {
+			t.Errorf("Expected artifact %d to be %q, got %q", i, want, got)
+		}
+	}
+
+	backend := data.Artifacts[1]
+	if backend.Since.ValueFloat64() != 1700000050 || backend.Instances.ValueInt64() != 3 {
+		t.Errorf("Expected the oldest of 3 instances, got since %v with %v instances", backend.Since, backend.Instances)
+	}
+	if !data.Artifacts[0].Since.IsNull() || data.Artifacts[0].Instances.ValueInt64() != 0 {
+		t.Errorf("Expected no start time without instances, got %v", data.Artifacts[0].Since)
+	}
+}
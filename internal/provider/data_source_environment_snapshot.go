@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &environmentSnapshotDataSource{}
+
+// NewEnvironmentSnapshotDataSource creates a new environment snapshot data
+// source.
+func NewEnvironmentSnapshotDataSource() datasource.DataSource {
+	return &environmentSnapshotDataSource{}
+}
+
+// environmentSnapshotDataSource defines the data source implementation.
+type environmentSnapshotDataSource struct {
+	client *client.Client
+}
+
+// environmentSnapshotDataSourceModel describes the data source data model.
+type environmentSnapshotDataSourceModel struct {
+	Selector       types.String           `tfsdk:"selector"`
+	FailIfNotFound types.Bool             `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool             `tfsdk:"exists"`
+	ID             types.String           `tfsdk:"id"`
+	Environment    types.String           `tfsdk:"environment"`
+	Index          types.Int64            `tfsdk:"index"`
+	Timestamp      types.Float64          `tfsdk:"timestamp"`
+	Compliant      types.Bool             `tfsdk:"compliant"`
+	Artifacts      []runningArtifactModel `tfsdk:"artifacts"`
+}
+
+// Metadata returns the data source type name.
+func (d *environmentSnapshotDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_snapshot"
+}
+
+// Schema defines the schema for the data source.
+func (d *environmentSnapshotDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a snapshot of a Kosli environment: the artifacts that were running in it at a point in its history. " +
+			"Use it for historical queries such as what ran three snapshots ago, or pin `kosli_environment.latest_snapshot_index` to read the latest snapshot deterministically within one plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"selector": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "The snapshot to fetch, in Kosli's snapshot selector syntax: `ENV` for the latest snapshot of environment `ENV`, " +
+					"`ENV#N` for snapshot number `N`, `ENV~N` for the snapshot `N` snapshots before the latest, and `ENV@{TIMESTAMP}` for the snapshot running at a Unix timestamp or ISO 8601 date.",
+				Validators: []validator.String{snapshotSelectorValidator{}},
+			},
+			"fail_if_not_found": failIfNotFoundAttribute("snapshot"),
+			"exists":            existsAttribute("snapshot"),
+			"id":                idAttribute("the selector"),
+			"environment": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the environment the snapshot belongs to.",
+			},
+			"index": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The 1-based index of the snapshot in the environment's history.",
+			},
+			"timestamp": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unix timestamp (with fractional seconds) of when the snapshot was reported.",
+			},
+			"compliant": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the environment was compliant in the snapshot.",
+			},
+			"artifacts": runningArtifactsAttribute("The artifacts running in the snapshot, ordered by name and fingerprint."),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *environmentSnapshotDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *environmentSnapshotDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentSnapshotDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(data.Selector.ValueString())
+
+	// The validator only sees known values, so parse again here
+	sel, err := client.ParseSnapshotSelector(data.Selector.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Snapshot Selector", err.Error())
+		return
+	}
+	data.Environment = types.StringValue(sel.Environment)
+
+	snapshot, err := d.client.GetSnapshot(ctx, sel)
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.Index = types.Int64Null()
+		data.Timestamp = types.Float64Null()
+		data.Compliant = types.BoolNull()
+		data.Artifacts = nil
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment Snapshot",
+			fmt.Sprintf("Could not read snapshot %s: %s", sel, apiErrorDetail(err)),
+		)
+		return
+	}
+
+	data.Exists = types.BoolValue(true)
+	data.Index = types.Int64Value(int64(snapshot.Index))
+	data.Timestamp = types.Float64Value(snapshot.Timestamp)
+	data.Compliant = types.BoolValue(snapshot.Compliant)
+	data.Artifacts = runningArtifacts(snapshot)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestEnvironmentSnapshotDataSource_Metadata(t *testing.T) {
+	d := &environmentSnapshotDataSource{}
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "kosli",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	expectedTypeName := "kosli_environment_snapshot"
+	if resp.TypeName != expectedTypeName {
+		t.Errorf("Expected TypeName %q, got %q", expectedTypeName, resp.TypeName)
+	}
+}
+
+func TestEnvironmentSnapshotDataSource_Schema(t *testing.T) {
+	d := &environmentSnapshotDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema method returned errors: %v", resp.Diagnostics.Errors())
+	}
+
+	attrs := resp.Schema.Attributes
+	if attr, exists := attrs["selector"]; !exists || !attr.IsRequired() {
+		t.Error("Expected 'selector' attribute to be required")
+	}
+	for _, name := range []string{"environment", "index", "timestamp", "compliant", "artifacts", "exists", "id"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsComputed() {
+			t.Errorf("Expected attribute %q to be computed", name)
+		}
+	}
+}
+
+func TestEnvironmentSnapshotDataSource_Configure(t *testing.T) {
+	d := &environmentSnapshotDataSource{}
+
+	req := datasource.ConfigureRequest{ProviderData: nil}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = datasource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
+
+func TestEnvironmentSnapshotDataSource_Read(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/snapshots/test-org/production/~3" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"index": 39, "timestamp": 1700000000, "compliant": true, "artifacts": [
+			{"name": "acme/frontend:2.0.0", "fingerprint": "b2", "creationTimestamp": [1699990000]},
+			{"name": "acme/backend:1.4.2", "fingerprint": "a1", "creationTimestamp": [1699990100, 1699990050]}
+		]}`))
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	d := &environmentSnapshotDataSource{client: c}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for attr, attrType := range objectType.AttributeTypes {
+		values[attr] = tftypes.NewValue(attrType, nil)
+	}
+	values["selector"] = tftypes.NewValue(tftypes.String, "production~3")
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	d.Read(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+	}
+	var data environmentSnapshotDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Environment.ValueString() != "production" || data.Index.ValueInt64() != 39 || !data.Compliant.ValueBool() || data.ID.ValueString() != "production~3" {
+		t.Errorf("Unexpected snapshot: %+v", data)
+	}
+	if len(data.Artifacts) != 2 || data.Artifacts[0].Name.ValueString() != "acme/backend:1.4.2" || data.Artifacts[0].Since.ValueFloat64() != 1699990050 {
+		t.Errorf("Unexpected artifacts: %+v", data.Artifacts)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &environmentTagsDataSource{}
+
+// NewEnvironmentTagsDataSource creates a new environment tags data source.
+func NewEnvironmentTagsDataSource() datasource.DataSource {
+	return &environmentTagsDataSource{}
+}
+
+// environmentTagsDataSource defines the data source implementation.
+type environmentTagsDataSource struct {
+	client *client.Client
+}
+
+// environmentTagsDataSourceModel describes the data source data model.
+type environmentTagsDataSourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	Tags           types.Map    `tfsdk:"tags"`
+	FailIfNotFound types.Bool   `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool   `tfsdk:"exists"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// Metadata returns the data source type name.
+func (d *environmentTagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_tags"
+}
+
+// Schema defines the schema for the data source.
+func (d *environmentTagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches only the tags of an existing Kosli environment (physical or logical), e.g. to reuse its labels on other resources without reading the whole environment.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the environment to query.",
+			},
+			"fail_if_not_found": failIfNotFoundAttribute("environment"),
+			"exists":            existsAttribute("environment"),
+			"id":                idAttribute("the environment name"),
+			"tags": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Key-value pairs tagging the environment. Empty if the environment has no tags.",
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *environmentTagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *environmentTagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentTagsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(data.Name.ValueString())
+
+	tags, err := d.client.ListEnvironmentTags(ctx, data.Name.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.Tags = types.MapNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment Tags",
+			fmt.Sprintf("Could not read tags of environment %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tags = tagsValue
+	data.Exists = types.BoolValue(true)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestEnvironmentTagsDataSource_Metadata(t *testing.T) {
+	d := &environmentTagsDataSource{}
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "kosli",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	expectedTypeName := "kosli_environment_tags"
+	if resp.TypeName != expectedTypeName {
+		t.Errorf("Expected TypeName %q, got %q", expectedTypeName, resp.TypeName)
+	}
+}
+
+func TestEnvironmentTagsDataSource_Schema(t *testing.T) {
+	d := &environmentTagsDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema method returned errors: %v", resp.Diagnostics.Errors())
+	}
+
+	attrs := resp.Schema.Attributes
+	if attr, exists := attrs["name"]; !exists || !attr.IsRequired() {
+		t.Error("Expected 'name' attribute to be required")
+	}
+	if attr, exists := attrs["tags"]; !exists || !attr.IsComputed() {
+		t.Error("Expected 'tags' attribute to be computed")
+	}
+}
+
+func TestEnvironmentTagsDataSource_Configure(t *testing.T) {
+	d := &environmentTagsDataSource{}
+
+	req := datasource.ConfigureRequest{ProviderData: nil}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = datasource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
@@ -2,10 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
 func TestEnvironmentDataSource_Metadata(t *testing.T) {
@@ -40,7 +44,7 @@ func TestEnvironmentDataSource_Schema(t *testing.T) {
 
 	// Verify required attributes exist
 	attrs := resp.Schema.Attributes
-	requiredAttrs := []string{"name", "type", "description", "include_scaling", "last_modified_at", "last_reported_at", "tags"}
+	requiredAttrs := []string{"name", "type", "description", "include_scaling", "last_modified_at", "last_reported_at", "tags", "policies", "require_provenance", "state", "compliance_status", "compliant_since", "latest_snapshot_index"}
 	for _, attr := range requiredAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -260,6 +264,46 @@ func TestEnvironmentDataSource_Implements(t *testing.T) {
 	var _ datasource.DataSource = &environmentDataSource{}
 }
 
+func TestLatestSnapshotIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/snapshots/test-org/production/latest":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(client.Snapshot{Index: 42, Timestamp: 1700000000})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	reported := 1700000000.0
+
+	tests := []struct {
+		name string
+		env  *client.Environment
+		want types.Int64
+	}{
+		{"latest snapshot", &client.Environment{Name: "production", LastReportedAt: &reported}, types.Int64Value(42)},
+		{"never reported", &client.Environment{Name: "production"}, types.Int64Null()},
+		{"no snapshots", &client.Environment{Name: "staging", LastReportedAt: &reported}, types.Int64Null()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := latestSnapshotIndex(context.Background(), c, tt.env)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
 // Note: Full Read operation tests require acceptance testing (issue #72)
 // These tests verify the data source structure and basic configuration,
 // while acceptance tests will verify the full read operation against a real API.
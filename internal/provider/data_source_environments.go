@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &environmentsDataSource{}
+
+// NewEnvironmentsDataSource creates a new environments data source.
+func NewEnvironmentsDataSource() datasource.DataSource {
+	return &environmentsDataSource{}
+}
+
+// environmentsDataSource defines the data source implementation.
+type environmentsDataSource struct {
+	client *client.Client
+}
+
+// environmentsDataSourceModel describes the data source data model.
+type environmentsDataSourceModel struct {
+	Type               types.String                          `tfsdk:"type"`
+	IncludeArchived    types.Bool                            `tfsdk:"include_archived"`
+	Tags               types.Map                             `tfsdk:"tags"`
+	PageSize           types.Int64                           `tfsdk:"page_size"`
+	Environments       []environmentsDataSourceItem          `tfsdk:"environments"`
+	EnvironmentsByName map[string]environmentsDataSourceItem `tfsdk:"environments_by_name"`
+	EnvironmentsByType map[string][]types.String             `tfsdk:"environments_by_type"`
+	Exists             types.Bool                            `tfsdk:"exists"`
+	ID                 types.String                          `tfsdk:"id"`
+}
+
+// environmentsDataSourceItem describes one listed environment.
+type environmentsDataSourceItem struct {
+	Name           types.String  `tfsdk:"name"`
+	Type           types.String  `tfsdk:"type"`
+	Description    types.String  `tfsdk:"description"`
+	LastModifiedAt types.Float64 `tfsdk:"last_modified_at"`
+	LastReportedAt types.Float64 `tfsdk:"last_reported_at"`
+	Tags           types.Map     `tfsdk:"tags"`
+}
+
+// Metadata returns the data source type name.
+func (d *environmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environments"
+}
+
+// environmentsDataSourceItemAttributes returns the attributes of a listed environment.
+func environmentsDataSourceItemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name of the environment.",
+		},
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The environment type (e.g., K8S, ECS, S3, docker, server, lambda, logical).",
+		},
+		"description": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The description of the environment.",
+		},
+		"last_modified_at": schema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Unix timestamp (with fractional seconds) of when the environment was last modified.",
+		},
+		"last_reported_at": schema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Unix timestamp (with fractional seconds) of when the environment was last reported. May be null if never reported.",
+		},
+		"tags": schema.MapAttribute{
+			Computed:            true,
+			MarkdownDescription: "Key-value pairs tagging the environment.",
+			ElementType:         types.StringType,
+		},
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *environmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the environments in the Kosli organization, optionally filtered by type and tags. Besides the list, the environments are keyed by name and grouped by type so they can be used with `for_each` directly.",
+
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list environments of this type (e.g., `K8S` or `logical`).",
+			},
+			"include_archived": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Also list archived environments. Defaults to `false`.",
+			},
+			"tags": schema.MapAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list environments carrying all of these tags.",
+				ElementType:         types.StringType,
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of environments fetched per API request. All pages are always read; a smaller page size can help organizations with many environments whose requests time out. Defaults to the provider's `default_page_size`, or `%d`.", client.DefaultEnvironmentsPageSize),
+				Validators: []validator.Int64{
+					atLeastValidator{min: 1},
+				},
+			},
+			"exists": alwaysExistsAttribute(),
+			"id":     idAttribute("the organization name"),
+			"environments": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching environments, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: environmentsDataSourceItemAttributes(),
+				},
+			},
+			"environments_by_name": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching environments keyed by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: environmentsDataSourceItemAttributes(),
+				},
+			},
+			"environments_by_type": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Names of the matching environments grouped by environment type.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *environmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(d.client.Organization())
+
+	opts := &client.ListEnvironmentsOptions{
+		Type:            data.Type.ValueString(),
+		IncludeArchived: data.IncludeArchived.ValueBool(),
+		PerPage:         int(data.PageSize.ValueInt64()),
+	}
+	if !data.Tags.IsNull() {
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &opts.Tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// List environments from API
+	envs, err := d.client.ListAllEnvironments(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Environments",
+			fmt.Sprintf("Could not list environments: %s", apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Map response to model
+	data.Environments = make([]environmentsDataSourceItem, 0, len(envs))
+	data.EnvironmentsByName = make(map[string]environmentsDataSourceItem, len(envs))
+	data.EnvironmentsByType = make(map[string][]types.String)
+	for _, env := range envs {
+		// The API may not apply every filter, so check them again
+		if !environmentMatches(env, opts.Type, opts.Tags) {
+			continue
+		}
+
+		// Normalize nil tags to an empty map, as the environment data source does
+		tags := env.Tags
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		item := environmentsDataSourceItem{
+			Name:           types.StringValue(env.Name),
+			Type:           types.StringValue(env.Type),
+			Description:    descriptionFromAPI(env.Description, types.StringNull(), false),
+			LastModifiedAt: types.Float64Value(env.LastModifiedAt),
+			LastReportedAt: types.Float64PointerValue(env.LastReportedAt),
+			Tags:           tagsValue,
+		}
+		data.Environments = append(data.Environments, item)
+		data.EnvironmentsByName[env.Name] = item
+		data.EnvironmentsByType[env.Type] = append(data.EnvironmentsByType[env.Type], item.Name)
+	}
+
+	data.Exists = types.BoolValue(true)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// environmentMatches reports whether env is of type envType, unless it is
+// empty, and carries all of tags.
+func environmentMatches(env client.Environment, envType string, tags map[string]string) bool {
+	if envType != "" && env.Type != envType {
+		return false
+	}
+	for key, value := range tags {
+		if got, ok := env.Tags[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
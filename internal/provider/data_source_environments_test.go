@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestEnvironmentsDataSource_Metadata(t *testing.T) {
+	d := &environmentsDataSource{}
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "kosli",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	expectedTypeName := "kosli_environments"
+	if resp.TypeName != expectedTypeName {
+		t.Errorf("Expected TypeName %q, got %q", expectedTypeName, resp.TypeName)
+	}
+}
+
+func TestEnvironmentsDataSource_Schema(t *testing.T) {
+	d := &environmentsDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema method returned errors: %v", resp.Diagnostics.Errors())
+	}
+
+	attrs := resp.Schema.Attributes
+
+	// Verify filters are optional
+	for _, name := range []string{"type", "include_archived", "tags", "page_size"} {
+		attr, exists := attrs[name]
+		if !exists {
+			t.Fatalf("Expected attribute %q to exist in schema", name)
+		}
+		if !attr.IsOptional() {
+			t.Errorf("Expected %q attribute to be optional", name)
+		}
+	}
+
+	// Verify outputs are computed
+	for _, name := range []string{"environments", "environments_by_name", "environments_by_type"} {
+		attr, exists := attrs[name]
+		if !exists {
+			t.Fatalf("Expected attribute %q to exist in schema", name)
+		}
+		if !attr.IsComputed() {
+			t.Errorf("Expected %q attribute to be computed", name)
+		}
+	}
+}
+
+func TestEnvironmentMatches(t *testing.T) {
+	env := client.Environment{Name: "prod-k8s", Type: "K8S", Tags: map[string]string{"tier": "prod", "region": "eu"}}
+
+	tests := []struct {
+		name    string
+		envType string
+		tags    map[string]string
+		want    bool
+	}{
+		{"no filter", "", nil, true},
+		{"type", "K8S", nil, true},
+		{"other type", "ECS", nil, false},
+		{"tags", "", map[string]string{"tier": "prod", "region": "eu"}, true},
+		{"other tag value", "", map[string]string{"tier": "staging"}, false},
+		{"missing tag", "", map[string]string{"team": ""}, false},
+		{"type and tags", "K8S", map[string]string{"tier": "prod"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := environmentMatches(env, tt.envType, tt.tags); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
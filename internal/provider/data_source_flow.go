@@ -23,10 +23,19 @@ type flowDataSource struct {
 	client *client.Client
 }
 
-// flowDataSourceModel is an alias for flowResourceModel: the data source exposes
-// the same fields as the resource, so a single model and mapper (mapFlowToModel)
-// serve both.
-type flowDataSourceModel = flowResourceModel
+// flowDataSourceModel describes the data source data model. It extends the
+// fields of flowResourceModel, which are mapped by the shared mapFlowToModel,
+// with the parsed trail template.
+type flowDataSourceModel struct {
+	Name           types.String            `tfsdk:"name"`
+	Description    types.String            `tfsdk:"description"`
+	Template       types.String            `tfsdk:"template"`
+	Tags           types.Map               `tfsdk:"tags"`
+	TrailTemplate  *flowTrailTemplateModel `tfsdk:"trail_template"`
+	FailIfNotFound types.Bool              `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool              `tfsdk:"exists"`
+	ID             types.String            `tfsdk:"id"`
+}
 
 // Metadata returns the data source type name.
 func (d *flowDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,6 +52,9 @@ func (d *flowDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Required:            true,
 				MarkdownDescription: "The name of the flow to query.",
 			},
+			"fail_if_not_found": failIfNotFoundAttribute("flow"),
+			"exists":            existsAttribute("flow"),
+			"id":                idAttribute("the flow name"),
 			"description": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The description of the flow.",
@@ -56,6 +68,57 @@ func (d *flowDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Key-value pairs tagging the flow.",
 				ElementType:         types.StringType,
 			},
+			"trail_template": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The flow's `template` parsed into the attestations and artifacts expected on every trail, for example to generate CI pipeline steps. Null if the flow has no template.",
+				Attributes: map[string]schema.Attribute{
+					"version": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "The template format version.",
+					},
+					"attestations": schema.ListNestedAttribute{
+						Computed:            true,
+						MarkdownDescription: "The attestations expected on the trail itself.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: flowTemplateAttestationAttributes(),
+						},
+					},
+					"artifacts": schema.ListNestedAttribute{
+						Computed:            true,
+						MarkdownDescription: "The artifacts expected on the trail.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "The name of the artifact placeholder.",
+								},
+								"attestations": schema.ListNestedAttribute{
+									Computed:            true,
+									MarkdownDescription: "The attestations expected for the artifact.",
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: flowTemplateAttestationAttributes(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flowTemplateAttestationAttributes returns the attributes of an attestation
+// expected by a flow template.
+func flowTemplateAttestationAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name of the attestation.",
+		},
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The attestation type, e.g. `generic`, `junit` or the name of a custom attestation type.",
 		},
 	}
 }
@@ -87,22 +150,45 @@ func (d *flowDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = lookupID(data.Name.ValueString())
 
 	// Get flow from API
 	flow, err := d.client.GetFlow(ctx, data.Name.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.Tags = types.MapNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Flow",
-			fmt.Sprintf("Could not read flow %s: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read flow %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to model (shared with the resource)
-	mapFlowToModel(ctx, flow, &data, &resp.Diagnostics)
+	flowData := flowResourceModel{Name: data.Name}
+	mapFlowToModel(ctx, flow, false, &flowData, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.Exists = types.BoolValue(true)
+	data.Name = flowData.Name
+	data.Description = flowData.Description
+	data.Template = flowData.Template
+	data.Tags = flowData.Tags
+
+	// A template the provider cannot parse should not hide the rest of the flow
+	trailTemplate, err := parseFlowTemplate(flow.Template)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Could Not Parse Flow Template",
+			fmt.Sprintf("The template of flow %q could not be parsed, so trail_template is null: %s", data.Name.ValueString(), err),
+		)
+	}
+	data.TrailTemplate = trailTemplate
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &flowComplianceDataSource{}
+
+// NewFlowComplianceDataSource creates a new flow compliance data source.
+func NewFlowComplianceDataSource() datasource.DataSource {
+	return &flowComplianceDataSource{}
+}
+
+// flowComplianceDataSource defines the data source implementation.
+type flowComplianceDataSource struct {
+	client *client.Client
+}
+
+// flowComplianceDataSourceModel describes the data source data model.
+type flowComplianceDataSourceModel struct {
+	Flow                     types.String                     `tfsdk:"flow"`
+	Trail                    types.String                     `tfsdk:"trail"`
+	FailOnNonCompliant       types.Bool                       `tfsdk:"fail_on_non_compliant"`
+	Compliant                types.Bool                       `tfsdk:"compliant"`
+	Status                   types.String                     `tfsdk:"status"`
+	MissingAttestations      []string                         `tfsdk:"missing_attestations"`
+	NonCompliantAttestations []string                         `tfsdk:"non_compliant_attestations"`
+	Attestations             []flowComplianceAttestationModel `tfsdk:"attestations"`
+	Artifacts                []flowComplianceArtifactModel    `tfsdk:"artifacts"`
+	Exists                   types.Bool                       `tfsdk:"exists"`
+	ID                       types.String                     `tfsdk:"id"`
+}
+
+// flowComplianceAttestationModel describes the status of one attestation on
+// the trail.
+type flowComplianceAttestationModel struct {
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Artifact  types.String `tfsdk:"artifact"`
+	Status    types.String `tfsdk:"status"`
+	Compliant types.Bool   `tfsdk:"compliant"`
+}
+
+// flowComplianceArtifactModel describes one artifact expected on the trail.
+type flowComplianceArtifactModel struct {
+	Name        types.String `tfsdk:"name"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+// Metadata returns the data source type name.
+func (d *flowComplianceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow_compliance"
+}
+
+// Schema defines the schema for the data source.
+func (d *flowComplianceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the compliance status of a trail of a Kosli flow: whether every attestation expected by the flow template has been reported and is compliant. " +
+			"Set `fail_on_non_compliant` to use the data source as a release gate that fails the plan when evidence is missing or non-compliant.",
+
+		Attributes: map[string]schema.Attribute{
+			"flow": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the flow.",
+			},
+			"trail": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the trail, e.g. a commit SHA. Defaults to the most recently created trail of the flow.",
+			},
+			"fail_on_non_compliant": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "If `true`, reading the data source fails unless the trail is compliant. Defaults to `false`.",
+			},
+			"exists": alwaysExistsAttribute(),
+			"id":     idAttribute("`<flow>`, or `<flow>/<trail>` when `trail` is set"),
+			"compliant": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether all expected attestations are present and compliant.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The compliance status of the trail: `COMPLIANT`, `NON-COMPLIANT` or `INCOMPLETE` (expected attestations are missing).",
+			},
+			"missing_attestations": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Expected attestations that have not been reported. Attestations of artifacts are named `<artifact>.<attestation>`.",
+			},
+			"non_compliant_attestations": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Reported attestations that are not compliant. Attestations of artifacts are named `<artifact>.<attestation>`.",
+			},
+			"attestations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of every attestation on the trail and its artifacts.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the attestation.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The attestation type.",
+						},
+						"artifact": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The template name of the artifact the attestation belongs to. Null for attestations on the trail itself.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "`COMPLETE` if the attestation has been reported, `MISSING` otherwise.",
+						},
+						"compliant": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the attestation is compliant.",
+						},
+					},
+				},
+			},
+			"artifacts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The artifacts of the trail, in name order. Their attestations are listed in `attestations`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The template name of the artifact.",
+						},
+						"fingerprint": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The SHA-256 fingerprint of the artifact. Null if the artifact has not been reported.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *flowComplianceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *flowComplianceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data flowComplianceDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = lookupID(data.Flow.ValueString())
+	if !data.Trail.IsNull() && !data.Trail.IsUnknown() {
+		data.ID = lookupID(data.Flow.ValueString(), data.Trail.ValueString())
+	}
+
+	flowName := data.Flow.ValueString()
+
+	// Default to the most recent trail
+	if data.Trail.IsNull() || data.Trail.IsUnknown() {
+		trails, err := d.client.ListTrails(ctx, flowName, &client.ListTrailsOptions{PerPage: 1})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Flow Trails",
+				fmt.Sprintf("Could not list trails of flow %q: %s", flowName, apiErrorDetail(err)),
+			)
+			return
+		}
+		if len(trails) == 0 {
+			resp.Diagnostics.AddError(
+				"Flow Has No Trails",
+				fmt.Sprintf("Flow %q has no trails to check the compliance of.", flowName),
+			)
+			return
+		}
+		data.Trail = types.StringValue(trails[0].Name)
+	}
+
+	trail, err := d.client.GetTrail(ctx, flowName, data.Trail.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Trail",
+			fmt.Sprintf("Could not read trail %q of flow %q: %s", data.Trail.ValueString(), flowName, apiErrorDetail(err)),
+		)
+		return
+	}
+
+	mapTrailComplianceToModel(trail, &data)
+	data.Exists = types.BoolValue(true)
+
+	if data.FailOnNonCompliant.ValueBool() && !data.Compliant.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Trail Not Compliant",
+			fmt.Sprintf("Trail %q of flow %q is %s.%s", data.Trail.ValueString(), flowName, data.Status.ValueString(), describeAttestationGaps(&data)),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapTrailComplianceToModel maps the compliance status of a trail into the
+// data source model. Attestations of the trail come first, then those of its
+// artifacts in artifact name order, which is also the order of artifacts.
+func mapTrailComplianceToModel(trail *client.Trail, data *flowComplianceDataSourceModel) {
+	status := trail.ComplianceStatus
+
+	data.Trail = types.StringValue(trail.Name)
+	data.Compliant = types.BoolValue(status.IsCompliant)
+	data.Status = types.StringValue(status.Status)
+	data.MissingAttestations = []string{}
+	data.NonCompliantAttestations = []string{}
+	data.Attestations = []flowComplianceAttestationModel{}
+	data.Artifacts = []flowComplianceArtifactModel{}
+
+	add := func(artifact string, a client.AttestationStatus) {
+		name := a.Name
+		artifactValue := types.StringNull()
+		if artifact != "" {
+			name = artifact + "." + a.Name
+			artifactValue = types.StringValue(artifact)
+		}
+
+		switch {
+		case a.Status == client.AttestationStatusMissing:
+			data.MissingAttestations = append(data.MissingAttestations, name)
+		case !a.IsCompliant:
+			data.NonCompliantAttestations = append(data.NonCompliantAttestations, name)
+		}
+
+		data.Attestations = append(data.Attestations, flowComplianceAttestationModel{
+			Name:      types.StringValue(a.Name),
+			Type:      types.StringValue(a.Type),
+			Artifact:  artifactValue,
+			Status:    types.StringValue(a.Status),
+			Compliant: types.BoolValue(a.IsCompliant),
+		})
+	}
+
+	for _, a := range status.AttestationsStatuses {
+		add("", a)
+	}
+
+	artifacts := make([]string, 0, len(status.ArtifactsStatuses))
+	for name := range status.ArtifactsStatuses {
+		artifacts = append(artifacts, name)
+	}
+	slices.Sort(artifacts)
+	for _, artifact := range artifacts {
+		artifactStatus := status.ArtifactsStatuses[artifact]
+		fingerprint := types.StringNull()
+		if artifactStatus.Fingerprint != "" {
+			fingerprint = types.StringValue(artifactStatus.Fingerprint)
+		}
+		data.Artifacts = append(data.Artifacts, flowComplianceArtifactModel{
+			Name:        types.StringValue(artifact),
+			Fingerprint: fingerprint,
+		})
+
+		for _, a := range artifactStatus.AttestationsStatuses {
+			add(artifact, a)
+		}
+	}
+}
+
+// describeAttestationGaps lists the missing and non-compliant attestations of
+// data for an error message.
+func describeAttestationGaps(data *flowComplianceDataSourceModel) string {
+	var b strings.Builder
+	if len(data.MissingAttestations) > 0 {
+		fmt.Fprintf(&b, " Missing attestations: %s.", strings.Join(data.MissingAttestations, ", "))
+	}
+	if len(data.NonCompliantAttestations) > 0 {
+		fmt.Fprintf(&b, " Non-compliant attestations: %s.", strings.Join(data.NonCompliantAttestations, ", "))
+	}
+	return b.String()
+}
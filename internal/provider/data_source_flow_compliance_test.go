@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestFlowComplianceDataSource_Metadata(t *testing.T) {
+	d := &flowComplianceDataSource{}
+
+	req := datasource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_flow_compliance" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_flow_compliance", resp.TypeName)
+	}
+}
+
+func TestFlowComplianceDataSource_Schema(t *testing.T) {
+	d := &flowComplianceDataSource{}
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	attrs := resp.Schema.Attributes
+	if !attrs["flow"].IsRequired() {
+		t.Error("Expected 'flow' to be required")
+	}
+	if !attrs["trail"].IsOptional() || !attrs["trail"].IsComputed() {
+		t.Error("Expected 'trail' to be optional and computed")
+	}
+	if !attrs["fail_on_non_compliant"].IsOptional() {
+		t.Error("Expected 'fail_on_non_compliant' to be optional")
+	}
+	for _, name := range []string{"compliant", "status", "missing_attestations", "non_compliant_attestations", "attestations", "artifacts"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsComputed() {
+			t.Errorf("Expected attribute %q to be computed", name)
+		}
+	}
+}
+
+func TestFlowComplianceDataSource_Configure(t *testing.T) {
+	d := &flowComplianceDataSource{}
+
+	req := datasource.ConfigureRequest{ProviderData: nil}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = datasource.ConfigureRequest{ProviderData: "wrong-type"}
+	resp = &datasource.ConfigureResponse{}
+
+	d.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected error when provider data is wrong type")
+	}
+}
+
+func TestMapTrailComplianceToModel(t *testing.T) {
+	trail := &client.Trail{
+		Name: "abc123",
+		ComplianceStatus: client.TrailComplianceStatus{
+			Status:      client.TrailStatusIncomplete,
+			IsCompliant: false,
+			AttestationsStatuses: []client.AttestationStatus{
+				{Name: "pull-request", Type: "pull_request", Status: "COMPLETE", IsCompliant: true},
+				{Name: "risk-review", Type: "generic", Status: client.AttestationStatusMissing},
+			},
+			ArtifactsStatuses: map[string]client.ArtifactStatus{
+				"frontend": {AttestationsStatuses: []client.AttestationStatus{
+					{Name: "unit-tests", Type: "junit", Status: "COMPLETE", IsCompliant: false},
+				}},
+				"backend": {Fingerprint: "8e7b6c5d", AttestationsStatuses: []client.AttestationStatus{
+					{Name: "unit-tests", Type: "junit", Status: "COMPLETE", IsCompliant: true},
+					{Name: "sonar", Type: "sonar", Status: client.AttestationStatusMissing},
+				}},
+			},
+		},
+	}
+
+	var data flowComplianceDataSourceModel
+	mapTrailComplianceToModel(trail, &data)
+
+	if data.Trail.ValueString() != "abc123" || data.Status.ValueString() != client.TrailStatusIncomplete || data.Compliant.ValueBool() {
+		t.Errorf("Unexpected trail status: %s %s %v", data.Trail, data.Status, data.Compliant)
+	}
+	if want := []string{"risk-review", "backend.sonar"}; !slices.Equal(data.MissingAttestations, want) {
+		t.Errorf("Expected missing attestations %v, got %v", want, data.MissingAttestations)
+	}
+	if want := []string{"frontend.unit-tests"}; !slices.Equal(data.NonCompliantAttestations, want) {
+		t.Errorf("Expected non-compliant attestations %v, got %v", want, data.NonCompliantAttestations)
+	}
+
+	if len(data.Attestations) != 5 {
+		t.Fatalf("Expected 5 attestations, got %d", len(data.Attestations))
+	}
+	if !data.Attestations[0].Artifact.IsNull() {
+		t.Errorf("Expected no artifact for a trail attestation, got %s", data.Attestations[0].Artifact)
+	}
+	if got := data.Attestations[2].Artifact.ValueString(); got != "backend" {
+		t.Errorf("Expected artifact attestations in artifact name order, got %q first", got)
+	}
+
+	if len(data.Artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts, got %d", len(data.Artifacts))
+	}
+	if got := data.Artifacts[0]; got.Name.ValueString() != "backend" || got.Fingerprint.ValueString() != "8e7b6c5d" {
+		t.Errorf("Expected the backend artifact with its fingerprint first, got %s %s", got.Name, got.Fingerprint)
+	}
+	if got := data.Artifacts[1]; got.Name.ValueString() != "frontend" || !got.Fingerprint.IsNull() {
+		t.Errorf("Expected the unreported frontend artifact without a fingerprint, got %s %s", got.Name, got.Fingerprint)
+	}
+
+	message := describeAttestationGaps(&data)
+	if !strings.Contains(message, "risk-review, backend.sonar") || !strings.Contains(message, "frontend.unit-tests") {
+		t.Errorf("Expected the gaps in the message, got %q", message)
+	}
+}
+
+func TestMapTrailComplianceToModel_Compliant(t *testing.T) {
+	trail := &client.Trail{
+		Name: "def456",
+		ComplianceStatus: client.TrailComplianceStatus{
+			Status:      client.TrailStatusCompliant,
+			IsCompliant: true,
+		},
+	}
+
+	var data flowComplianceDataSourceModel
+	mapTrailComplianceToModel(trail, &data)
+
+	if !data.Compliant.ValueBool() {
+		t.Error("Expected the trail to be compliant")
+	}
+	if data.MissingAttestations == nil || data.NonCompliantAttestations == nil || data.Attestations == nil || data.Artifacts == nil {
+		t.Error("Expected empty rather than null lists")
+	}
+	if describeAttestationGaps(&data) != "" {
+		t.Errorf("Expected no gaps, got %q", describeAttestationGaps(&data))
+	}
+}
@@ -33,7 +33,7 @@ func TestFlowDataSource_Schema(t *testing.T) {
 	}
 
 	attrs := resp.Schema.Attributes
-	expectedAttrs := []string{"name", "description", "template"}
+	expectedAttrs := []string{"name", "fail_if_not_found", "exists", "id", "description", "template", "trail_template"}
 	for _, attr := range expectedAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -31,6 +31,9 @@ type logicalEnvironmentDataSourceModel struct {
 	IncludedEnvironments types.List    `tfsdk:"included_environments"`
 	LastModifiedAt       types.Float64 `tfsdk:"last_modified_at"`
 	Tags                 types.Map     `tfsdk:"tags"`
+	FailIfNotFound       types.Bool    `tfsdk:"fail_if_not_found"`
+	Exists               types.Bool    `tfsdk:"exists"`
+	ID                   types.String  `tfsdk:"id"`
 }
 
 // Metadata returns the data source type name.
@@ -48,6 +51,9 @@ func (d *logicalEnvironmentDataSource) Schema(ctx context.Context, req datasourc
 				Required:            true,
 				MarkdownDescription: "The name of the logical environment to query.",
 			},
+			"fail_if_not_found": failIfNotFoundAttribute("logical environment"),
+			"exists":            existsAttribute("logical environment"),
+			"id":                idAttribute("the logical environment name"),
 			"type": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The environment type (always `logical` for logical environments).",
@@ -59,7 +65,7 @@ func (d *logicalEnvironmentDataSource) Schema(ctx context.Context, req datasourc
 			"included_environments": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Computed:            true,
-				MarkdownDescription: "List of physical environment names aggregated by this logical environment.",
+				MarkdownDescription: "List of physical environment names aggregated by this logical environment. Read from the latest snapshot when the environment response does not include them.",
 			},
 			"last_modified_at": schema.Float64Attribute{
 				Computed:            true,
@@ -103,13 +109,21 @@ func (d *logicalEnvironmentDataSource) Read(ctx context.Context, req datasource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = lookupID(data.Name.ValueString())
 
 	// Get environment from API
 	env, err := d.client.GetEnvironment(ctx, data.Name.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		data.IncludedEnvironments = types.ListNull(types.StringType)
+		data.Tags = types.MapNull(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Logical Environment",
-			fmt.Sprintf("Could not read logical environment %s: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read logical environment %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -128,10 +142,19 @@ func (d *logicalEnvironmentDataSource) Read(ctx context.Context, req datasource.
 	}
 
 	// Map API response to data source model
+	data.Exists = types.BoolValue(true)
 	data.Name = types.StringValue(env.Name)
 	data.Type = types.StringValue("logical")
-	data.Description = logicalEnvDescription(env.Description)
-	data.IncludedEnvironments = logicalEnvIncludedList(ctx, env.IncludedEnvironments, &resp.Diagnostics)
+	data.Description = descriptionFromAPI(env.Description, types.StringNull(), false)
+	included, err := logicalEnvMembers(ctx, d.client, env)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Logical Environment",
+			fmt.Sprintf("Could not read included environments of logical environment %s: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+	data.IncludedEnvironments = logicalEnvIncludedList(ctx, included, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
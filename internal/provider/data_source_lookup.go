@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// failIfNotFoundAttribute returns the fail_if_not_found attribute of data
+// sources looking up a single object, described as object.
+func failIfNotFoundAttribute(object string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf("Whether to fail when the %[1]s does not exist. Set to `false` to get `exists = false` and null attributes instead, "+
+			"e.g. to create the %[1]s only if it does not exist yet. Defaults to `true`.", object),
+	}
+}
+
+// existsAttribute returns the exists attribute of data sources looking up a
+// single object, described as object.
+func existsAttribute(object string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Computed:            true,
+		MarkdownDescription: fmt.Sprintf("Whether the %s exists. Always `true` unless `fail_if_not_found` is `false`.", object),
+	}
+}
+
+// lookupNotFound reports whether err means the looked-up object does not
+// exist and the data source was configured to tolerate that.
+func lookupNotFound(failIfNotFound types.Bool, err error) bool {
+	return client.IsNotFound(err) && !failIfNotFound.IsNull() && !failIfNotFound.ValueBool()
+}
+
+// alwaysExistsAttribute returns the exists attribute of data sources that
+// cannot fail to find what they look up, such as listings, so that every data
+// source can be used in the same adoption logic.
+func alwaysExistsAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Computed:            true,
+		MarkdownDescription: "Always `true`. Present on every Kosli data source so modules can test `exists` uniformly.",
+	}
+}
+
+// idAttribute returns the id attribute of data sources, which is derived
+// from their configuration only, described as composition.
+func idAttribute(composition string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: fmt.Sprintf("Identifier of the data source: %s. Derived from the configuration, so it is stable across reads and known even when the object does not exist.", composition),
+	}
+}
+
+// lookupID returns the id of a data source from the parts of its
+// configuration identifying what it looks up.
+func lookupID(parts ...string) types.String {
+	return types.StringValue(strings.Join(parts, "/"))
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestLookupNotFound(t *testing.T) {
+	notFound := &client.APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	serverError := &client.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+
+	tests := []struct {
+		name           string
+		failIfNotFound types.Bool
+		err            error
+		want           bool
+	}{
+		{"not found, tolerated", types.BoolValue(false), notFound, true},
+		{"not found, default", types.BoolNull(), notFound, false},
+		{"not found, fail", types.BoolValue(true), notFound, false},
+		{"other error", types.BoolValue(false), serverError, false},
+		{"wrapped not found", types.BoolValue(false), errors.Join(errors.New("reading"), notFound), true},
+		{"no error", types.BoolValue(false), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lookupNotFound(tt.failIfNotFound, tt.err); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDataSources_FailIfNotFound tests that the lookup data sources report a
+// missing object with exists = false instead of an error when asked to.
+func TestDataSources_FailIfNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "not found"}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	dataSources := map[string]struct {
+		newDataSource func() datasource.DataSource
+		wantID        string
+	}{
+		"action":                        {func() datasource.DataSource { return &actionDataSource{client: c} }, "missing"},
+		"attestation":                   {func() datasource.DataSource { return &attestationDataSource{client: c} }, "missing/missing/missing"},
+		"custom_attestation_type":       {func() datasource.DataSource { return &customAttestationTypeDataSource{client: c} }, "missing"},
+		"environment":                   {func() datasource.DataSource { return &environmentDataSource{client: c} }, "missing"},
+		"environment_tags":              {func() datasource.DataSource { return &environmentTagsDataSource{client: c} }, "missing"},
+		"environment_running_artifacts": {func() datasource.DataSource { return &environmentRunningArtifactsDataSource{client: c} }, "missing"},
+		"environment_snapshot":          {func() datasource.DataSource { return &environmentSnapshotDataSource{client: c} }, "missing"},
+		"flow":                          {func() datasource.DataSource { return &flowDataSource{client: c} }, "missing"},
+		"logical_environment":           {func() datasource.DataSource { return &logicalEnvironmentDataSource{client: c} }, "missing"},
+		"policy":                        {func() datasource.DataSource { return &policyDataSource{client: c} }, "missing"},
+	}
+
+	for name, tt := range dataSources {
+		for _, failIfNotFound := range []any{nil, false} {
+			ctx := context.Background()
+			d := tt.newDataSource()
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			values := map[string]tftypes.Value{}
+			for attr, attrType := range objectType.AttributeTypes {
+				values[attr] = tftypes.NewValue(attrType, nil)
+			}
+			for _, key := range []string{"flow", "trail", "name", "environment", "selector"} {
+				if _, ok := values[key]; ok {
+					values[key] = tftypes.NewValue(tftypes.String, "missing")
+				}
+			}
+			values["fail_if_not_found"] = tftypes.NewValue(tftypes.Bool, failIfNotFound)
+
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+			d.Read(ctx, req, resp)
+
+			if failIfNotFound == nil {
+				if !resp.Diagnostics.HasError() {
+					t.Errorf("%s: expected an error for a missing object by default", name)
+				}
+				continue
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("%s: unexpected errors: %v", name, resp.Diagnostics)
+			}
+			var exists types.Bool
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("exists"), &exists)...)
+			if !exists.Equal(types.BoolValue(false)) {
+				t.Errorf("%s: expected exists = false, got %v", name, exists)
+			}
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != tt.wantID {
+				t.Errorf("%s: expected id %q, got %v", name, tt.wantID, id)
+			}
+		}
+	}
+}
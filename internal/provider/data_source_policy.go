@@ -25,11 +25,14 @@ type policyDataSource struct {
 
 // policyDataSourceModel describes the data source data model.
 type policyDataSourceModel struct {
-	Name          types.String  `tfsdk:"name"`
-	Description   types.String  `tfsdk:"description"`
-	Content       types.String  `tfsdk:"content"`
-	LatestVersion types.Int64   `tfsdk:"latest_version"`
-	CreatedAt     types.Float64 `tfsdk:"created_at"`
+	Name           types.String  `tfsdk:"name"`
+	Description    types.String  `tfsdk:"description"`
+	Content        types.String  `tfsdk:"content"`
+	LatestVersion  types.Int64   `tfsdk:"latest_version"`
+	CreatedAt      types.Float64 `tfsdk:"created_at"`
+	FailIfNotFound types.Bool    `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool    `tfsdk:"exists"`
+	ID             types.String  `tfsdk:"id"`
 }
 
 // Metadata returns the data source type name.
@@ -47,6 +50,9 @@ func (d *policyDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Required:            true,
 				MarkdownDescription: "The name of the policy to query.",
 			},
+			"fail_if_not_found": failIfNotFoundAttribute("policy"),
+			"exists":            existsAttribute("policy"),
+			"id":                idAttribute("the policy name"),
 			"description": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Description of the policy.",
@@ -93,16 +99,23 @@ func (d *policyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = lookupID(data.Name.ValueString())
 
 	policy, err := d.client.GetPolicy(ctx, data.Name.ValueString())
+	if lookupNotFound(data.FailIfNotFound, err) {
+		data.Exists = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Policy",
-			fmt.Sprintf("Could not read policy %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read policy %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
+	data.Exists = types.BoolValue(true)
 	data.Name = types.StringValue(policy.Name)
 
 	if policy.Description == "" {
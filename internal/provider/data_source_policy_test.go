@@ -34,7 +34,7 @@ func TestPolicyDataSource_Schema(t *testing.T) {
 	}
 
 	attrs := resp.Schema.Attributes
-	expectedAttrs := []string{"name", "description", "content", "latest_version", "created_at"}
+	expectedAttrs := []string{"name", "fail_if_not_found", "exists", "id", "description", "content", "latest_version", "created_at"}
 	for _, attr := range expectedAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// descriptionFromAPI converts a description returned by the API to its
+// Terraform value. prior is the value being replaced: the plan after a write,
+// the prior state on refresh, and null on import or in data sources.
+//
+// The API does not distinguish an unset description from an empty one, so an
+// empty description maps to null. With keepEmpty, set by the
+// keep_empty_descriptions provider attribute, it keeps prior when that is an
+// explicit "", so that setting description = "" applies and refreshes
+// without inconsistencies too. A description that differs from
+// prior only in ways descriptionDiffSuppressor ignores keeps prior, so an API
+// that normalizes whitespace does not produce an inconsistent result after
+// apply.
+func descriptionFromAPI(description string, prior types.String, keepEmpty bool) types.String {
+	if description == "" && !keepEmpty {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() &&
+		normalizeDescription(description) == normalizeDescription(prior.ValueString()) {
+		return prior
+	}
+	if description == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(description)
+}
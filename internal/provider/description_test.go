@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDescriptionFromAPI(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		prior       types.String
+		keepEmpty   bool
+		want        types.String
+	}{
+		{"unset stays null", "", types.StringNull(), true, types.StringNull()},
+		{"explicit empty is kept", "", types.StringValue(""), true, types.StringValue("")},
+		{"explicit empty becomes null by default", "", types.StringValue(""), false, types.StringNull()},
+		{"cleared description becomes null", "", types.StringValue("old"), true, types.StringNull()},
+		{"import", "Production", types.StringNull(), false, types.StringValue("Production")},
+		{"unchanged", "Production", types.StringValue("Production"), false, types.StringValue("Production")},
+		{"changed outside Terraform", "Staging", types.StringValue("Production"), false, types.StringValue("Staging")},
+		{"normalized by the API", "Production", types.StringValue("Production\r\n"), false, types.StringValue("Production\r\n")},
+		{"unknown prior", "Production", types.StringUnknown(), false, types.StringValue("Production")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptionFromAPI(tt.description, tt.prior, tt.keepEmpty); !got.Equal(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// apiErrorDetail formats err for use in a diagnostic detail. When err wraps a
+// *client.APIError, the HTTP method, endpoint path, server request ID and the
+// ID the client sent are appended on separate lines so users can
+// cross-reference Kosli server logs (or hand the request IDs to support).
+// Well-known API errors get a remediation hint and documentation link (see
+// errorHints). Errors from an open circuit breaker get an outage hint; other
+// non-API errors are returned unchanged.
+func apiErrorDetail(err error) string {
+	if client.IsCircuitOpen(err) {
+		return err.Error() + "\n\n" + circuitOpenHint
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(err.Error())
+
+	if apiErr.Method != "" || apiErr.URL != "" {
+		fmt.Fprintf(&b, "\n\nRequest: %s %s", apiErr.Method, requestPath(apiErr.URL))
+	}
+	if apiErr.RequestID != "" {
+		fmt.Fprintf(&b, "\nRequest ID: %s", apiErr.RequestID)
+	}
+	if apiErr.ClientRequestID != "" && apiErr.ClientRequestID != apiErr.RequestID {
+		fmt.Fprintf(&b, "\nClient Request ID: %s", apiErr.ClientRequestID)
+	}
+	if hint := errorHintFor(apiErr); hint != "" {
+		b.WriteString("\n\n")
+		b.WriteString(hint)
+	}
+
+	return b.String()
+}
+
+// Guidance appended to 401/403 diagnostics. The raw API message alone ("Unauthorized")
+// doesn't tell users which of the provider settings to look at. See errorHints.
+const (
+	unauthorizedHint = "The Kosli API rejected the API token. Check that `api_token` (or the KOSLI_API_TOKEN " +
+		"environment variable) is set to a valid token that has not been revoked or expired."
+
+	wrongOrgHint = "The API token is valid but does not belong to the organization this request targeted. " +
+		"Check that `org` (or the KOSLI_ORG environment variable) matches the organization the token " +
+		"was created in; service account tokens are scoped to a single organization."
+
+	forbiddenHint = "The API token is valid but is not permitted to perform this operation. The provider " +
+		"requires a Kosli service account with Admin permissions. If the token was created in a " +
+		"different organization, check that `org` (or KOSLI_ORG) matches it."
+)
+
+// circuitOpenHint is appended when a request was rejected by the client's
+// circuit breaker, so users see one clear explanation instead of assuming
+// every failing resource has its own problem.
+const circuitOpenHint = "The request was not sent because recent requests to the Kosli API kept failing " +
+	"with server or connection errors, which usually indicates an outage. Re-run " +
+	"once the API has recovered. The threshold can be tuned with the provider `circuit_breaker` block."
+
+// requestPath returns the path and query of rawURL, falling back to rawURL
+// itself if it can't be parsed. The scheme and host are dropped because they
+// are already known from the provider's api_url.
+func requestPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Path == "" {
+		return rawURL
+	}
+	return u.RequestURI()
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestAPIErrorDetail_IncludesRequestContext(t *testing.T) {
+	err := &client.APIError{
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid name",
+		Method:     http.MethodPut,
+		URL:        "https://app.kosli.com/api/v2/environments/acme/prod?x=1",
+		RequestID:  "req-123",
+	}
+
+	detail := apiErrorDetail(err)
+
+	for _, want := range []string{
+		"kosli api error (status 400): invalid name",
+		"Request: PUT /api/v2/environments/acme/prod?x=1",
+		"Request ID: req-123",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected detail to contain %q, got %q", want, detail)
+		}
+	}
+	if strings.Contains(detail, "app.kosli.com") {
+		t.Errorf("expected host to be omitted, got %q", detail)
+	}
+}
+
+func TestAPIErrorDetail_ClientRequestID(t *testing.T) {
+	err := &client.APIError{StatusCode: http.StatusBadGateway, Method: http.MethodGet, URL: "https://app.kosli.com/api/v2/flows/acme/f", ClientRequestID: "c0ffee"}
+	if detail := apiErrorDetail(err); !strings.Contains(detail, "Client Request ID: c0ffee") {
+		t.Errorf("expected client request ID line, got %q", detail)
+	}
+
+	// A server that echoes the client's ID shows it once
+	err.RequestID = "c0ffee"
+	if detail := apiErrorDetail(err); strings.Contains(detail, "Client Request ID:") {
+		t.Errorf("expected no separate client request ID line, got %q", detail)
+	}
+}
+
+func TestAPIErrorDetail_WrappedAPIError(t *testing.T) {
+	apiErr := &client.APIError{StatusCode: http.StatusNotFound, Method: http.MethodGet, URL: "https://app.kosli.com/api/v2/flows/acme/f"}
+	err := fmt.Errorf("%w: %w", ErrRenameRace, apiErr)
+
+	detail := apiErrorDetail(err)
+	if !strings.Contains(detail, "Request: GET /api/v2/flows/acme/f") {
+		t.Errorf("expected request line for wrapped APIError, got %q", detail)
+	}
+	if strings.Contains(detail, "Request ID:") {
+		t.Errorf("expected no request ID line when none was returned, got %q", detail)
+	}
+}
+
+func TestAPIErrorDetail_NonAPIError(t *testing.T) {
+	err := errors.New("failed to execute request: connection refused")
+	if got := apiErrorDetail(err); got != err.Error() {
+		t.Errorf("expected %q, got %q", err.Error(), got)
+	}
+}
+
+func TestAPIErrorDetail_AuthHints(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		message    string
+		want       string
+	}{
+		{name: "401 wrong token", statusCode: http.StatusUnauthorized, message: "Unauthorized", want: unauthorizedHint},
+		{name: "403 other org", statusCode: http.StatusForbidden, message: "User does not have access to organization acme", want: wrongOrgHint},
+		{name: "403 missing permission", statusCode: http.StatusForbidden, message: "Forbidden", want: forbiddenHint},
+		{name: "404 no hint", statusCode: http.StatusNotFound, message: "not found", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := apiErrorDetail(&client.APIError{StatusCode: tt.statusCode, Message: tt.message})
+			if tt.want == "" {
+				for _, hint := range []string{unauthorizedHint, wrongOrgHint, forbiddenHint} {
+					if strings.Contains(detail, hint) {
+						t.Errorf("expected no auth hint, got %q", detail)
+					}
+				}
+				return
+			}
+			if !strings.Contains(detail, tt.want) {
+				t.Errorf("expected detail to contain %q, got %q", tt.want, detail)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDetail_ErrorHints(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		message    string
+		wantHint   string // empty when no hint is expected
+		wantDocURL string
+	}{
+		{"throttled", http.StatusTooManyRequests, "Too Many Requests", throttledHint, providerDocsURL + "#nestedatt--rate_limit"},
+		{"archived", http.StatusConflict, "Environment prod is archived", archivedHint, ""},
+		{"invalid jq", http.StatusBadRequest, "Invalid jq expression: .coverage >=", invalidJqHint, providerDocsURL + "/resources/custom_attestation_type"},
+		{"invalid schema", http.StatusUnprocessableEntity, "type_schema is not a valid JSON Schema", invalidTypeSchemaHint, providerDocsURL + "/resources/custom_attestation_type"},
+		{"invalid policy", http.StatusBadRequest, "Policy content does not match schema", invalidPolicyHint, providerDocsURL + "/functions/validate_policy"},
+		{"unauthorized links docs", http.StatusUnauthorized, "Unauthorized", unauthorizedHint, providerDocsURL + "#authentication"},
+		{"fragment with other status", http.StatusInternalServerError, "jq evaluator crashed", "", ""},
+		{"unknown message", http.StatusBadRequest, "name is too long", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := &client.APIError{StatusCode: tt.statusCode, Message: tt.message}
+			detail := apiErrorDetail(apiErr)
+			if tt.wantHint == "" {
+				if hint := errorHintFor(apiErr); hint != "" {
+					t.Errorf("expected no hint, got %q", hint)
+				}
+				return
+			}
+			if !strings.Contains(detail, tt.wantHint) {
+				t.Errorf("expected detail to contain %q, got %q", tt.wantHint, detail)
+			}
+			if tt.wantDocURL != "" && !strings.Contains(detail, "See "+tt.wantDocURL) {
+				t.Errorf("expected detail to link %q, got %q", tt.wantDocURL, detail)
+			}
+			if tt.wantDocURL == "" && strings.Contains(detail, providerDocsURL) {
+				t.Errorf("expected no documentation link, got %q", detail)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDetail_CircuitOpen(t *testing.T) {
+	err := fmt.Errorf("%w (3 consecutive failures)", client.ErrCircuitOpen)
+	detail := apiErrorDetail(err)
+	if !strings.Contains(detail, circuitOpenHint) {
+		t.Errorf("expected circuit open hint, got %q", detail)
+	}
+}
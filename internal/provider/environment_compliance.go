@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Values of the compliance_status attribute.
+const (
+	complianceStatusCompliant    = "COMPLIANT"
+	complianceStatusNonCompliant = "NON-COMPLIANT"
+	complianceStatusUnknown      = "UNKNOWN"
+)
+
+// complianceSnapshotPageSize is the number of snapshots requested per page
+// while looking for the start of the current compliant streak, unless the
+// client has a default page size.
+const complianceSnapshotPageSize = 100
+
+// complianceMaxSnapshotPages bounds the number of snapshot pages read per
+// environment. When the compliant streak is longer than that, compliant_since
+// is the timestamp of the oldest snapshot read.
+const complianceMaxSnapshotPages = 10
+
+// environmentCompliance returns the compliance status of env's latest
+// snapshot and, when it is compliant, the timestamp of the first snapshot of
+// the unbroken run of compliant snapshots leading up to it, looking back at
+// most complianceMaxSnapshotPages pages.
+//
+// Environments that have never reported are UNKNOWN without calling the
+// snapshots endpoint. A failure to read snapshots is reported as a warning
+// rather than an error so that it does not block managing the environment.
+func environmentCompliance(ctx context.Context, c *client.Client, env *client.Environment, diags *diag.Diagnostics) (types.String, types.Float64) {
+	if env.LastReportedAt == nil {
+		return types.StringValue(complianceStatusUnknown), types.Float64Null()
+	}
+
+	pageSize := c.DefaultPageSize()
+	if pageSize == 0 {
+		pageSize = complianceSnapshotPageSize
+	}
+
+	var since *float64
+	var first client.Snapshot
+	for page := 1; page <= complianceMaxSnapshotPages; page++ {
+		snapshots, err := c.ListSnapshots(ctx, env.Name, &client.ListSnapshotsOptions{
+			Page:    page,
+			PerPage: pageSize,
+		})
+		if err != nil {
+			diags.AddWarning(
+				"Could Not Determine Environment Compliance",
+				fmt.Sprintf("Could not read snapshots of environment %q, so compliance_status is %s: %s", env.Name, complianceStatusUnknown, apiErrorDetail(err)),
+			)
+			return types.StringValue(complianceStatusUnknown), types.Float64Null()
+		}
+
+		if page == 1 && len(snapshots) == 0 {
+			return types.StringValue(complianceStatusUnknown), types.Float64Null()
+		}
+		if page == 1 && !snapshots[0].Compliant {
+			return types.StringValue(complianceStatusNonCompliant), types.Float64Null()
+		}
+
+		// An API that ignores pagination returns the first page again;
+		// stop rather than walk it repeatedly
+		if page == 1 {
+			first = snapshots[0]
+		} else if len(snapshots) > 0 && snapshots[0].Index == first.Index {
+			break
+		}
+
+		for _, s := range snapshots {
+			if !s.Compliant {
+				return types.StringValue(complianceStatusCompliant), types.Float64PointerValue(since)
+			}
+			since = &s.Timestamp
+		}
+
+		// Every snapshot so far is compliant; stop at the oldest one
+		if len(snapshots) < pageSize {
+			return types.StringValue(complianceStatusCompliant), types.Float64PointerValue(since)
+		}
+	}
+
+	tflog.Debug(ctx, "Compliant streak longer than the snapshots read; compliant_since is the oldest snapshot read", map[string]any{
+		"environment": env.Name,
+		"pages":       complianceMaxSnapshotPages,
+	})
+	return types.StringValue(complianceStatusCompliant), types.Float64PointerValue(since)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// snapshotServer serves compliant flags as the environment's snapshots,
+// newest first, paginated like the snapshots endpoint.
+func snapshotServer(t *testing.T, compliant []bool, opts ...client.ClientOption) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		snapshots := []client.Snapshot{}
+		for i := (page - 1) * perPage; i < page*perPage && i < len(compliant); i++ {
+			snapshots = append(snapshots, client.Snapshot{
+				Index:     len(compliant) - i,
+				Timestamp: float64(1700000000 + len(compliant) - i),
+				Compliant: compliant[i],
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(snapshots)
+	}))
+	t.Cleanup(server.Close)
+
+	opts = append([]client.ClientOption{client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{})}, opts...)
+	c, err := client.NewClient("test-token", "test-org", opts...)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestEnvironmentCompliance(t *testing.T) {
+	reported := 1700000000.0
+	longStreak := make([]bool, complianceSnapshotPageSize+5)
+	for i := range longStreak {
+		longStreak[i] = i < complianceSnapshotPageSize+2
+	}
+
+	tests := []struct {
+		name       string
+		compliant  []bool
+		wantStatus string
+		wantSince  float64 // 0 when null
+	}{
+		{"no snapshots", []bool{}, complianceStatusUnknown, 0},
+		{"non-compliant", []bool{false, true}, complianceStatusNonCompliant, 0},
+		{"compliant streak", []bool{true, true, false, true}, complianceStatusCompliant, 1700000003},
+		{"always compliant", []bool{true, true}, complianceStatusCompliant, 1700000001},
+		{"streak across pages", longStreak, complianceStatusCompliant, 1700000004},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := snapshotServer(t, tt.compliant)
+			var diags diag.Diagnostics
+
+			status, since := environmentCompliance(context.Background(), c, &client.Environment{Name: "production", LastReportedAt: &reported}, &diags)
+
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if status.ValueString() != tt.wantStatus {
+				t.Errorf("Expected status %q, got %q", tt.wantStatus, status.ValueString())
+			}
+			if tt.wantSince == 0 && !since.IsNull() {
+				t.Errorf("Expected null compliant_since, got %v", since)
+			}
+			if tt.wantSince != 0 && since.ValueFloat64() != tt.wantSince {
+				t.Errorf("Expected compliant_since %v, got %v", tt.wantSince, since)
+			}
+		})
+	}
+}
+
+func TestEnvironmentCompliance_NeverReported(t *testing.T) {
+	var diags diag.Diagnostics
+
+	// A nil client would panic if the snapshots endpoint were called
+	status, since := environmentCompliance(context.Background(), nil, &client.Environment{Name: "production"}, &diags)
+
+	if status.ValueString() != complianceStatusUnknown || !since.IsNull() {
+		t.Errorf("Expected UNKNOWN and null, got %v and %v", status, since)
+	}
+}
+
+func TestEnvironmentCompliance_ErrorIsWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "forbidden"}`))
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	reported := 1700000000.0
+	var diags diag.Diagnostics
+
+	status, _ := environmentCompliance(context.Background(), c, &client.Environment{Name: "production", LastReportedAt: &reported}, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("Expected a warning, got errors: %v", diags.Errors())
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("Expected one warning, got %d", diags.WarningsCount())
+	}
+	if status.ValueString() != complianceStatusUnknown {
+		t.Errorf("Expected status %q, got %q", complianceStatusUnknown, status.ValueString())
+	}
+}
+
+func TestEnvironmentCompliance_DefaultPageSize(t *testing.T) {
+	reported := 1700000000.0
+	c := snapshotServer(t, []bool{true, true, true, true, false}, client.WithDefaultPageSize(2))
+	var diags diag.Diagnostics
+
+	// The streak spans two pages of two snapshots
+	status, since := environmentCompliance(context.Background(), c, &client.Environment{Name: "production", LastReportedAt: &reported}, &diags)
+
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if status.ValueString() != complianceStatusCompliant {
+		t.Errorf("Expected status %q, got %q", complianceStatusCompliant, status.ValueString())
+	}
+	if since.ValueFloat64() != 1700000002 {
+		t.Errorf("Expected compliant_since 1700000002, got %v", since)
+	}
+}
+
+func TestEnvironmentCompliance_PageLimit(t *testing.T) {
+	reported := 1700000000.0
+	compliant := make([]bool, 2*complianceMaxSnapshotPages+2)
+	for i := range compliant {
+		compliant[i] = true
+	}
+	c := snapshotServer(t, compliant, client.WithDefaultPageSize(2))
+	var diags diag.Diagnostics
+
+	// The streak is longer than the pages read, so compliant_since is the
+	// oldest snapshot read
+	status, since := environmentCompliance(context.Background(), c, &client.Environment{Name: "production", LastReportedAt: &reported}, &diags)
+
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if status.ValueString() != complianceStatusCompliant {
+		t.Errorf("Expected status %q, got %q", complianceStatusCompliant, status.ValueString())
+	}
+	if since.ValueFloat64() != 1700000003 {
+		t.Errorf("Expected compliant_since 1700000003, got %v", since)
+	}
+}
+
+func TestEnvironmentCompliance_IgnoredPagination(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]client.Snapshot{
+			{Index: 3, Timestamp: 1700000003, Compliant: true},
+			{Index: 2, Timestamp: 1700000002, Compliant: true},
+		})
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}), client.WithDefaultPageSize(2))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	reported := 1700000000.0
+	var diags diag.Diagnostics
+
+	status, since := environmentCompliance(context.Background(), c, &client.Environment{Name: "production", LastReportedAt: &reported}, &diags)
+
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+	if status.ValueString() != complianceStatusCompliant || since.ValueFloat64() != 1700000002 {
+		t.Errorf("Expected COMPLIANT since 1700000002, got %v and %v", status, since)
+	}
+}
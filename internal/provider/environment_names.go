@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// environmentNames records which resource type planned each environment
+// name, per organization, with one provider configuration. Physical and
+// logical environments share a name space in Kosli, and a resource cannot see
+// the rest of the configuration, so this is how a kosli_environment and a
+// kosli_logical_environment with the same name are caught at plan time
+// rather than as API conflicts during apply.
+//
+// The registry maps "org/name" keys to the type of the resource that planned
+// them first. The provider creates one in Configure and hands it to the
+// environment resources through KosliResourceData.
+type environmentNames struct {
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+// claim records that a resource of resourceType plans the environment named
+// name in the organization that c acts on with ctx. It reports an error on
+// the name attribute if a resource of another type planned the same name
+// already. Resources of the same type are not reported, as a resource is
+// planned more than once per run. A nil registry, as in a resource that was
+// not configured, records nothing.
+func (n *environmentNames) claim(ctx context.Context, c *client.Client, resourceType, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if n == nil {
+		return diags
+	}
+
+	org := ""
+	if c != nil {
+		org = c.OrganizationFor(ctx)
+	}
+	key := org + "/" + name
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.owners == nil {
+		n.owners = map[string]string{}
+	}
+	owner, ok := n.owners[key]
+	if !ok {
+		n.owners[key] = resourceType
+		return diags
+	}
+	if owner != resourceType {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Conflicting Environment Name",
+			fmt.Sprintf("Both a %s and a %s in this configuration are named %q. "+
+				"Physical and logical environments share names in Kosli, so one of them must be renamed.",
+				owner, resourceType, name),
+		)
+	}
+	return diags
+}
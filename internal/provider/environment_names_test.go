@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestEnvironmentNames_Claim(t *testing.T) {
+	c, err := client.NewClient("test-token", "test-org")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	other, err := client.NewClient("test-token", "other-org")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	var names environmentNames
+	if diags := names.claim(ctx, c, "kosli_environment", "production"); diags.HasError() {
+		t.Fatalf("Expected the first claim to succeed, got %v", diags)
+	}
+
+	// A resource is planned more than once per run
+	if diags := names.claim(ctx, c, "kosli_environment", "production"); diags.HasError() {
+		t.Errorf("Expected a claim by the same resource type to succeed, got %v", diags)
+	}
+
+	// Names are per organization
+	if diags := names.claim(ctx, other, "kosli_logical_environment", "production"); diags.HasError() {
+		t.Errorf("Expected a claim in another organization to succeed, got %v", diags)
+	}
+	if diags := names.claim(client.WithOrgOverride(ctx, "third-org"), c, "kosli_logical_environment", "production"); diags.HasError() {
+		t.Errorf("Expected a claim in an overridden organization to succeed, got %v", diags)
+	}
+
+	diags := names.claim(ctx, c, "kosli_logical_environment", "production")
+	if !diags.HasError() {
+		t.Fatal("Expected a claim by another resource type to fail")
+	}
+	if got := diags.Errors()[0].Summary(); got != "Conflicting Environment Name" {
+		t.Errorf("Expected summary 'Conflicting Environment Name', got %q", got)
+	}
+}
+
+func TestEnvironmentNames_PerConfiguration(t *testing.T) {
+	c, err := client.NewClient("test-token", "test-org")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	// Each provider configuration has its own registry
+	first, second := &environmentNames{}, &environmentNames{}
+	if diags := first.claim(ctx, c, "kosli_environment", "production"); diags.HasError() {
+		t.Fatalf("Expected the first claim to succeed, got %v", diags)
+	}
+	if diags := second.claim(ctx, c, "kosli_logical_environment", "production"); diags.HasError() {
+		t.Errorf("Expected a claim with another configuration to succeed, got %v", diags)
+	}
+
+	// Resources that were not configured record nothing
+	var unconfigured *environmentNames
+	if diags := unconfigured.claim(ctx, c, "kosli_environment", "production"); diags.HasError() {
+		t.Errorf("Expected a claim without a registry to succeed, got %v", diags)
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// syncEnvironmentPolicies attaches the policies that are not attached to the
+// environment named name yet and detaches the attached policies that are not
+// among them: all of them if exclusive, otherwise only those of prior, the
+// policies Terraform attached before. Null policies are not managed.
+func syncEnvironmentPolicies(ctx context.Context, c *client.Client, name string, prior, policies types.Set, exclusive bool) error {
+	if policies.IsNull() || policies.IsUnknown() {
+		return nil
+	}
+	var configured, previous []string
+	if diags := policies.ElementsAs(ctx, &configured, false); diags.HasError() {
+		return fmt.Errorf("invalid policies: %v", diags)
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if diags := prior.ElementsAs(ctx, &previous, false); diags.HasError() {
+			return fmt.Errorf("invalid prior policies: %v", diags)
+		}
+	}
+
+	attached, err := c.GetEnvironmentPolicies(ctx, name)
+	if err != nil {
+		return err
+	}
+	attachedNames := make([]string, 0, len(attached))
+	for _, p := range attached {
+		attachedNames = append(attachedNames, p.Name)
+	}
+
+	for _, policy := range configured {
+		if slices.Contains(attachedNames, policy) {
+			continue
+		}
+		if err := c.AttachPolicy(ctx, name, policy); err != nil {
+			return fmt.Errorf("attaching policy %q: %w", policy, err)
+		}
+	}
+	for _, policy := range attachedNames {
+		if slices.Contains(configured, policy) {
+			continue
+		}
+		// Leave policies attached outside Terraform alone unless exclusive
+		if !exclusive && !slices.Contains(previous, policy) {
+			continue
+		}
+		if err := c.DetachPolicy(ctx, name, policy); err != nil && !client.IsNotFound(err) {
+			return fmt.Errorf("detaching policy %q: %w", policy, err)
+		}
+	}
+	return nil
+}
+
+// environmentPoliciesFromAPI returns the policies attribute for the policies
+// attached to env. With exclusive, every attached policy is reported, so
+// policies attached outside Terraform show up as drift; otherwise only the
+// policies of prior that are still attached are. Null prior stays null.
+func environmentPoliciesFromAPI(ctx context.Context, env *client.Environment, prior types.Set, exclusive bool, diags *diag.Diagnostics) types.Set {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.SetNull(types.StringType)
+	}
+
+	attached, err := env.AttachedPolicies()
+	if err != nil {
+		diags.AddError(
+			"Error Reading Environment Policies",
+			fmt.Sprintf("Could not read the policies attached to environment %q: %s", env.Name, err),
+		)
+		return prior
+	}
+	names := make([]string, 0, len(attached))
+	for _, p := range attached {
+		names = append(names, p.Name)
+	}
+
+	if !exclusive {
+		var configured []string
+		diags.Append(prior.ElementsAs(ctx, &configured, false)...)
+		names = slices.DeleteFunc(configured, func(policy string) bool {
+			return !slices.Contains(names, policy)
+		})
+	}
+
+	policies, d := types.SetValueFrom(ctx, types.StringType, names)
+	diags.Append(d...)
+	return policies
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// policyServer serves an environment with the attached policies and records
+// the policies attached and detached.
+func policyServer(t *testing.T, attached []string) (c *client.Client, changes func() (added, removed []string)) {
+	t.Helper()
+	var mu sync.Mutex
+	var added, removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var body struct {
+			PolicyNames []string `json:"policy_names"`
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"name": "production", "type": "K8S", "policies": attached})
+			return
+		case http.MethodPost, http.MethodDelete:
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Invalid body: %v", err)
+			}
+		}
+		if r.Method == http.MethodPost {
+			added = append(added, body.PolicyNames...)
+		} else {
+			removed = append(removed, body.PolicyNames...)
+		}
+		w.Write([]byte(`"OK"`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c, func() ([]string, []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return added, removed
+	}
+}
+
+func TestSyncEnvironmentPolicies(t *testing.T) {
+	tests := []struct {
+		name        string
+		prior       types.Set
+		policies    types.Set
+		exclusive   bool
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{"unmanaged", policySet(t, "prod-policy"), types.SetNull(types.StringType), true, nil, nil},
+		{"additive", types.SetNull(types.StringType), policySet(t, "prod-policy", "new-policy"), false, []string{"new-policy"}, nil},
+		{"removed", policySet(t, "prod-policy"), policySet(t, "new-policy"), false, []string{"new-policy"}, []string{"prod-policy"}},
+		{"exclusive", types.SetNull(types.StringType), policySet(t, "prod-policy", "new-policy"), true, []string{"new-policy"}, []string{"manual-policy"}},
+		{"exclusive and empty", policySet(t, "prod-policy"), policySet(t), true, nil, []string{"prod-policy", "manual-policy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, changes := policyServer(t, []string{"prod-policy", "manual-policy"})
+
+			if err := syncEnvironmentPolicies(context.Background(), c, "production", tt.prior, tt.policies, tt.exclusive); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			added, removed := changes()
+			if !slices.Equal(added, tt.wantAdded) {
+				t.Errorf("Expected %v attached, got %v", tt.wantAdded, added)
+			}
+			if !slices.Equal(removed, tt.wantRemoved) {
+				t.Errorf("Expected %v detached, got %v", tt.wantRemoved, removed)
+			}
+		})
+	}
+}
+
+func TestEnvironmentPoliciesFromAPI(t *testing.T) {
+	env := &client.Environment{Name: "production", Policies: []any{"prod-policy", map[string]any{"name": "manual-policy"}}}
+	var diags diag.Diagnostics
+
+	// Not managed
+	if got := environmentPoliciesFromAPI(context.Background(), env, types.SetNull(types.StringType), true, &diags); !got.IsNull() {
+		t.Errorf("Expected null policies, got %v", got)
+	}
+
+	// Policies attached outside Terraform only show up when exclusive
+	prior := policySet(t, "prod-policy", "removed-policy")
+	if got := environmentPoliciesFromAPI(context.Background(), env, prior, false, &diags); !got.Equal(policySet(t, "prod-policy")) {
+		t.Errorf("Expected [prod-policy], got %v", got)
+	}
+	if got := environmentPoliciesFromAPI(context.Background(), env, prior, true, &diags); !got.Equal(policySet(t, "prod-policy", "manual-policy")) {
+		t.Errorf("Expected [prod-policy manual-policy], got %v", got)
+	}
+	if diags.HasError() {
+		t.Errorf("Unexpected diagnostics: %v", diags)
+	}
+}
+
+// policySet returns a set of policy names.
+func policySet(t *testing.T, names ...string) types.Set {
+	t.Helper()
+	if names == nil {
+		names = []string{}
+	}
+	set, diags := types.SetValueFrom(context.Background(), types.StringType, names)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	return set
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// providerDocsURL is the root of the provider documentation on the Terraform
+// Registry, which error hints link to.
+const providerDocsURL = "https://registry.terraform.io/providers/kosli-dev/kosli/latest/docs"
+
+// Guidance for well-known API errors that are not about authentication.
+const (
+	archivedHint = "The object is archived in Kosli, and archived objects cannot be changed. Restore it " +
+		"in the Kosli web UI and run `terraform import` to manage it again, or use a different name."
+
+	invalidJqHint = "The Kosli API rejected a jq rule. Check the syntax of each `jq_rules` expression, e.g. " +
+		"by running it with `jq` against a sample of the attestation data."
+
+	invalidTypeSchemaHint = "The Kosli API rejected the JSON Schema. Check that `schema` is a valid JSON " +
+		"Schema document, e.g. with `jsondecode(file(...))` or a JSON Schema linter."
+
+	invalidPolicyHint = "The Kosli API rejected the policy document. Lint it with the " +
+		"`provider::kosli::validate_policy` function to see every problem with its line and column."
+
+	throttledHint = "The Kosli API kept throttling requests. Lower `terraform apply -parallelism`, or " +
+		"tune the provider `rate_limit` and `retry` blocks to send requests more slowly."
+)
+
+// errorHint is remediation guidance for a category of API errors.
+type errorHint struct {
+	statuses  []int    // Status codes the hint applies to; any status if empty
+	fragments []string // Lower-case fragments, one of which the message must contain; any message if empty
+	hint      string
+	docURL    string // Page documenting the remedy, if any
+}
+
+// errorHints lists the categories of API errors that get remediation
+// guidance in diagnostics. The first matching hint is used, so narrower
+// hints come first.
+var errorHints = []errorHint{
+	{
+		statuses: []int{http.StatusUnauthorized},
+		hint:     unauthorizedHint,
+		docURL:   providerDocsURL + "#authentication",
+	},
+	{
+		statuses:  []int{http.StatusForbidden},
+		fragments: []string{"organization", "organisation", " org "},
+		hint:      wrongOrgHint,
+		docURL:    providerDocsURL + "#authentication",
+	},
+	{
+		statuses: []int{http.StatusForbidden},
+		hint:     forbiddenHint,
+		docURL:   providerDocsURL + "#authentication",
+	},
+	{
+		statuses: []int{http.StatusTooManyRequests},
+		hint:     throttledHint,
+		docURL:   providerDocsURL + "#nestedatt--rate_limit",
+	},
+	{
+		statuses:  []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity},
+		fragments: []string{"archived"},
+		hint:      archivedHint,
+	},
+	{
+		statuses:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		fragments: []string{"jq"},
+		hint:      invalidJqHint,
+		docURL:    providerDocsURL + "/resources/custom_attestation_type",
+	},
+	{
+		statuses:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		fragments: []string{"type_schema", "json schema"},
+		hint:      invalidTypeSchemaHint,
+		docURL:    providerDocsURL + "/resources/custom_attestation_type",
+	},
+	{
+		statuses:  []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		fragments: []string{"policy"},
+		hint:      invalidPolicyHint,
+		docURL:    providerDocsURL + "/functions/validate_policy",
+	},
+}
+
+// matches reports whether the hint applies to apiErr.
+func (h errorHint) matches(apiErr *client.APIError) bool {
+	if len(h.statuses) > 0 && !slices.Contains(h.statuses, apiErr.StatusCode) {
+		return false
+	}
+	if len(h.fragments) == 0 {
+		return true
+	}
+	msg := strings.ToLower(apiErr.Message)
+	for _, f := range h.fragments {
+		if strings.Contains(msg, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the hint followed by its documentation link.
+func (h errorHint) String() string {
+	if h.docURL == "" {
+		return h.hint
+	}
+	return h.hint + "\n\nSee " + h.docURL
+}
+
+// errorHintFor returns the remediation guidance for apiErr, or "" if it is
+// not a well-known error.
+func errorHintFor(apiErr *client.APIError) string {
+	for _, h := range errorHints {
+		if h.matches(apiErr) {
+			return h.String()
+		}
+	}
+	return ""
+}
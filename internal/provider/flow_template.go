@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// flowTemplatesEqual reports whether two flow templates describe the same
+// YAML document, ignoring formatting, comments, key order and quoting.
+// Templates that do not parse are only equal if they are identical.
+func flowTemplatesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var docA, docB any
+	if err := yaml.Unmarshal([]byte(a), &docA); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &docB); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(docA, docB)
+}
+
+// flowTemplateFromAPI returns the state value for a flow template read from
+// the API. The prior value is kept when it describes the same document, so
+// the API reformatting the template does not show up as a change; an empty
+// template is null.
+func flowTemplateFromAPI(template string, prior types.String) types.String {
+	if template == "" {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() && flowTemplatesEqual(prior.ValueString(), template) {
+		return prior
+	}
+	return types.StringValue(template)
+}
+
+// flowTemplateDiffSuppressor keeps the prior state value of a flow template
+// when the configured template describes the same YAML document.
+type flowTemplateDiffSuppressor struct{}
+
+var _ planmodifier.String = flowTemplateDiffSuppressor{}
+
+// Description returns a plain text description of the plan modifier.
+func (m flowTemplateDiffSuppressor) Description(ctx context.Context) string {
+	return "Ignores YAML formatting, comments and key order."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (m flowTemplateDiffSuppressor) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString plans the state value if it is semantically equal to the
+// configured value.
+func (m flowTemplateDiffSuppressor) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compare against on create, and unknown or null values are
+	// real changes
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() ||
+		req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if flowTemplatesEqual(req.ConfigValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// flowTemplateValidator checks that a flow template is a YAML mapping, so
+// syntax errors are reported at plan time rather than by the API.
+type flowTemplateValidator struct{}
+
+var _ validator.String = flowTemplateValidator{}
+
+// Description returns a plain text description of the validator.
+func (v flowTemplateValidator) Description(ctx context.Context) string {
+	return "value must be a YAML mapping"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v flowTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks the configured template.
+func (v flowTemplateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(req.ConfigValue.ValueString()), &doc); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Flow Template",
+			fmt.Sprintf("The template must be a YAML mapping: %s", err),
+		)
+	}
+}
+
+// flowTemplateDocument is the structure of a flow template.
+type flowTemplateDocument struct {
+	Version int64 `yaml:"version"`
+	Trail   struct {
+		Attestations []flowTemplateAttestation `yaml:"attestations"`
+		Artifacts    []struct {
+			Name         string                    `yaml:"name"`
+			Attestations []flowTemplateAttestation `yaml:"attestations"`
+		} `yaml:"artifacts"`
+	} `yaml:"trail"`
+}
+
+// flowTemplateAttestation is an attestation expected by a flow template.
+type flowTemplateAttestation struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// flowTrailTemplateModel describes the parsed trail template of a flow.
+type flowTrailTemplateModel struct {
+	Version      types.Int64                    `tfsdk:"version"`
+	Attestations []flowTemplateAttestationModel `tfsdk:"attestations"`
+	Artifacts    []flowTemplateArtifactModel    `tfsdk:"artifacts"`
+}
+
+// flowTemplateAttestationModel describes an expected attestation.
+type flowTemplateAttestationModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+// flowTemplateArtifactModel describes an expected artifact and the
+// attestations expected for it.
+type flowTemplateArtifactModel struct {
+	Name         types.String                   `tfsdk:"name"`
+	Attestations []flowTemplateAttestationModel `tfsdk:"attestations"`
+}
+
+// parseFlowTemplate parses a flow template into its trail template model. An
+// empty template has no trail template and returns nil.
+func parseFlowTemplate(template string) (*flowTrailTemplateModel, error) {
+	if template == "" {
+		return nil, nil
+	}
+
+	var doc flowTemplateDocument
+	if err := yaml.Unmarshal([]byte(template), &doc); err != nil {
+		return nil, err
+	}
+
+	model := &flowTrailTemplateModel{
+		Version:      types.Int64Value(doc.Version),
+		Attestations: flowTemplateAttestationModels(doc.Trail.Attestations),
+		Artifacts:    make([]flowTemplateArtifactModel, 0, len(doc.Trail.Artifacts)),
+	}
+	for _, artifact := range doc.Trail.Artifacts {
+		model.Artifacts = append(model.Artifacts, flowTemplateArtifactModel{
+			Name:         types.StringValue(artifact.Name),
+			Attestations: flowTemplateAttestationModels(artifact.Attestations),
+		})
+	}
+	return model, nil
+}
+
+// flowTemplateAttestationModels converts template attestations to their
+// models, returning an empty (not nil) slice when there are none.
+func flowTemplateAttestationModels(attestations []flowTemplateAttestation) []flowTemplateAttestationModel {
+	models := make([]flowTemplateAttestationModel, 0, len(attestations))
+	for _, a := range attestations {
+		models = append(models, flowTemplateAttestationModel{
+			Name: types.StringValue(a.Name),
+			Type: types.StringValue(a.Type),
+		})
+	}
+	return models
+}
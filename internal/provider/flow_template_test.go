@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testFlowTemplate = `version: 1
+trail:
+  attestations:
+    - name: jira-ticket
+      type: generic
+  artifacts:
+    - name: backend
+      attestations:
+        - name: unit-tests
+          type: junit
+`
+
+func TestFlowTemplatesEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"identical", testFlowTemplate, testFlowTemplate, true},
+		{"reformatted", testFlowTemplate, `# Backend flow
+trail:
+    artifacts:
+    -   name: "backend"
+        attestations: [{type: junit, name: unit-tests}]
+    attestations:
+    -   {name: jira-ticket, type: generic}
+version: 1`, true},
+		{"changed attestation", testFlowTemplate, `version: 1
+trail:
+  attestations:
+    - name: jira-ticket
+      type: generic
+  artifacts:
+    - name: backend
+      attestations:
+        - name: integration-tests
+          type: junit
+`, false},
+		{"reordered list", "items: [a, b]", "items: [b, a]", false},
+		{"number and string", "version: 1", `version: "1"`, false},
+		{"invalid", "trail: [", "trail: [ ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flowTemplatesEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("flowTemplatesEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlowTemplateFromAPI(t *testing.T) {
+	reformatted := "version: 1\ntrail: {attestations: [{name: jira-ticket, type: generic}], artifacts: [{name: backend, attestations: [{name: unit-tests, type: junit}]}]}\n"
+
+	tests := []struct {
+		name     string
+		template string
+		prior    types.String
+		want     types.String
+	}{
+		{"empty", "", types.StringValue(testFlowTemplate), types.StringNull()},
+		{"equal to prior", reformatted, types.StringValue(testFlowTemplate), types.StringValue(testFlowTemplate)},
+		{"changed", "version: 1\n", types.StringValue(testFlowTemplate), types.StringValue("version: 1\n")},
+		{"no prior", reformatted, types.StringNull(), types.StringValue(reformatted)},
+		{"unknown prior", reformatted, types.StringUnknown(), types.StringValue(reformatted)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flowTemplateFromAPI(tt.template, tt.prior); !got.Equal(tt.want) {
+				t.Errorf("flowTemplateFromAPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlowTemplateDiffSuppressor(t *testing.T) {
+	commented := "# Backend flow\n" + testFlowTemplate
+
+	tests := []struct {
+		name   string
+		config types.String
+		state  types.String
+		want   types.String
+	}{
+		{"comment added", types.StringValue(commented), types.StringValue(testFlowTemplate), types.StringValue(testFlowTemplate)},
+		{"real change", types.StringValue("version: 1\n"), types.StringValue(testFlowTemplate), types.StringValue("version: 1\n")},
+		{"create", types.StringValue(commented), types.StringNull(), types.StringValue(commented)},
+		{"removed", types.StringNull(), types.StringValue(testFlowTemplate), types.StringNull()},
+		{"unknown", types.StringUnknown(), types.StringValue(testFlowTemplate), types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{ConfigValue: tt.config, StateValue: tt.state, PlanValue: tt.config}
+			resp := &planmodifier.StringResponse{PlanValue: tt.config}
+
+			flowTemplateDiffSuppressor{}.PlanModifyString(context.Background(), req, resp)
+
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("Expected plan value %v, got %v", tt.want, resp.PlanValue)
+			}
+		})
+	}
+}
+
+func TestFlowTemplateValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"valid", types.StringValue(testFlowTemplate), false},
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+		{"syntax error", types.StringValue("trail:\n  attestations: [\n"), true},
+		{"not a mapping", types.StringValue("- name: jira-ticket\n"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("template"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			flowTemplateValidator{}.ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("Expected error %v, got %v: %v", tt.wantErr, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestParseFlowTemplate(t *testing.T) {
+	got, err := parseFlowTemplate(testFlowTemplate)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got.Version.ValueInt64() != 1 {
+		t.Errorf("Expected version 1, got %v", got.Version)
+	}
+	if len(got.Attestations) != 1 || got.Attestations[0].Name.ValueString() != "jira-ticket" || got.Attestations[0].Type.ValueString() != "generic" {
+		t.Errorf("Unexpected trail attestations: %v", got.Attestations)
+	}
+	if len(got.Artifacts) != 1 || got.Artifacts[0].Name.ValueString() != "backend" {
+		t.Fatalf("Unexpected artifacts: %v", got.Artifacts)
+	}
+	if attestations := got.Artifacts[0].Attestations; len(attestations) != 1 || attestations[0].Name.ValueString() != "unit-tests" || attestations[0].Type.ValueString() != "junit" {
+		t.Errorf("Unexpected artifact attestations: %v", attestations)
+	}
+}
+
+func TestParseFlowTemplate_NoAttestations(t *testing.T) {
+	got, err := parseFlowTemplate("version: 1\ntrail:\n  artifacts:\n    - name: backend\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Missing lists are empty rather than null
+	if got.Attestations == nil || len(got.Attestations) != 0 {
+		t.Errorf("Expected empty trail attestations, got %v", got.Attestations)
+	}
+	if got.Artifacts[0].Attestations == nil || len(got.Artifacts[0].Attestations) != 0 {
+		t.Errorf("Expected empty artifact attestations, got %v", got.Artifacts[0].Attestations)
+	}
+}
+
+func TestParseFlowTemplate_Empty(t *testing.T) {
+	got, err := parseFlowTemplate("")
+	if err != nil || got != nil {
+		t.Errorf("Expected nil without error, got %v, %v", got, err)
+	}
+}
+
+func TestParseFlowTemplate_Invalid(t *testing.T) {
+	if _, err := parseFlowTemplate("trail: [\n"); err == nil {
+		t.Error("Expected an error")
+	}
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &validatePolicyFunction{}
+
+// NewValidatePolicyFunction creates a new validate_policy function.
+func NewValidatePolicyFunction() function.Function {
+	return &validatePolicyFunction{}
+}
+
+// validatePolicyFunction defines the function implementation.
+type validatePolicyFunction struct{}
+
+// Metadata returns the function name.
+func (f *validatePolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_policy"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validatePolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Lint a Kosli policy document",
+		MarkdownDescription: "Checks a Kosli policy document the same way the `content` of a `kosli_policy` is checked at plan time: YAML syntax, the keys of the " +
+			"`https://docs.kosli.com/schemas/policy/v1` schema and the types of their values. Returns `true` for a valid document, and fails with every " +
+			"problem found, each with its line and column, otherwise. Use it to lint policy documents kept in separate files, e.g. in `terraform test` or " +
+			"`terraform console`, before they are applied. A document with an unknown `_schema` is not checked beyond its syntax and known keys, and is not " +
+			"reported as invalid for it. Requires Terraform 1.8 or later.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "document",
+				MarkdownDescription: "YAML content of the policy document, e.g. read with `file()`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run lints the document.
+func (f *validatePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &document))
+	if resp.Error != nil {
+		return
+	}
+
+	var problems []string
+	for _, p := range lintPolicyDocument(document) {
+		if !p.Warning {
+			problems = append(problems, p.String())
+		}
+	}
+	if len(problems) > 0 {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid policy document:\n"+strings.Join(problems, "\n"))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, true))
+}
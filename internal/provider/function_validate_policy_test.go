@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidatePolicyFunction_Metadata(t *testing.T) {
+	f := NewValidatePolicyFunction()
+	resp := &function.MetadataResponse{}
+
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	if resp.Name != "validate_policy" {
+		t.Errorf("Expected Name 'validate_policy', got %q", resp.Name)
+	}
+}
+
+func TestValidatePolicyFunction_Definition(t *testing.T) {
+	f := NewValidatePolicyFunction()
+	resp := &function.DefinitionResponse{}
+
+	f.Definition(context.Background(), function.DefinitionRequest{}, resp)
+
+	if len(resp.Definition.Parameters) != 1 {
+		t.Fatalf("Expected 1 parameter, got %d", len(resp.Definition.Parameters))
+	}
+	if _, ok := resp.Definition.Parameters[0].(function.StringParameter); !ok {
+		t.Errorf("Expected a string parameter, got %T", resp.Definition.Parameters[0])
+	}
+	if _, ok := resp.Definition.Return.(function.BoolReturn); !ok {
+		t.Errorf("Expected a bool return, got %T", resp.Definition.Return)
+	}
+}
+
+func TestValidatePolicyFunction_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		document  string
+		wantError string // empty when the document is valid
+	}{
+		{"valid", testPolicyDocument, ""},
+		{"unknown schema", "_schema: https://docs.kosli.com/schemas/policy/v2\n", ""},
+		{
+			"invalid",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  provenance:\n    required: maybe\n  attestation: []\n",
+			"line 4, column 15: artifacts.provenance.required must be a boolean\nline 5, column 3: unknown key \"attestation\" in artifacts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.document)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+
+			NewValidatePolicyFunction().Run(context.Background(), req, resp)
+
+			if tt.wantError == "" {
+				if resp.Error != nil {
+					t.Fatalf("Expected no error, got %v", resp.Error)
+				}
+				if !resp.Result.Value().Equal(types.BoolValue(true)) {
+					t.Errorf("Expected true, got %v", resp.Result.Value())
+				}
+				return
+			}
+			if resp.Error == nil {
+				t.Fatal("Expected an error")
+			}
+			if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+				t.Errorf("Expected the error to point at the document argument, got %v", resp.Error.FunctionArgument)
+			}
+			if !strings.Contains(resp.Error.Text, tt.wantError) {
+				t.Errorf("Expected error to contain %q, got %q", tt.wantError, resp.Error.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// mockFault is a scripted failure the mock API answers a request with
+// instead of handling it.
+type mockFault struct {
+	status     int           // Response status; 0 to handle the request after delay
+	retryAfter string        // Retry-After header, if set
+	delay      time.Duration // Wait before responding, e.g. beyond the client timeout
+}
+
+// mockKosliAPI is an in-memory stand-in for the environment endpoints of the
+// Kosli API of organization "test-org". Requests are answered with the
+// scripted faults for their method first, in order, so tests can drive the
+// provider through throttling, outages and timeouts end to end.
+type mockKosliAPI struct {
+	mu     sync.Mutex
+	envs   map[string]client.Environment
+	faults map[string][]mockFault
+}
+
+// newMockKosliAPI starts a mock API answering with faults, keyed by HTTP
+// method, and returns it with its URL.
+func newMockKosliAPI(t *testing.T, faults map[string][]mockFault) (*mockKosliAPI, string) {
+	t.Helper()
+	api := &mockKosliAPI{envs: map[string]client.Environment{}, faults: faults}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, server.URL
+}
+
+// nextFault pops the next scripted fault for method, if any.
+func (a *mockKosliAPI) nextFault(method string) (mockFault, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.faults[method]) == 0 {
+		return mockFault{}, false
+	}
+	f := a.faults[method][0]
+	a.faults[method] = a.faults[method][1:]
+	return f, true
+}
+
+// checkFaultsServed fails unless every scripted fault was served, i.e. the
+// provider really went through the failures it recovered from.
+func (a *mockKosliAPI) checkFaultsServed(s *terraform.State) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for method, faults := range a.faults {
+		if len(faults) > 0 {
+			return fmt.Errorf("%d scripted %s failure(s) were never served", len(faults), method)
+		}
+	}
+	return nil
+}
+
+func (a *mockKosliAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f, ok := a.nextFault(r.Method); ok {
+		select {
+		case <-time.After(f.delay):
+		case <-r.Context().Done():
+			return
+		}
+		if f.status != 0 {
+			if f.retryAfter != "" {
+				w.Header().Set("Retry-After", f.retryAfter)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.status)
+			fmt.Fprintf(w, `{"message": %q}`, http.StatusText(f.status))
+			return
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	name, _ := strings.CutPrefix(r.URL.Path, "/api/v2/environments/test-org")
+	name = strings.TrimPrefix(name, "/")
+	switch {
+	case r.Method == http.MethodPut && name == "":
+		var env client.Environment
+		if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
+			http.Error(w, `{"message": "invalid body"}`, http.StatusBadRequest)
+			return
+		}
+		env.Org = "test-org"
+		env.LastModifiedAt = float64(time.Now().Unix())
+		a.envs[env.Name] = env
+		w.Write([]byte(`"OK"`))
+	case r.Method == http.MethodPut && strings.HasSuffix(name, "/archive"):
+		delete(a.envs, strings.TrimSuffix(name, "/archive"))
+		w.Write([]byte(`"OK"`))
+	case r.Method == http.MethodGet, r.Method == http.MethodPatch:
+		env, ok := a.envs[name]
+		if !ok {
+			http.Error(w, `{"message": "Environment not found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var update struct {
+				Description    *string `json:"description"`
+				IncludeScaling *bool   `json:"include_scaling"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				http.Error(w, `{"message": "invalid body"}`, http.StatusBadRequest)
+				return
+			}
+			if update.Description != nil {
+				env.Description = *update.Description
+			}
+			if update.IncludeScaling != nil {
+				env.IncludeScaling = *update.IncludeScaling
+			}
+			env.LastModifiedAt = float64(time.Now().Unix())
+			a.envs[name] = env
+			w.Write([]byte(`"OK"`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(env)
+	default:
+		http.Error(w, `{"message": "Not found"}`, http.StatusNotFound)
+	}
+}
+
+// TestAccMockAPI_retriesTransientFailures tests that creates, reads and
+// updates succeed through throttling and server errors
+func TestAccMockAPI_retriesTransientFailures(t *testing.T) {
+	api, url := newMockKosliAPI(t, map[string][]mockFault{
+		http.MethodPut: {
+			{status: http.StatusTooManyRequests, retryAfter: "1"},
+			{status: http.StatusServiceUnavailable},
+		},
+		http.MethodGet:   {{status: http.StatusBadGateway}},
+		http.MethodPatch: {{status: http.StatusTooManyRequests, retryAfter: "1"}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMockAPIConfig(url, 3, "Created"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kosli_environment.test", "name", "mock-env"),
+					resource.TestCheckResourceAttr("kosli_environment.test", "description", "Created"),
+				),
+			},
+			// PATCH is not idempotent, so only the 429 is retried
+			{
+				Config: testAccMockAPIConfig(url, 3, "Updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kosli_environment.test", "description", "Updated"),
+					api.checkFaultsServed,
+				),
+			},
+		},
+	})
+}
+
+// TestAccMockAPI_retriesTimeouts tests that a request that times out is
+// retried
+func TestAccMockAPI_retriesTimeouts(t *testing.T) {
+	api, url := newMockKosliAPI(t, map[string][]mockFault{
+		http.MethodGet: {{delay: 3 * time.Second}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMockAPIConfig(url, 2, "Created"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kosli_environment.test", "description", "Created"),
+					api.checkFaultsServed,
+				),
+			},
+		},
+	})
+}
+
+// TestAccMockAPI_retriesExhausted tests that the diagnostic of a request
+// that kept failing carries the API's last status and message
+func TestAccMockAPI_retriesExhausted(t *testing.T) {
+	_, url := newMockKosliAPI(t, map[string][]mockFault{
+		http.MethodPut: {
+			{status: http.StatusServiceUnavailable},
+			{status: http.StatusServiceUnavailable},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMockAPIConfig(url, 1, "Created"),
+				ExpectError: regexp.MustCompile(`Error\s+Creating\s+Environment(?s:.*)status\s+503(?s:.*)Service\s+Unavailable`),
+			},
+		},
+	})
+}
+
+// TestAccMockAPI_throttledTooLong tests the diagnostic when the API keeps
+// throttling requests
+func TestAccMockAPI_throttledTooLong(t *testing.T) {
+	_, url := newMockKosliAPI(t, map[string][]mockFault{
+		http.MethodPut: {
+			{status: http.StatusTooManyRequests, retryAfter: "1"},
+			{status: http.StatusTooManyRequests, retryAfter: "1"},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMockAPIConfig(url, 1, "Created"),
+				ExpectError: regexp.MustCompile(`Error\s+Creating\s+Environment(?s:.*)status\s+429(?s:.*)Too\s+Many\s+Requests`),
+			},
+		},
+	})
+}
+
+// testAccMockAPIConfig returns a configuration managing one environment
+// through the mock API at url, retrying each request up to maxRetries times
+func testAccMockAPIConfig(url string, maxRetries int, description string) string {
+	return fmt.Sprintf(`
+provider "kosli" {
+  api_url   = %[1]q
+  api_token = "test-token"
+  org       = "test-org"
+  timeout   = 1
+
+  retry = {
+    max_retries = %[2]d
+    min_wait    = 1
+    max_wait    = 1
+  }
+}
+
+resource "kosli_environment" "test" {
+  name        = "mock-env"
+  type        = "K8S"
+  description = %[3]q
+}
+`, url, maxRetries, description)
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// objectLocks serializes the mutations of each named Kosli object within
+// this provider process, across resources and provider configurations.
+// Terraform runs operations on different resource instances in parallel, and
+// several of them are a read followed by a write, such as a membership
+// change or the archive of a replaced or renamed resource. Holding the lock
+// keeps the steps of one operation from interleaving with those of another
+// on the same object. It does not order the operations: a destroy can still
+// archive an object after the create of its successor under the same name,
+// which the post-create read in retryReadAfterCreate detects.
+var objectLocks keyedMutex
+
+// lockObject waits until no other create, update or delete of the object
+// of kind (e.g. "environment") named name, in the organization that c acts
+// on with ctx, is in progress, and returns the function that lets the next
+// one proceed. Logical and physical environments share a name space, so
+// both use the "environment" kind.
+func lockObject(ctx context.Context, c *client.Client, kind, name string) func() {
+	org := ""
+	if c != nil {
+		org = c.OrganizationFor(ctx)
+	}
+	key := fmt.Sprintf("%s/%s/%s", org, kind, name)
+
+	if unlock, ok := objectLocks.tryLock(key); ok {
+		return unlock
+	}
+	tflog.Debug(ctx, "Waiting for another operation on the same Kosli object", map[string]any{
+		"kind": kind,
+		"name": name,
+	})
+	return objectLocks.lock(key)
+}
+
+// keyedMutex is a set of mutexes, one per key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// mutex returns the mutex of key.
+func (k *keyedMutex) mutex(key string) *sync.Mutex {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.locks == nil {
+		k.locks = map[string]*sync.Mutex{}
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		k.locks[key] = l
+	}
+	return l
+}
+
+// lock locks the mutex of key and returns the function that unlocks it.
+func (k *keyedMutex) lock(key string) func() {
+	l := k.mutex(key)
+	l.Lock()
+	return l.Unlock
+}
+
+// tryLock locks the mutex of key if it is free, and returns the function
+// that unlocks it and whether it did.
+func (k *keyedMutex) tryLock(key string) (func(), bool) {
+	l := k.mutex(key)
+	if !l.TryLock() {
+		return nil, false
+	}
+	return l.Unlock, true
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestLockObject_SameObject(t *testing.T) {
+	c, err := client.NewClient("test-token", "test-org")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	unlock := lockObject(context.Background(), c, "environment", "same-object")
+
+	locked := make(chan func())
+	go func() {
+		locked <- lockObject(context.Background(), c, "environment", "same-object")
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("Expected the second lock to wait for the first")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case unlock := <-locked:
+		unlock()
+	case <-time.After(time.Second):
+		t.Fatal("Expected the second lock once the first was released")
+	}
+}
+
+func TestLockObject_OtherObjects(t *testing.T) {
+	c, err := client.NewClient("test-token", "test-org")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	other, err := client.NewClient("test-token", "other-org")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	unlock := lockObject(context.Background(), c, "environment", "other-objects")
+	defer unlock()
+
+	// Another name, kind or organization does not wait
+	for _, l := range []func() func(){
+		func() func() { return lockObject(context.Background(), c, "environment", "other-objects-2") },
+		func() func() { return lockObject(context.Background(), c, "flow", "other-objects") },
+		func() func() { return lockObject(context.Background(), other, "environment", "other-objects") },
+		func() func() {
+			return lockObject(client.WithOrgOverride(context.Background(), "other-org"), c, "environment", "other-objects")
+		},
+	} {
+		done := make(chan func())
+		go func() { done <- l() }()
+		select {
+		case unlock := <-done:
+			unlock()
+		case <-time.After(time.Second):
+			t.Fatal("Expected a lock on another object not to wait")
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// descriptionDiffSuppressor keeps the prior state value of a description when
+// the configured value differs from it only in leading or trailing whitespace
+// or in line endings. The Kosli API occasionally normalizes descriptions this
+// way, which would otherwise show up as a perpetual diff.
+type descriptionDiffSuppressor struct{}
+
+var _ planmodifier.String = descriptionDiffSuppressor{}
+
+// Description returns a plain text description of the plan modifier.
+func (m descriptionDiffSuppressor) Description(ctx context.Context) string {
+	return "Ignores differences in surrounding whitespace and line endings."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (m descriptionDiffSuppressor) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString plans the state value if it is insignificantly different
+// from the configured value.
+func (m descriptionDiffSuppressor) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compare against on create, and unknown or null values are
+	// real changes
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() ||
+		req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if normalizeDescription(req.ConfigValue.ValueString()) == normalizeDescription(req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// normalizeDescription converts line endings to "\n" and trims surrounding
+// whitespace.
+func normalizeDescription(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.TrimSpace(s)
+}
+
+// jqRulesOrderSuppressor keeps the prior state value of jq_rules when
+// unordered_rules is set and the configured rules differ from it only in
+// order. Every rule must hold for an attestation to be compliant, so
+// reordering them does not change evaluation, but updating the list would
+// publish a new version of the custom attestation type.
+type jqRulesOrderSuppressor struct{}
+
+var _ planmodifier.List = jqRulesOrderSuppressor{}
+
+// Description returns a plain text description of the plan modifier.
+func (m jqRulesOrderSuppressor) Description(ctx context.Context) string {
+	return "Ignores the order of the rules when unordered_rules is set."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (m jqRulesOrderSuppressor) MarkdownDescription(ctx context.Context) string {
+	return "Ignores the order of the rules when `unordered_rules` is set."
+}
+
+// PlanModifyList plans the state value if unordered_rules is set and it has
+// the same rules as the configured value.
+func (m jqRulesOrderSuppressor) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Nothing to compare against on create, and unknown or null values are
+	// real changes
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() ||
+		req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var unordered types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("unordered_rules"), &unordered)...)
+	if resp.Diagnostics.HasError() || !unordered.ValueBool() {
+		return
+	}
+
+	var configured, prior []string
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &configured, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if sameJqRules(configured, prior) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// sameJqRules reports whether a and b hold the same rules, in any order. A
+// rule listed twice must be listed twice in both.
+func sameJqRules(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDescriptionDiffSuppressor(t *testing.T) {
+	tests := []struct {
+		name   string
+		config types.String
+		state  types.String
+		want   types.String
+	}{
+		{"trailing newline", types.StringValue("Production cluster\n"), types.StringValue("Production cluster"), types.StringValue("Production cluster")},
+		{"surrounding spaces", types.StringValue("  Production cluster "), types.StringValue("Production cluster"), types.StringValue("Production cluster")},
+		{"line endings", types.StringValue("line one\r\nline two"), types.StringValue("line one\nline two"), types.StringValue("line one\nline two")},
+		{"real change", types.StringValue("Staging cluster"), types.StringValue("Production cluster"), types.StringValue("Staging cluster")},
+		{"inner whitespace", types.StringValue("Production  cluster"), types.StringValue("Production cluster"), types.StringValue("Production  cluster")},
+		{"create", types.StringValue("Production cluster\n"), types.StringNull(), types.StringValue("Production cluster\n")},
+		{"removed", types.StringNull(), types.StringValue("Production cluster"), types.StringNull()},
+		{"unknown", types.StringUnknown(), types.StringValue("Production cluster"), types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{ConfigValue: tt.config, StateValue: tt.state, PlanValue: tt.config}
+			resp := &planmodifier.StringResponse{PlanValue: tt.config}
+
+			descriptionDiffSuppressor{}.PlanModifyString(context.Background(), req, resp)
+
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("Expected plan value %v, got %v", tt.want, resp.PlanValue)
+			}
+		})
+	}
+}
+
+func TestJqRulesOrderSuppressor(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&customAttestationTypeResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	rules := func(rules ...string) types.List {
+		if rules == nil {
+			return types.ListNull(types.StringType)
+		}
+		values := make([]attr.Value, len(rules))
+		for i, rule := range rules {
+			values[i] = types.StringValue(rule)
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	tests := []struct {
+		name      string
+		unordered any
+		config    types.List
+		state     types.List
+		want      types.List
+	}{
+		{"reordered", true, rules(".b", ".a"), rules(".a", ".b"), rules(".a", ".b")},
+		{"reordered with duplicates", true, rules(".a", ".b", ".a"), rules(".a", ".a", ".b"), rules(".a", ".a", ".b")},
+		{"rule added", true, rules(".b", ".a", ".c"), rules(".a", ".b"), rules(".b", ".a", ".c")},
+		{"rule replaced", true, rules(".b", ".c"), rules(".a", ".b"), rules(".b", ".c")},
+		{"duplicate differs", true, rules(".a", ".b", ".b"), rules(".a", ".a", ".b"), rules(".a", ".b", ".b")},
+		{"ordered", false, rules(".b", ".a"), rules(".a", ".b"), rules(".b", ".a")},
+		{"unset", nil, rules(".b", ".a"), rules(".a", ".b"), rules(".b", ".a")},
+		{"create", true, rules(".b", ".a"), rules(), rules(".b", ".a")},
+		{"removed", true, rules(), rules(".a", ".b"), rules()},
+		{"unknown", true, types.ListUnknown(types.StringType), rules(".a", ".b"), types.ListUnknown(types.StringType)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name":            tftypes.NewValue(tftypes.String, "security-scan"),
+					"description":     tftypes.NewValue(tftypes.String, nil),
+					"schema":          tftypes.NewValue(tftypes.String, nil),
+					"jq_rules":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+					"unordered_rules": tftypes.NewValue(tftypes.Bool, tt.unordered),
+					"version":         tftypes.NewValue(tftypes.Number, nil),
+					"latest_version":  tftypes.NewValue(tftypes.Number, nil),
+					"app_url":         tftypes.NewValue(tftypes.String, nil),
+					"schema_sha256":   tftypes.NewValue(tftypes.String, nil),
+				}),
+			}
+			req := planmodifier.ListRequest{Config: config, ConfigValue: tt.config, StateValue: tt.state, PlanValue: tt.config}
+			resp := &planmodifier.ListResponse{PlanValue: tt.config}
+
+			jqRulesOrderSuppressor{}.PlanModifyList(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("Expected plan value %v, got %v", tt.want, resp.PlanValue)
+			}
+		})
+	}
+}
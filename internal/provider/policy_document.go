@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// policySchemaURL is the _schema of the policy documents this provider
+// knows how to check.
+const policySchemaURL = "https://docs.kosli.com/schemas/policy/v1"
+
+// Keys allowed in each part of a policy document.
+var (
+	policyDocumentKeys    = []string{"_schema", "artifacts"}
+	policyArtifactsKeys   = []string{"provenance", "trail-compliance", "attestations"}
+	policyRequirementKeys = []string{"required", "exceptions"}
+	policyExceptionKeys   = []string{"if"}
+	policyAttestationKeys = []string{"name", "type", "if"}
+)
+
+// yamlErrorLine extracts the line number from a YAML syntax error.
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// policyProblem is a problem found in a policy document. Line and Column
+// are 1-based; Column is 0 when only the line is known, and both are 0 for
+// problems with the document as a whole.
+type policyProblem struct {
+	Line    int
+	Column  int
+	Message string
+	// Warning marks problems that do not stop the policy from being
+	// created, e.g. a _schema this provider does not know.
+	Warning bool
+}
+
+// String formats the problem with its position.
+func (p policyProblem) String() string {
+	switch {
+	case p.Line == 0:
+		return p.Message
+	case p.Column == 0:
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	default:
+		return fmt.Sprintf("line %d, column %d: %s", p.Line, p.Column, p.Message)
+	}
+}
+
+// lintPolicyDocument checks the YAML syntax of a policy document and its
+// structure against the Kosli policy schema: known keys, and the types of
+// their values. Whether attestation types or exception expressions are
+// valid is left to the API.
+func lintPolicyDocument(content string) []policyProblem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		problem := policyProblem{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			problem.Line, _ = strconv.Atoi(m[1])
+			problem.Message = strings.TrimPrefix(err.Error(), m[0])
+		}
+		return []policyProblem{problem}
+	}
+	if len(doc.Content) == 0 {
+		return []policyProblem{{Message: "the policy document is empty"}}
+	}
+
+	l := &policyLinter{}
+	root := l.mapping(doc.Content[0], "the policy document", policyDocumentKeys)
+	if root == nil {
+		return l.problems
+	}
+
+	schemaNode, ok := root["_schema"]
+	switch {
+	case !ok:
+		l.add(doc.Content[0], false, "missing _schema; set it to %s", policySchemaURL)
+	case l.scalar(schemaNode, "_schema", "!!str") && schemaNode.Value != policySchemaURL:
+		l.add(schemaNode, true, "unknown _schema %q; only %s can be checked", schemaNode.Value, policySchemaURL)
+	}
+
+	if artifacts, ok := root["artifacts"]; ok {
+		l.artifacts(artifacts)
+	}
+
+	// Report problems in the order they appear in the document
+	slices.SortStableFunc(l.problems, func(a, b policyProblem) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	return l.problems
+}
+
+// policyLinter collects the problems found while walking a policy document.
+type policyLinter struct {
+	problems []policyProblem
+}
+
+// add records a problem at node.
+func (l *policyLinter) add(node *yaml.Node, warning bool, format string, args ...any) {
+	l.problems = append(l.problems, policyProblem{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+		Warning: warning,
+	})
+}
+
+// mapping checks that node is a mapping with only allowed keys, each at
+// most once, and returns its values by key. It returns nil if node is not
+// a mapping.
+func (l *policyLinter) mapping(node *yaml.Node, what string, allowed []string) map[string]*yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		l.add(node, false, "%s must be a mapping", what)
+		return nil
+	}
+
+	values := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case !slices.Contains(allowed, key.Value):
+			l.add(key, false, "unknown key %q in %s; expected one of: %s", key.Value, what, strings.Join(allowed, ", "))
+		case values[key.Value] != nil:
+			l.add(key, false, "duplicate key %q in %s", key.Value, what)
+		default:
+			values[key.Value] = value
+		}
+	}
+	return values
+}
+
+// scalar checks that node is a scalar with the given YAML tag, e.g. !!str.
+func (l *policyLinter) scalar(node *yaml.Node, what, tag string) bool {
+	if node.Kind != yaml.ScalarNode || node.ShortTag() != tag {
+		l.add(node, false, "%s must be a %s", what, map[string]string{"!!str": "string", "!!bool": "boolean"}[tag])
+		return false
+	}
+	return true
+}
+
+// sequence checks that node is a sequence and returns its items.
+func (l *policyLinter) sequence(node *yaml.Node, what string) []*yaml.Node {
+	if node.Kind != yaml.SequenceNode {
+		l.add(node, false, "%s must be a list", what)
+		return nil
+	}
+	return node.Content
+}
+
+// artifacts checks the artifacts section.
+func (l *policyLinter) artifacts(node *yaml.Node) {
+	values := l.mapping(node, "artifacts", policyArtifactsKeys)
+	for _, name := range []string{"provenance", "trail-compliance"} {
+		if requirement, ok := values[name]; ok {
+			l.requirement(requirement, "artifacts."+name)
+		}
+	}
+
+	attestations, ok := values["attestations"]
+	if !ok {
+		return
+	}
+	for i, item := range l.sequence(attestations, "artifacts.attestations") {
+		what := fmt.Sprintf("artifacts.attestations[%d]", i)
+		attestation := l.mapping(item, what, policyAttestationKeys)
+		if attestation == nil {
+			continue
+		}
+		if name, ok := attestation["name"]; !ok {
+			l.add(item, false, "missing name in %s", what)
+		} else {
+			l.scalar(name, what+".name", "!!str")
+		}
+		if typ, ok := attestation["type"]; ok {
+			l.scalar(typ, what+".type", "!!str")
+		}
+		if cond, ok := attestation["if"]; ok {
+			l.scalar(cond, what+".if", "!!str")
+		}
+	}
+}
+
+// requirement checks a provenance or trail-compliance requirement.
+func (l *policyLinter) requirement(node *yaml.Node, what string) {
+	values := l.mapping(node, what, policyRequirementKeys)
+	if required, ok := values["required"]; ok {
+		l.scalar(required, what+".required", "!!bool")
+	}
+
+	exceptions, ok := values["exceptions"]
+	if !ok {
+		return
+	}
+	for i, item := range l.sequence(exceptions, what+".exceptions") {
+		exception := fmt.Sprintf("%s.exceptions[%d]", what, i)
+		values := l.mapping(item, exception, policyExceptionKeys)
+		if values == nil {
+			continue
+		}
+		if cond, ok := values["if"]; !ok {
+			l.add(item, false, "missing if in %s", exception)
+		} else {
+			l.scalar(cond, exception+".if", "!!str")
+		}
+	}
+}
+
+// policyDocumentValidator lints a policy document at plan time, so
+// malformed policies are reported with their position before they are
+// created or attached to environments.
+type policyDocumentValidator struct{}
+
+var _ validator.String = policyDocumentValidator{}
+
+// Description returns a plain text description of the validator.
+func (v policyDocumentValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a YAML policy document following %s", policySchemaURL)
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v policyDocumentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString reports each problem in the configured policy document.
+func (v policyDocumentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, p := range lintPolicyDocument(req.ConfigValue.ValueString()) {
+		if p.Warning {
+			resp.Diagnostics.AddAttributeWarning(req.Path, "Unchecked Policy Document", p.String())
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Policy Document", p.String())
+	}
+}
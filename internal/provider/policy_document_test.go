@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testPolicyDocument = `_schema: https://docs.kosli.com/schemas/policy/v1
+artifacts:
+  provenance:
+    required: true
+    exceptions:
+      - if: ${{ matches(artifact.name, "^datadog:.*") }}
+  trail-compliance:
+    required: false
+  attestations:
+    - name: unit-test
+      type: junit
+    - name: dependency-scan
+      type: "*"
+      if: ${{ flow.name == "backend" }}
+`
+
+func TestLintPolicyDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // formatted problems, in order
+	}{
+		{"valid", testPolicyDocument, nil},
+		{"schema only", "_schema: https://docs.kosli.com/schemas/policy/v1\n", nil},
+		{"empty", "", []string{"the policy document is empty"}},
+		{"syntax error", "_schema: x\nartifacts: [\n", []string{"line 2: did not find expected node content"}},
+		{"not a mapping", "- provenance\n", []string{"line 1, column 1: the policy document must be a mapping"}},
+		{"missing schema", "artifacts:\n  provenance:\n    required: true\n", []string{"line 1, column 1: missing _schema; set it to https://docs.kosli.com/schemas/policy/v1"}},
+		{
+			"unknown top-level key",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nenvironments: {}\n",
+			[]string{`line 2, column 1: unknown key "environments" in the policy document; expected one of: _schema, artifacts`},
+		},
+		{
+			"misspelled key",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  trail_compliance:\n    required: true\n",
+			[]string{`line 3, column 3: unknown key "trail_compliance" in artifacts; expected one of: provenance, trail-compliance, attestations`},
+		},
+		{
+			"duplicate key",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  provenance:\n    required: true\n    required: false\n",
+			[]string{`line 5, column 5: duplicate key "required" in artifacts.provenance`},
+		},
+		{
+			"required not a boolean",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  provenance:\n    required: yes please\n",
+			[]string{"line 4, column 15: artifacts.provenance.required must be a boolean"},
+		},
+		{
+			"exception without if",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  provenance:\n    exceptions:\n      - when: x\n",
+			[]string{
+				`line 5, column 9: unknown key "when" in artifacts.provenance.exceptions[0]; expected one of: if`,
+				"line 5, column 9: missing if in artifacts.provenance.exceptions[0]",
+			},
+		},
+		{
+			"attestations not a list",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  attestations:\n    name: unit-test\n",
+			[]string{"line 4, column 5: artifacts.attestations must be a list"},
+		},
+		{
+			"attestation without name",
+			"_schema: https://docs.kosli.com/schemas/policy/v1\nartifacts:\n  attestations:\n    - type: junit\n",
+			[]string{"line 4, column 7: missing name in artifacts.attestations[0]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range lintPolicyDocument(tt.content) {
+				if p.Warning {
+					t.Errorf("Unexpected warning: %s", p)
+					continue
+				}
+				got = append(got, p.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Expected problems:\n%s\ngot:\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestLintPolicyDocument_UnknownSchema(t *testing.T) {
+	problems := lintPolicyDocument("_schema: https://docs.kosli.com/schemas/policy/v2\nanything: goes\n")
+
+	// Unknown keys are still reported, as v1 is the only schema known
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", problems)
+	}
+	if !problems[0].Warning || problems[0].Line != 1 || problems[0].Column != 10 {
+		t.Errorf("Expected a warning at line 1, column 10, got %+v", problems[0])
+	}
+}
+
+func TestPolicyDocumentValidator(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       types.String
+		wantErr     bool
+		wantWarning bool
+	}{
+		{"valid", types.StringValue(testPolicyDocument), false, false},
+		{"null", types.StringNull(), false, false},
+		{"unknown", types.StringUnknown(), false, false},
+		{"invalid", types.StringValue("_schema: https://docs.kosli.com/schemas/policy/v1\nartifact: {}\n"), true, false},
+		{"unknown schema", types.StringValue("_schema: https://docs.kosli.com/schemas/policy/v2\n"), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("content"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			policyDocumentValidator{}.ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("Expected error %v, got %v: %v", tt.wantErr, got, resp.Diagnostics.Errors())
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("Expected warning %v, got %v: %v", tt.wantWarning, got, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
@@ -3,14 +3,22 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
@@ -20,10 +28,27 @@ const (
 
 	// DefaultTimeout is the default HTTP timeout in seconds.
 	DefaultTimeout = 30
+
+	// DefaultCircuitBreakerCooldown is the default circuit breaker cooldown in seconds.
+	DefaultCircuitBreakerCooldown = 30
+
+	// DefaultTokenExpiryWarningDays is the default number of days before the
+	// API token expires from which a warning is shown.
+	DefaultTokenExpiryWarningDays = 14
+)
+
+// Default retry settings, mirroring the client defaults so the provider
+// block documents the effective values.
+var (
+	DefaultRetryMaxRetries = int64(client.DefaultRetryMax)
+	DefaultRetryMinWait    = int64(client.DefaultRetryWaitMin / time.Second)
+	DefaultRetryMaxWait    = int64(client.DefaultRetryWaitMax / time.Second)
 )
 
 // Ensure KosliProvider satisfies various provider interfaces.
 var _ provider.Provider = &KosliProvider{}
+var _ provider.ProviderWithActions = &KosliProvider{}
+var _ provider.ProviderWithFunctions = &KosliProvider{}
 
 // KosliProvider defines the provider implementation.
 type KosliProvider struct {
@@ -34,10 +59,72 @@ type KosliProvider struct {
 
 // KosliProviderModel describes the provider data model.
 type KosliProviderModel struct {
-	APIToken types.String `tfsdk:"api_token"`
-	Org      types.String `tfsdk:"org"`
-	APIURL   types.String `tfsdk:"api_url"`
-	Timeout  types.Int64  `tfsdk:"timeout"`
+	APIToken            types.String `tfsdk:"api_token"`
+	Org                 types.String `tfsdk:"org"`
+	APIURL              types.String `tfsdk:"api_url"`
+	Timeout             types.Int64  `tfsdk:"timeout"`
+	ReadTimeout         types.Int64  `tfsdk:"read_timeout"`
+	WriteTimeout        types.Int64  `tfsdk:"write_timeout"`
+	MaxParallelRequests types.Int64  `tfsdk:"max_parallel_requests"`
+	DefaultPageSize     types.Int64  `tfsdk:"default_page_size"`
+	MaxResponseSizeMB   types.Int64  `tfsdk:"max_response_size_mb"`
+	Retry               types.Object `tfsdk:"retry"`
+	RateLimit           types.Object `tfsdk:"rate_limit"`
+	CircuitBreaker      types.Object `tfsdk:"circuit_breaker"`
+	ReadCache           types.Bool   `tfsdk:"read_cache"`
+	SkipReadAfterWrite  types.Bool   `tfsdk:"skip_read_after_write"`
+	DefaultTags         types.Object `tfsdk:"default_tags"`
+
+	KeepEmptyDescriptions types.Bool `tfsdk:"keep_empty_descriptions"`
+
+	TokenExpiryWarningDays types.Int64 `tfsdk:"token_expiry_warning_days"`
+}
+
+// KosliResourceData is the provider data passed to resources. Data sources
+// receive the *client.Client directly.
+type KosliResourceData struct {
+	Client *client.Client
+
+	// SkipReadAfterWrite mirrors the skip_read_after_write provider attribute.
+	SkipReadAfterWrite bool
+
+	// KeepEmptyDescriptions mirrors the keep_empty_descriptions provider
+	// attribute.
+	KeepEmptyDescriptions bool
+
+	// DefaultTags are the tags from the default_tags provider attribute.
+	// Never nil.
+	DefaultTags map[string]string
+
+	// EnvironmentNames records the environment names planned with this
+	// provider configuration, to catch physical and logical environments
+	// sharing a name.
+	EnvironmentNames *environmentNames
+}
+
+// KosliProviderRetryModel describes the provider `retry` block.
+type KosliProviderRetryModel struct {
+	MaxRetries        types.Int64 `tfsdk:"max_retries"`
+	MinWait           types.Int64 `tfsdk:"min_wait"`
+	MaxWait           types.Int64 `tfsdk:"max_wait"`
+	RespectRetryAfter types.Bool  `tfsdk:"respect_retry_after"`
+}
+
+// KosliProviderRateLimitModel describes the provider `rate_limit` block.
+type KosliProviderRateLimitModel struct {
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
+// KosliProviderDefaultTagsModel describes the provider `default_tags` block.
+type KosliProviderDefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// KosliProviderCircuitBreakerModel describes the provider `circuit_breaker` block.
+type KosliProviderCircuitBreakerModel struct {
+	FailureThreshold types.Int64 `tfsdk:"failure_threshold"`
+	Cooldown         types.Int64 `tfsdk:"cooldown"`
 }
 
 // Metadata returns the provider type name.
@@ -52,7 +139,7 @@ func (p *KosliProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 		Description: "Manage Kosli resources using Terraform. The Kosli provider allows you to define and manage Kosli custom attestation types as Infrastructure-as-Code.",
 		Attributes: map[string]schema.Attribute{
 			"api_token": schema.StringAttribute{
-				Description: "Kosli API token for authentication. Can also be set via KOSLI_API_TOKEN environment variable.",
+				Description: "Kosli API token for authentication. Can also be set via the `KOSLI_API_TOKEN_<ORG>` environment variable, where `<ORG>` is `org` in upper case with every character other than a letter or digit replaced by `_` (e.g. `KOSLI_API_TOKEN_ACME_EU` for `acme-eu`), or else via the KOSLI_API_TOKEN environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
@@ -61,13 +148,110 @@ func (p *KosliProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 			},
 			"api_url": schema.StringAttribute{
-				Description: "Kosli API endpoint URL. Defaults to https://app.kosli.com (EU region). Use https://app.us.kosli.com for US region. Can also be set via KOSLI_API_URL environment variable.",
+				Description: "Kosli API endpoint URL. Defaults to https://app.kosli.com (EU region). Use https://app.us.kosli.com for US region. Must be the base URL of the host, without the `/api/v2` path. Can also be set via KOSLI_API_URL environment variable, or KOSLI_HOST as used by the Kosli CLI.",
 				Optional:    true,
 			},
 			"timeout": schema.Int64Attribute{
-				Description: "HTTP client timeout in seconds. Defaults to 30 seconds.",
+				Description: "HTTP client timeout in seconds, limiting each individual request attempt. Overridden by `read_timeout` and `write_timeout` for reads and writes respectively when they are set. Defaults to 30 seconds.",
+				Optional:    true,
+			},
+			"read_timeout": schema.Int64Attribute{
+				Description: "Maximum time in seconds for a read request, including retries and the waits between them. When set, it also replaces `timeout` as the limit of each individual attempt of a read. Defaults to 120 seconds, with each attempt limited by `timeout`.",
+				Optional:    true,
+			},
+			"write_timeout": schema.Int64Attribute{
+				Description: "Maximum time in seconds for a create, update or delete request (including uploads of flow templates and attestation type schemas), including retries and the waits between them. When set, it also replaces `timeout` as the limit of each individual attempt of a write, so slow uploads are not cut short. Defaults to 300 seconds, with each attempt limited by `timeout`.",
+				Optional:    true,
+			},
+			"retry": schema.SingleNestedAttribute{
+				Description: "Retry behavior for failed API requests. Requests that fail with a connection error, 429 Too Many Requests or a 5xx response (other than 501) are retried with exponential backoff. Non-idempotent requests (POST, PATCH), such as creating a new custom attestation type version, are only retried when they cannot have reached the API: on connection failures and 429 responses. Independently of these retries, idempotent requests are resent up to twice straight away after a connection reset, an unexpected EOF or a failed DNS lookup, even with `max_retries = 0`.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"max_retries": schema.Int64Attribute{
+						Description: "Maximum number of retries per request. Defaults to 3. Set to 0 to disable retries.",
+						Optional:    true,
+					},
+					"min_wait": schema.Int64Attribute{
+						Description: "Minimum wait between retries in seconds. Defaults to 1.",
+						Optional:    true,
+					},
+					"max_wait": schema.Int64Attribute{
+						Description: "Maximum wait between retries in seconds. Also caps delays requested via the Retry-After header. Defaults to 30.",
+						Optional:    true,
+					},
+					"respect_retry_after": schema.BoolAttribute{
+						Description: "Whether to honor the Retry-After header (seconds or HTTP date) on 429 and 503 responses instead of the exponential schedule. Defaults to true.",
+						Optional:    true,
+					},
+				},
+			},
+			"rate_limit": schema.SingleNestedAttribute{
+				Description: "Client-side rate limit for API requests, shared by all resources and data sources using this provider configuration. Useful for large applies that would otherwise trip server-side throttling. Unlimited when omitted.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"requests_per_second": schema.Float64Attribute{
+						Description: "Average number of requests per second allowed. Must be greater than 0.",
+						Required:    true,
+					},
+					"burst": schema.Int64Attribute{
+						Description: "Maximum number of requests allowed in a single burst. Defaults to 1.",
+						Optional:    true,
+					},
+				},
+			},
+			"max_parallel_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once, shared by all resources and data sources using this provider configuration. Protects smaller organizations from server-side throttling when Terraform's parallelism (10 by default) fans out. Retries of a request count against its slot. Unlimited when omitted.",
+				Optional:    true,
+			},
+			"default_page_size": schema.Int64Attribute{
+				Description: "Number of items requested per page by data sources and attributes that read paginated lists, such as `kosli_environments` and the compliance attributes of `kosli_environment`. Larger pages mean fewer API requests in organizations with many objects, smaller pages less data per response. A `page_size` set on a data source takes precedence. Defaults to 100.",
+				Optional:    true,
+			},
+			"max_response_size_mb": schema.Int64Attribute{
+				Description: "Maximum size in megabytes (MiB) of an API response the provider reads. A larger response, such as a huge error page from a misbehaving proxy, fails the request instead of exhausting the provider's memory. Defaults to 50.",
+				Optional:    true,
+			},
+			"read_cache": schema.BoolAttribute{
+				Description: "Whether to cache API reads in memory for the duration of a single Terraform operation, so resources and data sources reading the same object share one API call. The cache is cleared whenever the provider writes to the API. Defaults to true.",
+				Optional:    true,
+			},
+			"skip_read_after_write": schema.BoolAttribute{
+				Description: "Whether to trust the planned values after a successful create or update instead of reading the object back from the API. Roughly halves the API calls of large applies, at the cost of not detecting differences between the configuration and what the API stored until the next refresh. Applies to environments, logical environments, flows and custom attestation types. Defaults to false.",
+				Optional:    true,
+			},
+			"keep_empty_descriptions": schema.BoolAttribute{
+				Description: "Whether to keep `description = \"\"` as an empty string in state. The Kosli API does not distinguish an empty description from an unset one, so by default an empty description is read back as null, and a resource that sets `description = \"\"` fails to apply with an inconsistent result; omit `description` instead, or enable this. Applies to environments, logical environments, flows, policies and custom attestation types. Defaults to false.",
+				Optional:    true,
+			},
+			"default_tags": schema.SingleNestedAttribute{
+				Description: "Tags applied to every `kosli_environment` managed by this provider configuration. Tags set on a resource override default tags with the same key. The merged tags are exposed in the resource's `tags_all` attribute.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						Description: "Key-value pairs to tag every environment with.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"token_expiry_warning_days": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of days before the API token expires from which every run shows a warning, so the token can be rotated before runs using it fail. Only tokens that carry their expiry, i.e. JSON Web Tokens with an `exp` claim, can be checked. Set to 0 to disable the warning. Defaults to %d.", DefaultTokenExpiryWarningDays),
 				Optional:    true,
 			},
+			"circuit_breaker": schema.SingleNestedAttribute{
+				Description: "Circuit breaker for API requests. After `failure_threshold` consecutive requests fail with a 5xx response or connection error (after retries), further requests fail immediately until a probe request succeeds, so an outage fails the run fast with one clear error. Disabled when omitted.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"failure_threshold": schema.Int64Attribute{
+						Description: "Number of consecutive failed requests that opens the circuit. Must be at least 1.",
+						Required:    true,
+					},
+					"cooldown": schema.Int64Attribute{
+						Description: "Seconds to wait after the circuit opens before a single probe request is allowed through. Defaults to 30.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -82,20 +266,24 @@ func (p *KosliProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 
 	// Resolve configuration values with environment variable fallbacks
-	apiToken := getConfigValue(config.APIToken, "KOSLI_API_TOKEN")
 	org := getConfigValue(config.Org, "KOSLI_ORG")
-	apiURL := getConfigValue(config.APIURL, "KOSLI_API_URL")
+	apiToken := getAPIToken(config.APIToken, org)
+	apiURL := getAPIURL(config.APIURL)
 
 	// Set default API URL if not provided
 	if apiURL == "" {
 		apiURL = DefaultAPIURL
 	}
 
+	// Reject URLs the client would turn into confusing 404s
+	apiURL, diags := normalizeAPIURL(apiURL)
+	resp.Diagnostics.Append(diags...)
+
 	// Validate required fields
 	if apiToken == "" {
 		resp.Diagnostics.AddError(
 			"Missing API Token",
-			"The provider requires an API token. Set the api_token attribute in the provider configuration or the KOSLI_API_TOKEN environment variable.",
+			fmt.Sprintf("The provider requires an API token. Set the api_token attribute in the provider configuration, the %s environment variable or the KOSLI_API_TOKEN environment variable.", orgTokenEnvVar(org)),
 		)
 	}
 
@@ -110,6 +298,17 @@ func (p *KosliProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	// Warn about an API token that is about to expire
+	warningDays := int64(DefaultTokenExpiryWarningDays)
+	if !config.TokenExpiryWarningDays.IsNull() {
+		warningDays = config.TokenExpiryWarningDays.ValueInt64()
+		if warningDays < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("token_expiry_warning_days"), "Invalid Token Expiry Warning Days", "token_expiry_warning_days must be at least 0.")
+			return
+		}
+	}
+	resp.Diagnostics.Append(tokenExpiryWarning(apiToken, time.Now(), time.Duration(warningDays)*24*time.Hour)...)
+
 	// Determine timeout
 	timeout := DefaultTimeout * time.Second
 	if !config.Timeout.IsNull() {
@@ -124,9 +323,117 @@ func (p *KosliProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		opts = append(opts, client.WithBaseURL(apiURL))
 	}
 
-	// Set timeout
+	// Set timeout. Must precede the retry policy, which copies the timeout
+	// into the retrying HTTP client it creates.
 	opts = append(opts, client.WithTimeout(timeout))
 
+	// Set per-operation deadlines
+	readTimeout := client.DefaultReadTimeout
+	if !config.ReadTimeout.IsNull() {
+		readTimeout = time.Duration(config.ReadTimeout.ValueInt64()) * time.Second
+	}
+	writeTimeout := client.DefaultWriteTimeout
+	if !config.WriteTimeout.IsNull() {
+		writeTimeout = time.Duration(config.WriteTimeout.ValueInt64()) * time.Second
+	}
+	opts = append(opts, client.WithOperationTimeouts(readTimeout, writeTimeout))
+
+	// Explicit read/write timeouts also replace timeout as the limit of each
+	// attempt, e.g. so that slow schema uploads are not cut short. Like
+	// timeout, must precede the retry policy.
+	var readAttemptTimeout, writeAttemptTimeout time.Duration
+	if !config.ReadTimeout.IsNull() {
+		readAttemptTimeout = readTimeout
+	}
+	if !config.WriteTimeout.IsNull() {
+		writeAttemptTimeout = writeTimeout
+	}
+	opts = append(opts, client.WithAttemptTimeouts(readAttemptTimeout, writeAttemptTimeout))
+
+	// Configure retries
+	retryOpts, diags := retryOptions(ctx, config.Retry)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts = append(opts, retryOpts...)
+
+	// Bound the number of concurrent requests
+	if !config.MaxParallelRequests.IsNull() {
+		maxParallel := config.MaxParallelRequests.ValueInt64()
+		if maxParallel < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_parallel_requests"), "Invalid Max Parallel Requests", "max_parallel_requests must be at least 1.")
+			return
+		}
+		opts = append(opts, client.WithMaxConcurrentRequests(int(maxParallel)))
+	}
+
+	// Set the page size of paginated list requests
+	if !config.DefaultPageSize.IsNull() {
+		pageSize := config.DefaultPageSize.ValueInt64()
+		if pageSize < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("default_page_size"), "Invalid Default Page Size", "default_page_size must be at least 1.")
+			return
+		}
+		opts = append(opts, client.WithDefaultPageSize(int(pageSize)))
+	}
+
+	// Cap the size of response bodies
+	if !config.MaxResponseSizeMB.IsNull() {
+		maxResponseSize := config.MaxResponseSizeMB.ValueInt64()
+		if maxResponseSize < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_response_size_mb"), "Invalid Max Response Size", "max_response_size_mb must be at least 1.")
+			return
+		}
+		opts = append(opts, client.WithMaxResponseSize(maxResponseSize<<20))
+	}
+
+	// Configure client-side rate limiting
+	rateLimitOpts, diags := rateLimitOptions(ctx, config.RateLimit)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts = append(opts, rateLimitOpts...)
+
+	// Configure the circuit breaker
+	breakerOpts, diags := circuitBreakerOptions(ctx, config.CircuitBreaker)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts = append(opts, breakerOpts...)
+
+	// Revalidate repeated reads with conditional GETs
+	opts = append(opts, client.WithETagCache(client.DefaultETagCacheSize))
+
+	// Cache reads for the rest of this operation unless disabled
+	if config.ReadCache.IsNull() || config.ReadCache.ValueBool() {
+		opts = append(opts, client.WithReadCache())
+	}
+
+	// Share concurrent reads of the same object, e.g. several data sources
+	// looking up one environment during a plan
+	opts = append(opts, client.WithGetDeduplication())
+
+	// Export request spans when OpenTelemetry is configured in the environment
+	tracingOpts, diags := tracingOptions(ctx, p.version)
+	resp.Diagnostics.Append(diags...)
+	opts = append(opts, tracingOpts...)
+
+	// Fail on unrecognized API response fields when developing the provider
+	if strict, _ := strconv.ParseBool(os.Getenv("KOSLI_STRICT_DECODING")); strict {
+		opts = append(opts, client.WithStrictDecoding())
+	}
+
+	// Forward the client's logs to tflog
+	opts = append(opts, clientLoggingOptions()...)
+
+	// Dump API traffic to a file when requested for support escalations
+	wireDumpOpts, diags := wireDumpOptions()
+	resp.Diagnostics.Append(diags...)
+	opts = append(opts, wireDumpOpts...)
+
 	// Set user agent with provider version
 	userAgent := fmt.Sprintf("terraform-provider-kosli/%s", p.version)
 	opts = append(opts, client.WithUserAgent(userAgent))
@@ -140,21 +447,41 @@ func (p *KosliProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	// Make the client available to resources and data sources
+	// Resolve tags applied to every environment
+	defaultTags, diags := defaultTagsValue(ctx, config.DefaultTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make the client available to resources, data sources and actions
 	resp.DataSourceData = kosliClient
-	resp.ResourceData = kosliClient
+	resp.ActionData = kosliClient
+	resp.ResourceData = &KosliResourceData{
+		Client:                kosliClient,
+		SkipReadAfterWrite:    config.SkipReadAfterWrite.ValueBool(),
+		KeepEmptyDescriptions: config.KeepEmptyDescriptions.ValueBool(),
+		DefaultTags:           defaultTags,
+		EnvironmentNames:      &environmentNames{},
+	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *KosliProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewActionResource,
+		NewArtifactResource,
+		NewSonarAttestationResource,
 		NewCustomAttestationTypeResource,
 		NewEnvironmentResource,
+		NewEnvironmentTagResource,
 		NewFlowResource,
 		NewLogicalEnvironmentResource,
+		NewLogicalEnvironmentMembershipResource,
 		NewPolicyResource,
 		NewPolicyAttachmentResource,
+		NewServerEnvironmentReportResource,
+		NewEnvironmentSnapshotReportResource,
 	}
 }
 
@@ -162,14 +489,35 @@ func (p *KosliProvider) Resources(ctx context.Context) []func() resource.Resourc
 func (p *KosliProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewActionDataSource,
+		NewAttestationDataSource,
 		NewCustomAttestationTypeDataSource,
+		NewCustomAttestationTypesDataSource,
 		NewEnvironmentDataSource,
+		NewEnvironmentRunningArtifactsDataSource,
+		NewEnvironmentSnapshotDataSource,
+		NewEnvironmentTagsDataSource,
+		NewEnvironmentsDataSource,
 		NewFlowDataSource,
+		NewFlowComplianceDataSource,
 		NewLogicalEnvironmentDataSource,
 		NewPolicyDataSource,
 	}
 }
 
+// Actions defines the actions implemented in the provider.
+func (p *KosliProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewCustomAttestationTypeRollbackAction,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *KosliProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewValidatePolicyFunction,
+	}
+}
+
 // New returns a new provider instance.
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -179,6 +527,192 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// retryOptions converts the optional `retry` block into client options,
+// filling unset attributes with the client defaults.
+func retryOptions(ctx context.Context, retry types.Object) ([]client.ClientOption, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	maxRetries := DefaultRetryMaxRetries
+	minWait := DefaultRetryMinWait
+	maxWait := DefaultRetryMaxWait
+	respectRetryAfter := true
+
+	if !retry.IsNull() && !retry.IsUnknown() {
+		var cfg KosliProviderRetryModel
+		diags.Append(retry.As(ctx, &cfg, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if !cfg.MaxRetries.IsNull() {
+			maxRetries = cfg.MaxRetries.ValueInt64()
+		}
+		if !cfg.MinWait.IsNull() {
+			minWait = cfg.MinWait.ValueInt64()
+		}
+		if !cfg.MaxWait.IsNull() {
+			maxWait = cfg.MaxWait.ValueInt64()
+		}
+		if !cfg.RespectRetryAfter.IsNull() {
+			respectRetryAfter = cfg.RespectRetryAfter.ValueBool()
+		}
+	}
+
+	if maxRetries < 0 {
+		diags.AddAttributeError(path.Root("retry").AtName("max_retries"), "Invalid Retry Configuration", "max_retries must be 0 or greater.")
+	}
+	if minWait <= 0 {
+		diags.AddAttributeError(path.Root("retry").AtName("min_wait"), "Invalid Retry Configuration", "min_wait must be greater than 0.")
+	}
+	if maxWait < minWait {
+		diags.AddAttributeError(path.Root("retry").AtName("max_wait"), "Invalid Retry Configuration", "max_wait must be greater than or equal to min_wait.")
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return []client.ClientOption{
+		client.WithRetryAfter(respectRetryAfter),
+		client.WithRetryPolicy(int(maxRetries), time.Duration(minWait)*time.Second, time.Duration(maxWait)*time.Second),
+	}, diags
+}
+
+// rateLimitOptions converts the optional `rate_limit` block into client
+// options. No options are returned when the block is omitted.
+func rateLimitOptions(ctx context.Context, rateLimit types.Object) ([]client.ClientOption, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if rateLimit.IsNull() || rateLimit.IsUnknown() {
+		return nil, diags
+	}
+
+	var cfg KosliProviderRateLimitModel
+	diags.Append(rateLimit.As(ctx, &cfg, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	requestsPerSecond := cfg.RequestsPerSecond.ValueFloat64()
+	burst := int64(1)
+	if !cfg.Burst.IsNull() {
+		burst = cfg.Burst.ValueInt64()
+	}
+
+	if requestsPerSecond <= 0 {
+		diags.AddAttributeError(path.Root("rate_limit").AtName("requests_per_second"), "Invalid Rate Limit Configuration", "requests_per_second must be greater than 0.")
+	}
+	if burst < 1 {
+		diags.AddAttributeError(path.Root("rate_limit").AtName("burst"), "Invalid Rate Limit Configuration", "burst must be at least 1.")
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return []client.ClientOption{client.WithRateLimit(requestsPerSecond, int(burst))}, diags
+}
+
+// circuitBreakerOptions converts the optional `circuit_breaker` block into
+// client options. No options are returned when the block is omitted.
+func circuitBreakerOptions(ctx context.Context, circuitBreaker types.Object) ([]client.ClientOption, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if circuitBreaker.IsNull() || circuitBreaker.IsUnknown() {
+		return nil, diags
+	}
+
+	var cfg KosliProviderCircuitBreakerModel
+	diags.Append(circuitBreaker.As(ctx, &cfg, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	threshold := cfg.FailureThreshold.ValueInt64()
+	cooldown := int64(DefaultCircuitBreakerCooldown)
+	if !cfg.Cooldown.IsNull() {
+		cooldown = cfg.Cooldown.ValueInt64()
+	}
+
+	if threshold < 1 {
+		diags.AddAttributeError(path.Root("circuit_breaker").AtName("failure_threshold"), "Invalid Circuit Breaker Configuration", "failure_threshold must be at least 1.")
+	}
+	if cooldown <= 0 {
+		diags.AddAttributeError(path.Root("circuit_breaker").AtName("cooldown"), "Invalid Circuit Breaker Configuration", "cooldown must be greater than 0.")
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return []client.ClientOption{client.WithCircuitBreaker(int(threshold), time.Duration(cooldown)*time.Second)}, diags
+}
+
+// defaultTagsValue converts the optional `default_tags` block into a tag map.
+// An empty map is returned when the block or its tags are omitted.
+func defaultTagsValue(ctx context.Context, defaultTags types.Object) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tags := map[string]string{}
+
+	if defaultTags.IsNull() || defaultTags.IsUnknown() {
+		return tags, diags
+	}
+
+	var cfg KosliProviderDefaultTagsModel
+	diags.Append(defaultTags.As(ctx, &cfg, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || cfg.Tags.IsNull() || cfg.Tags.IsUnknown() {
+		return tags, diags
+	}
+
+	diags.Append(cfg.Tags.ElementsAs(ctx, &tags, false)...)
+	return tags, diags
+}
+
+// normalizeAPIURL checks that apiURL is an absolute http(s) URL of a Kosli
+// host, without a path, query or fragment, and returns it without a trailing
+// slash. A trailing API path, as in https://app.kosli.com/api/v2, is removed
+// with a warning, since the client appends it itself.
+func normalizeAPIURL(apiURL string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	invalid := func(reason string) (string, diag.Diagnostics) {
+		diags.AddAttributeError(
+			path.Root("api_url"),
+			"Invalid API URL",
+			fmt.Sprintf("The Kosli API URL %q (from api_url or the KOSLI_API_URL or KOSLI_HOST environment variable) %s. "+
+				"Use the base URL of the Kosli host, e.g. %s or https://app.us.kosli.com.", apiURL, reason, DefaultAPIURL),
+		)
+		return "", diags
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return invalid(fmt.Sprintf("could not be parsed: %s", err))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return invalid("must start with https:// or http://")
+	}
+	if u.Host == "" {
+		return invalid("has no host")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return invalid("must not have a query or fragment")
+	}
+
+	apiPath := strings.TrimRight(u.Path, "/")
+	if apiPath == client.DefaultAPIPath {
+		diags.AddAttributeWarning(
+			path.Root("api_url"),
+			"API URL Includes the API Path",
+			fmt.Sprintf("The Kosli API URL %q ends with %s, which the provider adds itself. It was removed; "+
+				"set the base URL of the Kosli host instead to silence this warning.", apiURL, client.DefaultAPIPath),
+		)
+		apiPath = ""
+	}
+	if apiPath != "" {
+		return invalid(fmt.Sprintf("must not have a path, but has %q", u.Path))
+	}
+
+	u.Path, u.RawPath = "", ""
+	return u.String(), diags
+}
+
 // getConfigValue returns the value from the config if set, otherwise falls back to environment variable.
 func getConfigValue(configValue types.String, envVar string) string {
 	if !configValue.IsNull() && configValue.ValueString() != "" {
@@ -186,3 +720,45 @@ func getConfigValue(configValue types.String, envVar string) string {
 	}
 	return os.Getenv(envVar)
 }
+
+// getAPIURL returns the API URL from the config if set, otherwise from
+// KOSLI_API_URL, and otherwise from KOSLI_HOST, the Kosli CLI's variable for
+// it.
+func getAPIURL(configValue types.String) string {
+	if apiURL := getConfigValue(configValue, "KOSLI_API_URL"); apiURL != "" {
+		return apiURL
+	}
+	return os.Getenv("KOSLI_HOST")
+}
+
+// getAPIToken returns the API token from the config if set, otherwise from
+// the organization's own environment variable (see orgTokenEnvVar), so
+// provider aliases for several organizations can each get their token from
+// the environment, and otherwise from KOSLI_API_TOKEN.
+func getAPIToken(configValue types.String, org string) string {
+	if !configValue.IsNull() && configValue.ValueString() != "" {
+		return configValue.ValueString()
+	}
+	if org != "" {
+		if token := os.Getenv(orgTokenEnvVar(org)); token != "" {
+			return token
+		}
+	}
+	return os.Getenv("KOSLI_API_TOKEN")
+}
+
+// orgTokenEnvVar returns the name of the environment variable holding the
+// API token of org: KOSLI_API_TOKEN_ followed by org in upper case, with
+// every character other than a letter or digit replaced by an underscore.
+func orgTokenEnvVar(org string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, org)
+	return "KOSLI_API_TOKEN_" + name
+}
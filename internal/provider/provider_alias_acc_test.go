@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccPreCheckSecondOrg skips the test unless a second organization is
+// configured in addition to KOSLI_ORG. KOSLI_SECOND_API_TOKEN defaults to
+// KOSLI_API_TOKEN, for tokens that belong to both organizations.
+func testAccPreCheckSecondOrg(t *testing.T) {
+	t.Helper()
+	testAccPreCheck(t)
+	if v := os.Getenv("KOSLI_SECOND_ORG"); v == "" {
+		t.Skip("KOSLI_SECOND_ORG must be set for multi-organization acceptance tests")
+	}
+	if os.Getenv("KOSLI_SECOND_ORG") == os.Getenv("KOSLI_ORG") {
+		t.Fatal("KOSLI_SECOND_ORG must differ from KOSLI_ORG")
+	}
+}
+
+// TestAccProviderAliases_multiOrg tests two provider configurations, one
+// aliased, managing objects with the same names in different organizations
+// from a single configuration
+func TestAccProviderAliases_multiOrg(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckSecondOrg(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderAliasesConfig(rName, "Second organization"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Each organization holds its own environment
+					resource.TestCheckResourceAttr("data.kosli_environment.first", "name", rName),
+					resource.TestCheckResourceAttr("data.kosli_environment.first", "description", "First organization"),
+					resource.TestCheckResourceAttr("data.kosli_environment.second", "name", rName),
+					resource.TestCheckResourceAttr("data.kosli_environment.second", "description", "Second organization"),
+					// Each provider configuration targets its own organization
+					resource.TestCheckResourceAttr("data.kosli_custom_attestation_type.first", "org", os.Getenv("KOSLI_ORG")),
+					resource.TestCheckResourceAttr("data.kosli_custom_attestation_type.second", "org", os.Getenv("KOSLI_SECOND_ORG")),
+				),
+			},
+			// Updating the aliased organization's environment leaves the
+			// other organization's untouched
+			{
+				Config: testAccProviderAliasesConfig(rName, "Second organization, updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kosli_environment.first", "description", "First organization"),
+					resource.TestCheckResourceAttr("data.kosli_environment.second", "description", "Second organization, updated"),
+				),
+			},
+		},
+	})
+}
+
+// testAccProviderAliasesConfig returns a configuration managing an
+// environment and a custom attestation type named name in both KOSLI_ORG,
+// with the default provider, and KOSLI_SECOND_ORG, with an aliased one
+func testAccProviderAliasesConfig(name, secondDescription string) string {
+	apiToken := ""
+	if v := os.Getenv("KOSLI_SECOND_API_TOKEN"); v != "" {
+		apiToken = fmt.Sprintf("  api_token = %q\n", v)
+	}
+
+	return fmt.Sprintf(`
+provider "kosli" {}
+
+provider "kosli" {
+  alias = "second"
+  org   = %[2]q
+%[3]s}
+
+resource "kosli_environment" "first" {
+  name        = %[1]q
+  type        = "K8S"
+  description = "First organization"
+}
+
+resource "kosli_environment" "second" {
+  provider    = kosli.second
+  name        = %[1]q
+  type        = "K8S"
+  description = %[4]q
+}
+
+resource "kosli_custom_attestation_type" "first" {
+  name     = %[1]q
+  schema   = jsonencode({ type = "object" })
+  jq_rules = [".ok == true"]
+}
+
+resource "kosli_custom_attestation_type" "second" {
+  provider = kosli.second
+  name     = %[1]q
+  schema   = jsonencode({ type = "object" })
+  jq_rules = [".ok == true"]
+}
+
+data "kosli_environment" "first" {
+  name = kosli_environment.first.name
+}
+
+data "kosli_environment" "second" {
+  provider = kosli.second
+  name     = kosli_environment.second.name
+}
+
+data "kosli_custom_attestation_type" "first" {
+  name = kosli_custom_attestation_type.first.name
+}
+
+data "kosli_custom_attestation_type" "second" {
+  provider = kosli.second
+  name     = kosli_custom_attestation_type.second.name
+}
+`, name, os.Getenv("KOSLI_SECOND_ORG"), apiToken, secondDescription)
+}
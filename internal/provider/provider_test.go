@@ -3,13 +3,19 @@ package provider
 import (
 	"context"
 	"os"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -51,7 +57,7 @@ func TestKosliProvider_Schema(t *testing.T) {
 
 	// Verify required attributes exist
 	attrs := resp.Schema.Attributes
-	requiredAttrs := []string{"api_token", "org", "api_url", "timeout"}
+	requiredAttrs := []string{"api_token", "org", "api_url", "timeout", "read_timeout", "write_timeout", "max_parallel_requests", "default_page_size", "max_response_size_mb", "retry", "rate_limit", "circuit_breaker", "read_cache", "skip_read_after_write", "keep_empty_descriptions", "default_tags", "token_expiry_warning_days"}
 	for _, attr := range requiredAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute '%s' to exist in schema", attr)
@@ -124,12 +130,18 @@ func TestKosliProvider_Resources(t *testing.T) {
 
 	expected := []string{
 		"kosli_action",
+		"kosli_artifact",
+		"kosli_attestation_sonar",
 		"kosli_custom_attestation_type",
 		"kosli_environment",
+		"kosli_environment_tag",
 		"kosli_flow",
 		"kosli_logical_environment",
+		"kosli_logical_environment_membership",
 		"kosli_policy",
 		"kosli_policy_attachment",
+		"kosli_server_environment_report",
+		"kosli_environment_snapshot_report",
 	}
 	for _, name := range expected {
 		if !registered[name] {
@@ -138,6 +150,40 @@ func TestKosliProvider_Resources(t *testing.T) {
 	}
 }
 
+func TestKosliProvider_Actions(t *testing.T) {
+	p := &KosliProvider{}
+	ctx := context.Background()
+
+	registered := make(map[string]bool)
+	for _, factory := range p.Actions(ctx) {
+		a := factory()
+		resp := &action.MetadataResponse{}
+		a.Metadata(ctx, action.MetadataRequest{ProviderTypeName: "kosli"}, resp)
+		registered[resp.TypeName] = true
+	}
+
+	if !registered["kosli_custom_attestation_type_rollback"] {
+		t.Error("Expected action \"kosli_custom_attestation_type_rollback\" to be registered")
+	}
+}
+
+func TestKosliProvider_Functions(t *testing.T) {
+	p := &KosliProvider{}
+	ctx := context.Background()
+
+	registered := make(map[string]bool)
+	for _, factory := range p.Functions(ctx) {
+		f := factory()
+		resp := &function.MetadataResponse{}
+		f.Metadata(ctx, function.MetadataRequest{}, resp)
+		registered[resp.Name] = true
+	}
+
+	if !registered["validate_policy"] {
+		t.Error("Expected function \"validate_policy\" to be registered")
+	}
+}
+
 func TestKosliProvider_DataSources(t *testing.T) {
 	p := &KosliProvider{}
 	ctx := context.Background()
@@ -152,9 +198,16 @@ func TestKosliProvider_DataSources(t *testing.T) {
 
 	expected := []string{
 		"kosli_action",
+		"kosli_attestation",
 		"kosli_custom_attestation_type",
+		"kosli_custom_attestation_types",
 		"kosli_environment",
+		"kosli_environment_running_artifacts",
+		"kosli_environment_snapshot",
+		"kosli_environment_tags",
+		"kosli_environments",
 		"kosli_flow",
+		"kosli_flow_compliance",
 		"kosli_logical_environment",
 		"kosli_policy",
 	}
@@ -244,3 +297,357 @@ func TestGetConfigValue(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeAPIURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiURL        string
+		expected      string
+		expectError   bool
+		expectWarning bool
+	}{
+		{name: "default", apiURL: "https://app.kosli.com", expected: "https://app.kosli.com"},
+		{name: "trailing slash", apiURL: "https://app.us.kosli.com/", expected: "https://app.us.kosli.com"},
+		{name: "http with port", apiURL: "http://localhost:8080", expected: "http://localhost:8080"},
+		{name: "API path", apiURL: "https://app.kosli.com/api/v2", expected: "https://app.kosli.com", expectWarning: true},
+		{name: "API path with trailing slash", apiURL: "https://app.kosli.com/api/v2/", expected: "https://app.kosli.com", expectWarning: true},
+		{name: "no scheme", apiURL: "app.kosli.com", expectError: true},
+		{name: "unsupported scheme", apiURL: "ftp://app.kosli.com", expectError: true},
+		{name: "no host", apiURL: "https://", expectError: true},
+		{name: "other path", apiURL: "https://app.kosli.com/api/v1", expectError: true},
+		{name: "query", apiURL: "https://app.kosli.com?org=acme", expectError: true},
+		{name: "unparseable", apiURL: "https://app.kosli.com:port", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := normalizeAPIURL(tt.apiURL)
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Fatalf("expected error diagnostics, got none (URL %q)", got)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.expectWarning {
+				t.Errorf("expected warning %v, got %v", tt.expectWarning, diags)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryOptions(t *testing.T) {
+	ctx := context.Background()
+	retryAttrTypes := map[string]attr.Type{
+		"max_retries":         types.Int64Type,
+		"min_wait":            types.Int64Type,
+		"max_wait":            types.Int64Type,
+		"respect_retry_after": types.BoolType,
+	}
+	retryBlock := func(maxRetries, minWait, maxWait types.Int64, respect types.Bool) types.Object {
+		return types.ObjectValueMust(retryAttrTypes, map[string]attr.Value{
+			"max_retries":         maxRetries,
+			"min_wait":            minWait,
+			"max_wait":            maxWait,
+			"respect_retry_after": respect,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		retry       types.Object
+		expectError bool
+	}{
+		{
+			name:  "block omitted uses defaults",
+			retry: types.ObjectNull(retryAttrTypes),
+		},
+		{
+			name:  "partial block",
+			retry: retryBlock(types.Int64Value(5), types.Int64Null(), types.Int64Null(), types.BoolValue(false)),
+		},
+		{
+			name:  "retries disabled",
+			retry: retryBlock(types.Int64Value(0), types.Int64Null(), types.Int64Null(), types.BoolNull()),
+		},
+		{
+			name:        "negative max_retries",
+			retry:       retryBlock(types.Int64Value(-1), types.Int64Null(), types.Int64Null(), types.BoolNull()),
+			expectError: true,
+		},
+		{
+			name:        "max_wait below min_wait",
+			retry:       retryBlock(types.Int64Null(), types.Int64Value(10), types.Int64Value(5), types.BoolNull()),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, diags := retryOptions(ctx, tt.retry)
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if _, err := client.NewClient("token", "org", opts...); err != nil {
+				t.Errorf("expected options to apply cleanly, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRateLimitOptions(t *testing.T) {
+	ctx := context.Background()
+	rateLimitAttrTypes := map[string]attr.Type{
+		"requests_per_second": types.Float64Type,
+		"burst":               types.Int64Type,
+	}
+	rateLimitBlock := func(rps types.Float64, burst types.Int64) types.Object {
+		return types.ObjectValueMust(rateLimitAttrTypes, map[string]attr.Value{
+			"requests_per_second": rps,
+			"burst":               burst,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		rateLimit   types.Object
+		expectOpts  int
+		expectError bool
+	}{
+		{
+			name:       "block omitted",
+			rateLimit:  types.ObjectNull(rateLimitAttrTypes),
+			expectOpts: 0,
+		},
+		{
+			name:       "default burst",
+			rateLimit:  rateLimitBlock(types.Float64Value(5), types.Int64Null()),
+			expectOpts: 1,
+		},
+		{
+			name:       "explicit burst",
+			rateLimit:  rateLimitBlock(types.Float64Value(0.5), types.Int64Value(10)),
+			expectOpts: 1,
+		},
+		{
+			name:        "zero rate",
+			rateLimit:   rateLimitBlock(types.Float64Value(0), types.Int64Null()),
+			expectError: true,
+		},
+		{
+			name:        "zero burst",
+			rateLimit:   rateLimitBlock(types.Float64Value(5), types.Int64Value(0)),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, diags := rateLimitOptions(ctx, tt.rateLimit)
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(opts) != tt.expectOpts {
+				t.Fatalf("expected %d options, got %d", tt.expectOpts, len(opts))
+			}
+			if _, err := client.NewClient("token", "org", opts...); err != nil {
+				t.Errorf("expected options to apply cleanly, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerOptions(t *testing.T) {
+	ctx := context.Background()
+	breakerAttrTypes := map[string]attr.Type{
+		"failure_threshold": types.Int64Type,
+		"cooldown":          types.Int64Type,
+	}
+	breakerBlock := func(threshold, cooldown types.Int64) types.Object {
+		return types.ObjectValueMust(breakerAttrTypes, map[string]attr.Value{
+			"failure_threshold": threshold,
+			"cooldown":          cooldown,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		breaker     types.Object
+		expectOpts  int
+		expectError bool
+	}{
+		{name: "block omitted", breaker: types.ObjectNull(breakerAttrTypes), expectOpts: 0},
+		{name: "default cooldown", breaker: breakerBlock(types.Int64Value(5), types.Int64Null()), expectOpts: 1},
+		{name: "explicit cooldown", breaker: breakerBlock(types.Int64Value(3), types.Int64Value(60)), expectOpts: 1},
+		{name: "zero threshold", breaker: breakerBlock(types.Int64Value(0), types.Int64Null()), expectError: true},
+		{name: "negative cooldown", breaker: breakerBlock(types.Int64Value(3), types.Int64Value(-1)), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, diags := circuitBreakerOptions(ctx, tt.breaker)
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected error diagnostics, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(opts) != tt.expectOpts {
+				t.Fatalf("expected %d options, got %d", tt.expectOpts, len(opts))
+			}
+			if _, err := client.NewClient("token", "org", opts...); err != nil {
+				t.Errorf("expected options to apply cleanly, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDefaultTagsValue(t *testing.T) {
+	ctx := context.Background()
+	defaultTagsAttrTypes := map[string]attr.Type{
+		"tags": types.MapType{ElemType: types.StringType},
+	}
+
+	tests := []struct {
+		name        string
+		defaultTags types.Object
+		want        map[string]string
+	}{
+		{name: "block omitted", defaultTags: types.ObjectNull(defaultTagsAttrTypes), want: map[string]string{}},
+		{
+			name: "tags omitted",
+			defaultTags: types.ObjectValueMust(defaultTagsAttrTypes, map[string]attr.Value{
+				"tags": types.MapNull(types.StringType),
+			}),
+			want: map[string]string{},
+		},
+		{
+			name: "tags set",
+			defaultTags: types.ObjectValueMust(defaultTagsAttrTypes, map[string]attr.Value{
+				"tags": types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("platform")}),
+			}),
+			want: map[string]string{"owner": "platform"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := defaultTagsValue(ctx, tt.defaultTags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultTagsValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAPIURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   types.String
+		apiURL   string
+		host     string
+		expected string
+	}{
+		{"config takes precedence", types.StringValue("https://config.kosli.com"), "https://env.kosli.com", "https://host.kosli.com", "https://config.kosli.com"},
+		{"KOSLI_API_URL before KOSLI_HOST", types.StringNull(), "https://env.kosli.com", "https://host.kosli.com", "https://env.kosli.com"},
+		{"KOSLI_HOST as used by the CLI", types.StringNull(), "", "https://app.us.kosli.com", "https://app.us.kosli.com"},
+		{"unset", types.StringNull(), "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KOSLI_API_URL", tt.apiURL)
+			t.Setenv("KOSLI_HOST", tt.host)
+			if got := getAPIURL(tt.config); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetAPIToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   types.String
+		org      string
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "config takes precedence",
+			config:   types.StringValue("config-token"),
+			org:      "acme",
+			env:      map[string]string{"KOSLI_API_TOKEN_ACME": "org-token", "KOSLI_API_TOKEN": "env-token"},
+			expected: "config-token",
+		},
+		{
+			name:     "org variable before the generic one",
+			config:   types.StringNull(),
+			org:      "acme",
+			env:      map[string]string{"KOSLI_API_TOKEN_ACME": "org-token", "KOSLI_API_TOKEN": "env-token"},
+			expected: "org-token",
+		},
+		{
+			name:     "other org's variable ignored",
+			config:   types.StringNull(),
+			org:      "acme",
+			env:      map[string]string{"KOSLI_API_TOKEN_OTHER": "other-token", "KOSLI_API_TOKEN": "env-token"},
+			expected: "env-token",
+		},
+		{
+			name:     "normalized org name",
+			config:   types.StringValue(""),
+			org:      "acme-eu.prod",
+			env:      map[string]string{"KOSLI_API_TOKEN_ACME_EU_PROD": "org-token"},
+			expected: "org-token",
+		},
+		{
+			name:     "empty org variable falls back",
+			config:   types.StringNull(),
+			org:      "acme",
+			env:      map[string]string{"KOSLI_API_TOKEN_ACME": "", "KOSLI_API_TOKEN": "env-token"},
+			expected: "env-token",
+		},
+		{
+			name:     "no token",
+			config:   types.StringNull(),
+			org:      "",
+			env:      map[string]string{"KOSLI_API_TOKEN": ""},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := getAPIToken(tt.config, tt.org); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
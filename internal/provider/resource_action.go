@@ -3,8 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -17,6 +19,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &actionResource{}
 var _ resource.ResourceWithImportState = &actionResource{}
+var _ resource.ResourceWithConfigValidators = &actionResource{}
 
 // NewActionResource creates a new action resource.
 func NewActionResource() resource.Resource {
@@ -30,13 +33,16 @@ type actionResource struct {
 
 // actionResourceModel describes the resource data model.
 type actionResourceModel struct {
-	Name           types.String  `tfsdk:"name"`
-	Environments   types.List    `tfsdk:"environments"`
-	Triggers       types.List    `tfsdk:"triggers"`
-	WebhookURL     types.String  `tfsdk:"webhook_url"`
-	Number         types.Int64   `tfsdk:"number"`
-	CreatedBy      types.String  `tfsdk:"created_by"`
-	LastModifiedAt types.Float64 `tfsdk:"last_modified_at"`
+	Name                types.String  `tfsdk:"name"`
+	Environments        types.List    `tfsdk:"environments"`
+	Triggers            types.List    `tfsdk:"triggers"`
+	WebhookURL          types.String  `tfsdk:"webhook_url"`
+	WebhookURLWO        types.String  `tfsdk:"webhook_url_wo"`
+	WebhookURLWOVersion types.Int64   `tfsdk:"webhook_url_wo_version"`
+	VerifyOnCreate      types.Bool    `tfsdk:"verify_on_create"`
+	Number              types.Int64   `tfsdk:"number"`
+	CreatedBy           types.String  `tfsdk:"created_by"`
+	LastModifiedAt      types.Float64 `tfsdk:"last_modified_at"`
 }
 
 // Metadata returns the resource type name.
@@ -69,9 +75,24 @@ func (r *actionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:            true,
 			},
 			"webhook_url": schema.StringAttribute{
-				MarkdownDescription: "Webhook URL to send notifications to.",
-				Required:            true,
+				MarkdownDescription: "Webhook URL to send notifications to. The URL is stored in the Terraform state; use `webhook_url_wo` to keep it out. Exactly one of `webhook_url` and `webhook_url_wo` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"webhook_url_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only webhook URL to send notifications to. The URL is sent to Kosli but never stored in the Terraform plan or state. Requires `webhook_url_wo_version` and Terraform 1.11 or later.",
+				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"webhook_url_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `webhook_url_wo`. Terraform cannot detect changes to write-only values, so change the version to send a changed webhook URL to Kosli.",
+				Optional:            true,
+			},
+			"verify_on_create": schema.BoolAttribute{
+				MarkdownDescription: "Whether to send a test event to the webhook before creating the action, failing the apply if delivery fails, so that a bad webhook URL is caught at provision time. " +
+					"The event is sent from where Terraform runs, not from Kosli. Defaults to `false`.",
+				Optional: true,
 			},
 			"number": schema.Int64Attribute{
 				MarkdownDescription: "Server-assigned numeric identifier for the action.",
@@ -95,22 +116,29 @@ func (r *actionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+// ConfigValidators returns validators that check the configuration as a whole.
+func (r *actionResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		webhookURLValidator{},
+	}
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *actionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = providerData.Client
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -122,16 +150,35 @@ func (r *actionResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Write-only values are only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("webhook_url_wo"), &data.WebhookURLWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	actionReq, diags := buildActionRequest(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Verify before creating, so a bad URL doesn't leave an action behind
+	if data.VerifyOnCreate.ValueBool() {
+		if err := verifyWebhook(ctx, http.DefaultClient, actionReq.Targets[0].Webhook, actionReq.Name); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("verify_on_create"),
+				"Webhook Verification Failed",
+				fmt.Sprintf("Could not deliver a test event to the webhook of action %q, so the action was not created: %s. "+
+					"Check the webhook URL, or unset verify_on_create if the webhook is only reachable from Kosli.", actionReq.Name, err),
+			)
+			return
+		}
+	}
+
 	if err := r.client.CreateOrUpdateAction(ctx, actionReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Action",
-			fmt.Sprintf("Could not create action %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create action %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -142,7 +189,7 @@ func (r *actionResource) Create(ctx context.Context, req resource.CreateRequest,
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Action After Creation",
-			fmt.Sprintf("Could not read action %q after creation: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read action %q after creation: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -174,7 +221,7 @@ func (r *actionResource) Read(ctx context.Context, req resource.ReadRequest, res
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading Action",
-			fmt.Sprintf("Could not read action number %d: %s", data.Number.ValueInt64(), err.Error()),
+			fmt.Sprintf("Could not read action number %d: %s", data.Number.ValueInt64(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -204,6 +251,12 @@ func (r *actionResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 	data.Number = state.Number
 
+	// Write-only values are only available in the configuration
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("webhook_url_wo"), &data.WebhookURLWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	actionReq, diags := buildActionRequest(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -215,7 +268,7 @@ func (r *actionResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if err := r.client.UpdateAction(ctx, int(data.Number.ValueInt64()), actionReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Action",
-			fmt.Sprintf("Could not update action %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update action %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -224,7 +277,7 @@ func (r *actionResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Action After Update",
-			fmt.Sprintf("Could not read action number %d after update: %s", data.Number.ValueInt64(), err.Error()),
+			fmt.Sprintf("Could not read action number %d after update: %s", data.Number.ValueInt64(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -249,7 +302,7 @@ func (r *actionResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if err := r.client.DeleteAction(ctx, int(data.Number.ValueInt64())); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Action",
-			fmt.Sprintf("Could not delete action number %d: %s", data.Number.ValueInt64(), err.Error()),
+			fmt.Sprintf("Could not delete action number %d: %s", data.Number.ValueInt64(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -264,7 +317,7 @@ func (r *actionResource) ImportState(ctx context.Context, req resource.ImportSta
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Action",
-			fmt.Sprintf("Could not find action named %q: %s", req.ID, err.Error()),
+			fmt.Sprintf("Could not find action named %q: %s", req.ID, apiErrorDetail(err)),
 		)
 		return
 	}
@@ -294,6 +347,11 @@ func buildActionRequest(ctx context.Context, data *actionResourceModel) (*client
 		return nil, diags
 	}
 
+	webhook := data.WebhookURL.ValueString()
+	if !data.WebhookURLWO.IsNull() {
+		webhook = data.WebhookURLWO.ValueString()
+	}
+
 	return &client.ActionRequest{
 		Name: data.Name.ValueString(),
 		// Type is always "env" — the only action type currently supported by the Kosli API.
@@ -303,7 +361,7 @@ func buildActionRequest(ctx context.Context, data *actionResourceModel) (*client
 		Targets: []client.ActionTarget{
 			{
 				Type:    "WEBHOOK",
-				Webhook: data.WebhookURL.ValueString(),
+				Webhook: webhook,
 			},
 		},
 	}, diags
@@ -340,11 +398,15 @@ func mapActionResponseToModel(ctx context.Context, action *client.ActionResponse
 	}
 	data.Triggers = trigList
 
+	// Write-only values are never stored in state.
+	data.WebhookURLWO = types.StringNull()
+
 	// Extract webhook_url from the first WEBHOOK target.
 	// The API does not echo back sensitive fields (webhook URL) on GET responses,
 	// so only overwrite state when the API returns a non-empty value. This preserves
 	// the value the user configured and prevents a permanent plan diff on every refresh.
-	if len(action.Targets) > 0 && action.Targets[0].Webhook != "" {
+	// When webhook_url_wo is used (marked by its version), the URL must stay out of state.
+	if data.WebhookURLWOVersion.IsNull() && len(action.Targets) > 0 && action.Targets[0].Webhook != "" {
 		data.WebhookURL = types.StringValue(action.Targets[0].Webhook)
 	}
 
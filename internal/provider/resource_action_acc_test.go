@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 // TestAccActionResource_basic tests minimal required configuration
@@ -66,6 +67,41 @@ func TestAccActionResource_update(t *testing.T) {
 	})
 }
 
+// TestAccActionResource_writeOnlyWebhookURL tests that webhook_url_wo is kept
+// out of state and that changing its version sends the new URL.
+func TestAccActionResource_writeOnlyWebhookURL(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	envName := acctest.RandomWithPrefix("tf-acc-test-env")
+	resourceName := "kosli_action.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccActionResourceConfigWriteOnly(rName, envName, "https://hooks.example.com/kosli-test", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckNoResourceAttr(resourceName, "webhook_url"),
+					resource.TestCheckNoResourceAttr(resourceName, "webhook_url_wo"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_url_wo_version", "1"),
+				),
+			},
+			{
+				Config: testAccActionResourceConfigWriteOnly(rName, envName, "https://hooks.example.com/kosli-test-updated", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "webhook_url"),
+					resource.TestCheckNoResourceAttr(resourceName, "webhook_url_wo"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_url_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
 // TestAccActionResource_import tests terraform import functionality
 func TestAccActionResource_import(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -155,3 +191,21 @@ resource "kosli_action" "test" {
 }
 `, name, envName)
 }
+
+// testAccActionResourceConfigWriteOnly returns an action configuration with a write-only webhook URL.
+func testAccActionResourceConfigWriteOnly(name, envName, webhookURL string, version int) string {
+	return fmt.Sprintf(`
+resource "kosli_environment" "test" {
+  name = %[2]q
+  type = "K8S"
+}
+
+resource "kosli_action" "test" {
+  name                   = %[1]q
+  environments           = [kosli_environment.test.name]
+  triggers               = ["ON_NON_COMPLIANT_ENV"]
+  webhook_url_wo         = %[3]q
+  webhook_url_wo_version = %[4]d
+}
+`, name, envName, webhookURL, version)
+}
@@ -35,7 +35,7 @@ func TestActionResource_Schema(t *testing.T) {
 	}
 
 	attrs := resp.Schema.Attributes
-	expectedAttrs := []string{"name", "environments", "triggers", "webhook_url", "number", "created_by", "last_modified_at"}
+	expectedAttrs := []string{"name", "environments", "triggers", "webhook_url", "webhook_url_wo", "webhook_url_wo_version", "verify_on_create", "number", "created_by", "last_modified_at"}
 	for _, attr := range expectedAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -54,8 +54,14 @@ func TestActionResource_Schema(t *testing.T) {
 	if !attrs["triggers"].IsRequired() {
 		t.Error("Expected 'triggers' to be required")
 	}
-	if !attrs["webhook_url"].IsRequired() {
-		t.Error("Expected 'webhook_url' to be required")
+	if !attrs["webhook_url"].IsOptional() {
+		t.Error("Expected 'webhook_url' to be optional")
+	}
+	if !attrs["webhook_url_wo"].IsWriteOnly() || !attrs["webhook_url_wo"].IsSensitive() {
+		t.Error("Expected 'webhook_url_wo' to be write-only and sensitive")
+	}
+	if !attrs["verify_on_create"].IsOptional() {
+		t.Error("Expected 'verify_on_create' to be optional")
 	}
 	if !attrs["number"].IsComputed() {
 		t.Error("Expected 'number' to be computed")
@@ -138,6 +144,7 @@ func TestNewActionResource(t *testing.T) {
 func TestActionResource_Implements(t *testing.T) {
 	var _ resource.Resource = &actionResource{}
 	var _ resource.ResourceWithImportState = &actionResource{}
+	var _ resource.ResourceWithConfigValidators = &actionResource{}
 }
 
 // TestMapActionResponseToModel_PreservesWebhookURLWhenAPIReturnsEmpty verifies that
@@ -317,3 +324,66 @@ func TestBuildActionRequest(t *testing.T) {
 		t.Errorf("expected Webhook URL, got %q", req.Targets[0].Webhook)
 	}
 }
+
+// TestMapActionResponseToModel_KeepsWriteOnlyWebhookURLOutOfState verifies that
+// a webhook URL echoed by the API is not stored when webhook_url_wo is used.
+func TestMapActionResponseToModel_KeepsWriteOnlyWebhookURLOutOfState(t *testing.T) {
+	ctx := context.TODO()
+
+	data := actionResourceModel{
+		WebhookURL:          types.StringNull(),
+		WebhookURLWO:        types.StringValue("https://hooks.example.com/secret"),
+		WebhookURLWOVersion: types.Int64Value(1),
+	}
+
+	action := &client.ActionResponse{
+		Name:   "my-action",
+		Number: 1,
+		Targets: []client.ActionTarget{
+			{Type: "WEBHOOK", Webhook: "https://hooks.example.com/secret"},
+		},
+		Environments: []string{"prod"},
+		Triggers:     []string{"ON_NON_COMPLIANT_ENV"},
+	}
+
+	diags := mapActionResponseToModel(ctx, action, &data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !data.WebhookURL.IsNull() {
+		t.Errorf("expected webhook_url to stay null, got %q", data.WebhookURL.ValueString())
+	}
+	if !data.WebhookURLWO.IsNull() {
+		t.Errorf("expected webhook_url_wo to be null in state, got %q", data.WebhookURLWO.ValueString())
+	}
+	if data.WebhookURLWOVersion.ValueInt64() != 1 {
+		t.Errorf("expected webhook_url_wo_version 1, got %d", data.WebhookURLWOVersion.ValueInt64())
+	}
+}
+
+// TestBuildActionRequest_WriteOnlyWebhookURL verifies that webhook_url_wo is
+// sent as the webhook target when set.
+func TestBuildActionRequest_WriteOnlyWebhookURL(t *testing.T) {
+	ctx := context.TODO()
+
+	envList, _ := types.ListValueFrom(ctx, types.StringType, []string{"prod"})
+	trigList, _ := types.ListValueFrom(ctx, types.StringType, []string{"ON_NON_COMPLIANT_ENV"})
+
+	data := actionResourceModel{
+		Name:         types.StringValue("my-action"),
+		Environments: envList,
+		Triggers:     trigList,
+		WebhookURL:   types.StringNull(),
+		WebhookURLWO: types.StringValue("https://hooks.example.com/secret"),
+	}
+
+	req, diags := buildActionRequest(ctx, &data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(req.Targets) != 1 || req.Targets[0].Webhook != "https://hooks.example.com/secret" {
+		t.Errorf("expected the write-only webhook URL as target, got %+v", req.Targets)
+	}
+}
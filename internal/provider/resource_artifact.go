@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &artifactResource{}
+
+// NewArtifactResource creates a new artifact resource.
+func NewArtifactResource() resource.Resource {
+	return &artifactResource{}
+}
+
+// artifactResource defines the resource implementation.
+type artifactResource struct {
+	client *client.Client
+}
+
+// artifactResourceModel describes the resource data model.
+type artifactResourceModel struct {
+	Flow         types.String `tfsdk:"flow"`
+	Trail        types.String `tfsdk:"trail"`
+	Fingerprint  types.String `tfsdk:"fingerprint"`
+	Name         types.String `tfsdk:"name"`
+	TemplateName types.String `tfsdk:"template_name"`
+	CommitSHA    types.String `tfsdk:"commit_sha"`
+	BuildURL     types.String `tfsdk:"build_url"`
+	CommitURL    types.String `tfsdk:"commit_url"`
+}
+
+// Metadata returns the resource type name.
+func (r *artifactResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_artifact"
+}
+
+// Schema defines the schema for the resource.
+func (r *artifactResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Reported artifacts are evidence: every change reports the artifact again
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports an artifact to a Kosli trail, so artifacts produced by Terraform-driven build steps, such as AMIs, Lambda packages or container images, enter Kosli without the CLI.\n\n" +
+			"~> **Note:** Reported artifacts are evidence. Changing any attribute reports the artifact again, and destroying the resource only removes it from the Terraform state: the artifact stays on the trail.",
+
+		Attributes: map[string]schema.Attribute{
+			"flow": schema.StringAttribute{
+				MarkdownDescription: "Name of the flow the trail belongs to. Changing this will report the artifact again.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"trail": schema.StringAttribute{
+				MarkdownDescription: "Name of the trail to report the artifact to. Changing this will report the artifact again.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "SHA256 fingerprint of the artifact, e.g. `filesha256(\"lambda.zip\")` or the digest of a container image without the `sha256:` prefix. Changing this will report a new artifact.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the artifact shown in Kosli, e.g. the image name or file name. Changing this will report the artifact again.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"template_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the artifact in the flow template, so the attestations the template expects for it apply. Changing this will report the artifact again.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "Git commit the artifact was built from. Changing this will report the artifact again.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"build_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the build that produced the artifact. Changing this will report the artifact again.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"commit_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the commit in the source code repository. Changing this will report the artifact again.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *artifactResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KosliResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create reports the artifact and sets the Terraform state.
+func (r *artifactResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data artifactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportReq := &client.ArtifactRequest{
+		FlowName:     data.Flow.ValueString(),
+		TrailName:    data.Trail.ValueString(),
+		Fingerprint:  data.Fingerprint.ValueString(),
+		DisplayName:  data.Name.ValueString(),
+		TemplateName: data.TemplateName.ValueString(),
+		CommitSHA:    data.CommitSHA.ValueString(),
+		BuildURL:     data.BuildURL.ValueString(),
+		CommitURL:    data.CommitURL.ValueString(),
+	}
+
+	if err := r.client.ReportArtifact(ctx, reportReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reporting Artifact",
+			fmt.Sprintf("Could not report artifact %q to trail %q of flow %q: %s", reportReq.DisplayName, reportReq.TrailName, reportReq.FlowName, apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// The API returns "OK" rather than the artifact, so the planned values
+	// are what was reported
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the Terraform state: the provider only reports artifacts and
+// does not manage them afterwards.
+func (r *artifactResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The response state already holds the prior state
+}
+
+// Update is never called with a change, since every attribute requires
+// replacement; it stores the plan.
+func (r *artifactResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data artifactResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the artifact from the Terraform state. Kosli keeps reported
+// artifacts, so there is nothing to delete in the API.
+func (r *artifactResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// State is automatically removed by the framework
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestArtifactResource_Metadata(t *testing.T) {
+	r := &artifactResource{}
+
+	req := resource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_artifact" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_artifact", resp.TypeName)
+	}
+}
+
+func TestArtifactResource_Schema(t *testing.T) {
+	r := &artifactResource{}
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	attrs := resp.Schema.Attributes
+	for _, name := range []string{"flow", "trail", "fingerprint", "name", "commit_sha", "build_url"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsRequired() {
+			t.Errorf("Expected attribute %q to be required", name)
+		}
+	}
+	for _, name := range []string{"template_name", "commit_url"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsOptional() {
+			t.Errorf("Expected attribute %q to be optional", name)
+		}
+	}
+}
+
+func TestArtifactResource_Configure(t *testing.T) {
+	r := &artifactResource{}
+
+	req := resource.ConfigureRequest{ProviderData: nil}
+	resp := &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = resource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
+
+func TestArtifactResource_Create(t *testing.T) {
+	var reported map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/artifacts/test-org/backend" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&reported)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &artifactResource{client: c}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"flow":          tftypes.NewValue(tftypes.String, "backend"),
+			"trail":         tftypes.NewValue(tftypes.String, "abc123"),
+			"fingerprint":   tftypes.NewValue(tftypes.String, "3b7f9a1c"),
+			"name":          tftypes.NewValue(tftypes.String, "backend.zip"),
+			"template_name": tftypes.NewValue(tftypes.String, "backend-lambda"),
+			"commit_sha":    tftypes.NewValue(tftypes.String, "abc123"),
+			"build_url":     tftypes.NewValue(tftypes.String, "https://ci.example.com/builds/42"),
+			"commit_url":    tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors: %v", resp.Diagnostics.Errors())
+	}
+	if reported["fingerprint"] != "3b7f9a1c" || reported["filename"] != "backend.zip" || reported["template_reference_name"] != "backend-lambda" {
+		t.Errorf("Unexpected report: %v", reported)
+	}
+	var data artifactResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Fingerprint.ValueString() != "3b7f9a1c" || !data.CommitURL.IsNull() {
+		t.Errorf("Expected the plan in state, got %+v", data)
+	}
+}
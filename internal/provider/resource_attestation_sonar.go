@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &sonarAttestationResource{}
+
+// sonarQualityGatePassed is the quality gate status of a passing scan.
+const sonarQualityGatePassed = "OK"
+
+// NewSonarAttestationResource creates a new sonar attestation resource.
+func NewSonarAttestationResource() resource.Resource {
+	return &sonarAttestationResource{}
+}
+
+// sonarAttestationResource defines the resource implementation.
+type sonarAttestationResource struct {
+	client *client.Client
+}
+
+// sonarAttestationResourceModel describes the resource data model.
+type sonarAttestationResourceModel struct {
+	Flow                types.String `tfsdk:"flow"`
+	Trail               types.String `tfsdk:"trail"`
+	Name                types.String `tfsdk:"name"`
+	ArtifactFingerprint types.String `tfsdk:"artifact_fingerprint"`
+	ProjectKey          types.String `tfsdk:"project_key"`
+	QualityGateStatus   types.String `tfsdk:"quality_gate_status"`
+	ScanURL             types.String `tfsdk:"scan_url"`
+	Compliant           types.Bool   `tfsdk:"compliant"`
+}
+
+// Metadata returns the resource type name.
+func (r *sonarAttestationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attestation_sonar"
+}
+
+// Schema defines the schema for the resource.
+func (r *sonarAttestationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Attestations are immutable evidence: every change reports a new one
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports a SonarQube or SonarCloud scan result as a sonar attestation on a Kosli trail, or on an artifact in it, so code-quality evidence can be attached from Terraform-driven pipelines.\n\n" +
+			"~> **Note:** Attestations are immutable. Changing any attribute reports a new attestation, and destroying the resource only removes it from the Terraform state: the attestation stays on the trail.",
+
+		Attributes: map[string]schema.Attribute{
+			"flow": schema.StringAttribute{
+				MarkdownDescription: "Name of the flow the trail belongs to. Changing this will report a new attestation.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"trail": schema.StringAttribute{
+				MarkdownDescription: "Name of the trail to attest. Changing this will report a new attestation.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the attestation, matching the attestation expected by the flow template (e.g. `code-quality`). Changing this will report a new attestation.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"artifact_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "SHA256 fingerprint of the artifact to attest. If omitted, the attestation is reported for the trail. Changing this will report a new attestation.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"project_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the SonarQube or SonarCloud project that was scanned. Changing this will report a new attestation.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"quality_gate_status": schema.StringAttribute{
+				MarkdownDescription: "Quality gate status of the scan. Valid values: `OK`, `WARN`, `ERROR`, `NONE`. Changing this will report a new attestation.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"scan_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the scan results in SonarQube or SonarCloud. Changing this will report a new attestation.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"compliant": schema.BoolAttribute{
+				MarkdownDescription: "Whether the attestation is compliant, which is the case when `quality_gate_status` is `OK`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *sonarAttestationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KosliResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create reports the attestation and sets the Terraform state.
+func (r *sonarAttestationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data sonarAttestationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attestReq := &client.SonarAttestationRequest{
+		FlowName:            data.Flow.ValueString(),
+		TrailName:           data.Trail.ValueString(),
+		Name:                data.Name.ValueString(),
+		ArtifactFingerprint: data.ArtifactFingerprint.ValueString(),
+		ProjectKey:          data.ProjectKey.ValueString(),
+		QualityGateStatus:   data.QualityGateStatus.ValueString(),
+		ScanURL:             data.ScanURL.ValueString(),
+	}
+
+	if err := r.client.CreateSonarAttestation(ctx, attestReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Sonar Attestation",
+			fmt.Sprintf("Could not report sonar attestation %q to trail %q of flow %q: %s", attestReq.Name, attestReq.TrailName, attestReq.FlowName, apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// The API returns "OK" rather than the attestation, and the attestation
+	// is immutable, so the planned values are what was reported
+	data.Compliant = types.BoolValue(data.QualityGateStatus.ValueString() == sonarQualityGatePassed)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the Terraform state: attestations cannot change after they are
+// reported.
+func (r *sonarAttestationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The response state already holds the prior state
+}
+
+// Update is never called with a change, since every attribute requires
+// replacement; it stores the plan.
+func (r *sonarAttestationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data sonarAttestationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the attestation from the Terraform state. Kosli keeps
+// reported attestations, so there is nothing to delete in the API.
+func (r *sonarAttestationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// State is automatically removed by the framework
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestSonarAttestationResource_Metadata(t *testing.T) {
+	r := &sonarAttestationResource{}
+
+	req := resource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_attestation_sonar" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_attestation_sonar", resp.TypeName)
+	}
+}
+
+func TestSonarAttestationResource_Schema(t *testing.T) {
+	r := &sonarAttestationResource{}
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	attrs := resp.Schema.Attributes
+	for _, name := range []string{"flow", "trail", "name", "project_key", "quality_gate_status"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsRequired() {
+			t.Errorf("Expected attribute %q to be required", name)
+		}
+	}
+	for _, name := range []string{"artifact_fingerprint", "scan_url"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsOptional() {
+			t.Errorf("Expected attribute %q to be optional", name)
+		}
+	}
+	if !attrs["compliant"].IsComputed() {
+		t.Error("Expected 'compliant' to be computed")
+	}
+}
+
+func TestSonarAttestationResource_Configure(t *testing.T) {
+	r := &sonarAttestationResource{}
+
+	req := resource.ConfigureRequest{ProviderData: nil}
+	resp := &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = resource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
@@ -1,15 +1,23 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
@@ -17,6 +25,16 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &customAttestationTypeResource{}
 var _ resource.ResourceWithImportState = &customAttestationTypeResource{}
+var _ resource.ResourceWithModifyPlan = &customAttestationTypeResource{}
+
+// rawSchemaSHA256Key is the private state key of the RawSchemaSHA256 of the
+// schema in state, which Read compares to skip normalizing it again.
+const rawSchemaSHA256Key = "raw_schema_sha256"
+
+// versionCountWarningThreshold is the number of versions above which an
+// update warns that a custom attestation type is accumulating versions.
+// Every update creates a version and the API has no way to remove old ones.
+const versionCountWarningThreshold = 50
 
 // NewCustomAttestationTypeResource creates a new custom attestation type resource.
 func NewCustomAttestationTypeResource() resource.Resource {
@@ -26,6 +44,15 @@ func NewCustomAttestationTypeResource() resource.Resource {
 // customAttestationTypeResource defines the resource implementation.
 type customAttestationTypeResource struct {
 	client *client.Client
+
+	// skipReadAfterWrite trusts planned values after a successful write
+	// instead of reading the object back. See the provider's
+	// skip_read_after_write attribute.
+	skipReadAfterWrite bool
+
+	// keepEmptyDescriptions keeps an explicit empty description in state.
+	// See the provider's keep_empty_descriptions attribute.
+	keepEmptyDescriptions bool
 }
 
 // customAttestationTypeResourceModel describes the resource data model.
@@ -34,6 +61,13 @@ type customAttestationTypeResourceModel struct {
 	Description types.String         `tfsdk:"description"`
 	Schema      jsontypes.Normalized `tfsdk:"schema"`
 	JqRules     types.List           `tfsdk:"jq_rules"`
+
+	UnorderedRules types.Bool `tfsdk:"unordered_rules"`
+
+	Version       types.Int64  `tfsdk:"version"`
+	LatestVersion types.Int64  `tfsdk:"latest_version"`
+	AppURL        types.String `tfsdk:"app_url"`
+	SchemaSHA256  types.String `tfsdk:"schema_sha256"`
 }
 
 // Metadata returns the resource type name.
@@ -53,20 +87,61 @@ func (r *customAttestationTypeResource) Schema(ctx context.Context, req resource
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					customAttestationTypeNameValidator{},
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the custom attestation type. Explains what this attestation type validates.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					descriptionDiffSuppressor{},
+				},
 			},
 			"schema": schema.StringAttribute{
 				MarkdownDescription: "JSON Schema definition that defines the structure of attestation data. Can be provided inline using heredoc syntax or loaded from a file using `file()`. If omitted, no schema validation is performed. Semantic equality is used for comparison, so formatting differences are ignored.",
 				Optional:            true,
 				CustomType:          jsontypes.NormalizedType{},
+				Validators: []validator.String{
+					customAttestationTypeSchemaSizeValidator(),
+				},
+			},
+			"schema_sha256": schema.StringAttribute{
+				MarkdownDescription: "Hex-encoded SHA-256 of the normalized `schema`, so other resources can react to schema changes without comparing the whole document. " +
+					"Formatting and key order do not change it. Null if there is no schema.",
+				Computed: true,
 			},
 			"jq_rules": schema.ListAttribute{
 				MarkdownDescription: "List of jq evaluation rules. Each rule is a jq expression that must evaluate to true for the attestation to be considered compliant. Example: `[\".coverage >= 80\"]`. If omitted, no evaluation is performed.",
 				Optional:            true,
 				ElementType:         types.StringType,
+				Validators: []validator.List{
+					jqRulesValidator{},
+				},
+				PlanModifiers: []planmodifier.List{
+					jqRulesOrderSuppressor{},
+				},
+			},
+			"unordered_rules": schema.BoolAttribute{
+				MarkdownDescription: "Whether the order of `jq_rules` is insignificant. All rules must hold for an attestation to be compliant, so their order does not change evaluation. " +
+					"When `true`, reordering `jq_rules` does not publish a new version; the rules keep the order they were last published in. Defaults to `false`.",
+				Optional: true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the custom attestation type published by the last create or update. Null after a write with `skip_read_after_write` until the next refresh.",
+				Computed:            true,
+			},
+			"latest_version": schema.Int64Attribute{
+				MarkdownDescription: "Latest version of the custom attestation type, refreshed on every read. " +
+					"When it differs from `version`, a version was published outside Terraform, e.g. with the Kosli CLI, and the next plan updates the type to publish the configured content again.",
+				Computed: true,
+			},
+			"app_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the custom attestation type in the Kosli web UI.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -78,16 +153,74 @@ func (r *customAttestationTypeResource) Configure(ctx context.Context, req resou
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.skipReadAfterWrite = providerData.SkipReadAfterWrite
+	r.keepEmptyDescriptions = providerData.KeepEmptyDescriptions
+}
+
+// ModifyPlan plans the schema hash, plans an update when a version was
+// published outside Terraform, and marks the version attributes unknown
+// whenever an update will publish a new version.
+func (r *customAttestationTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan customAttestationTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Dependents see the new hash at plan time, unless the schema is only
+	// known at apply time.
+	if !plan.Schema.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_sha256"), customAttestationTypeSchemaSHA256(plan.Schema))...)
+	}
+
+	// The rest only applies to updates (both state and plan present).
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state customAttestationTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outOfBand := !state.Version.IsNull() && !state.LatestVersion.IsNull() &&
+		state.Version.ValueInt64() != state.LatestVersion.ValueInt64()
+	if outOfBand {
+		resp.Diagnostics.AddWarning(
+			"Custom Attestation Type Changed Outside Terraform",
+			fmt.Sprintf("Custom attestation type %q has version %d, but the last version published by Terraform is %d. "+
+				"Applying publishes the configured description, schema and jq_rules as a new version.",
+				state.Name.ValueString(), state.LatestVersion.ValueInt64(), state.Version.ValueInt64()),
 		)
+	}
+
+	changed := !plan.Description.Equal(state.Description) || !plan.Schema.Equal(state.Schema) || !plan.JqRules.Equal(state.JqRules)
+	if outOfBand || changed {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("latest_version"), types.Int64Unknown())...)
 		return
 	}
 
-	r.client = client
+	// Only settings that are not published changed, e.g. unordered_rules, so
+	// Update keeps the version instead of publishing a new one.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), state.Version)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("latest_version"), state.LatestVersion)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -100,6 +233,13 @@ func (r *customAttestationTypeResource) Create(ctx context.Context, req resource
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "custom-attestation-types", data.Name.ValueString()))
+	data.SchemaSHA256 = customAttestationTypeSchemaSHA256(data.Schema)
+
+	// Keep other operations on the same custom attestation type from interleaving with this one
+	defer lockObject(ctx, r.client, "custom_attestation_type", data.Name.ValueString())()
+
 	// Extract jq_rules from the list if not null
 	var jqRules []string
 	if !data.JqRules.IsNull() {
@@ -127,11 +267,20 @@ func (r *customAttestationTypeResource) Create(ctx context.Context, req resource
 	if err := r.client.CreateCustomAttestationType(ctx, createReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Custom Attestation Type",
-			fmt.Sprintf("Could not create custom attestation type %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create custom attestation type %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
+	// Trust the plan instead of reading the custom attestation type back, if
+	// configured. The published version is only known after the next refresh.
+	if r.skipReadAfterWrite {
+		data.Version = types.Int64Null()
+		data.LatestVersion = types.Int64Null()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Per ADR 002: POST returns "OK", so we must GET to populate state.
 	// We pass nil for the rePut callback because CreateCustomAttestationType
 	// is a POST that allocates a new version on every call — re-issuing it
@@ -139,11 +288,15 @@ func (r *customAttestationTypeResource) Create(ctx context.Context, req resource
 	// version counter on the underlying type. The retry still surfaces the
 	// rename-race hint so users know to use `terraform state mv`; full
 	// recovery requires `state mv` or API support for upsert-on-archive.
-	attestationType, err := retryReadAfterCreate(ctx,
+	// Types are also eventually consistent while their versions are being
+	// indexed: a fresh type can 404, or be returned without its first
+	// version, so both are retried.
+	attestationType, err := retryReadAfterCreateUntil(ctx,
 		nil,
 		func(ctx context.Context) (*client.CustomAttestationType, error) {
 			return r.client.GetCustomAttestationType(ctx, createReq.Name, nil)
 		},
+		customAttestationTypeIndexed,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -154,12 +307,9 @@ func (r *customAttestationTypeResource) Create(ctx context.Context, req resource
 	}
 
 	// Map API response to Terraform state
-	// Handle empty description as null to avoid inconsistency when not provided in config
-	if attestationType.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(attestationType.Description)
-	}
+	data.Description = descriptionFromAPI(attestationType.Description, data.Description, r.keepEmptyDescriptions)
+	data.LatestVersion = latestCustomAttestationTypeVersion(attestationType)
+	data.Version = data.LatestVersion
 
 	// Handle empty schema as null (similar to description handling)
 	if attestationType.Schema == "" || attestationType.Schema == "None" {
@@ -167,6 +317,8 @@ func (r *customAttestationTypeResource) Create(ctx context.Context, req resource
 	} else {
 		data.Schema = jsontypes.NewNormalizedValue(attestationType.Schema)
 	}
+	data.SchemaSHA256 = customAttestationTypeSchemaSHA256(data.Schema)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, rawSchemaSHA256Key, rawSchemaSHA256Private(attestationType))...)
 
 	// Convert jq_rules back to list, handling empty list as null
 	if len(attestationType.JqRules) == 0 {
@@ -194,22 +346,38 @@ func (r *customAttestationTypeResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "custom-attestation-types", data.Name.ValueString()))
+
+	// Normalizing a large schema is costly, so the schema in state is reused
+	// while the API returns the same raw schema as when it was read
+	opts := &client.GetCustomAttestationTypeOptions{}
+	if !data.Schema.IsNull() {
+		rawSHA256, diags := req.Private.GetKey(ctx, rawSchemaSHA256Key)
+		resp.Diagnostics.Append(diags...)
+		var hash string
+		if len(rawSHA256) > 0 && json.Unmarshal(rawSHA256, &hash) == nil && hash != "" {
+			opts.Known = &client.KnownSchema{RawSHA256: hash, Schema: data.Schema.ValueString()}
+		}
+	}
+
 	// Get current state from API
-	attestationType, err := r.client.GetCustomAttestationType(ctx, data.Name.ValueString(), nil)
+	attestationType, err := r.client.GetCustomAttestationType(ctx, data.Name.ValueString(), opts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Custom Attestation Type",
-			fmt.Sprintf("Could not read custom attestation type %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read custom attestation type %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	// Map API response to Terraform state
-	// Handle empty description as null to avoid inconsistency when not provided in config
-	if attestationType.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(attestationType.Description)
+	// Map API response to Terraform state. The version Terraform published
+	// is kept, so versions published outside Terraform show up as a
+	// difference; imported types adopt the latest version.
+	data.Description = descriptionFromAPI(attestationType.Description, data.Description, r.keepEmptyDescriptions)
+	data.LatestVersion = latestCustomAttestationTypeVersion(attestationType)
+	if data.Version.IsNull() {
+		data.Version = data.LatestVersion
 	}
 
 	// Handle empty schema as null (similar to description handling)
@@ -218,6 +386,11 @@ func (r *customAttestationTypeResource) Read(ctx context.Context, req resource.R
 	} else {
 		data.Schema = jsontypes.NewNormalizedValue(attestationType.Schema)
 	}
+	schemaUnchanged := opts.Known != nil && attestationType.RawSchemaSHA256 == opts.Known.RawSHA256
+	if !schemaUnchanged || data.SchemaSHA256.IsNull() {
+		data.SchemaSHA256 = customAttestationTypeSchemaSHA256(data.Schema)
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, rawSchemaSHA256Key, rawSchemaSHA256Private(attestationType))...)
 
 	// Convert jq_rules back to list, handling empty list as null
 	if len(attestationType.JqRules) == 0 {
@@ -246,6 +419,20 @@ func (r *customAttestationTypeResource) Update(ctx context.Context, req resource
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "custom-attestation-types", data.Name.ValueString()))
+	data.SchemaSHA256 = customAttestationTypeSchemaSHA256(data.Schema)
+
+	// ModifyPlan keeps the version when nothing published changed, e.g. only
+	// unordered_rules; publishing would create a version with the same content
+	if !data.Version.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Keep other operations on the same custom attestation type from interleaving with this one
+	defer lockObject(ctx, r.client, "custom_attestation_type", data.Name.ValueString())()
+
 	// Extract jq_rules from the list if not null
 	var jqRules []string
 	if !data.JqRules.IsNull() {
@@ -273,28 +460,48 @@ func (r *customAttestationTypeResource) Update(ctx context.Context, req resource
 	if err := r.client.CreateCustomAttestationType(ctx, createReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Custom Attestation Type",
-			fmt.Sprintf("Could not update custom attestation type %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update custom attestation type %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	// GET to populate state with new version
-	attestationType, err := r.client.GetCustomAttestationType(ctx, data.Name.ValueString(), nil)
+	// Trust the plan instead of reading the custom attestation type back, if
+	// configured. The published version is only known after the next refresh.
+	if r.skipReadAfterWrite {
+		data.Version = types.Int64Null()
+		data.LatestVersion = types.Int64Null()
+		// The raw schema the API now returns is not known yet
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, rawSchemaSHA256Key, nil)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// GET to populate state with new version. The API can still serve the
+	// previous version while the new one is indexed; retry briefly rather
+	// than record the pre-update content.
+	attestationType, err := retryReadAfterUpdate(ctx,
+		func(ctx context.Context) (*client.CustomAttestationType, error) {
+			return r.client.GetCustomAttestationType(ctx, createReq.Name, nil)
+		},
+		func(at *client.CustomAttestationType) bool {
+			return customAttestationTypeMatches(at, createReq)
+		},
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Custom Attestation Type After Update",
-			fmt.Sprintf("Could not read custom attestation type %q after update: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read custom attestation type %q after update: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
+	// Warn when old versions are piling up
+	resp.Diagnostics.Append(versionCountWarning(data.Name.ValueString(), len(attestationType.Versions))...)
+
 	// Map API response to Terraform state
-	// Handle empty description as null to avoid inconsistency when not provided in config
-	if attestationType.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(attestationType.Description)
-	}
+	data.Description = descriptionFromAPI(attestationType.Description, data.Description, r.keepEmptyDescriptions)
+	data.LatestVersion = latestCustomAttestationTypeVersion(attestationType)
+	data.Version = data.LatestVersion
 
 	// Handle empty schema as null (similar to description handling)
 	if attestationType.Schema == "" || attestationType.Schema == "None" {
@@ -302,6 +509,8 @@ func (r *customAttestationTypeResource) Update(ctx context.Context, req resource
 	} else {
 		data.Schema = jsontypes.NewNormalizedValue(attestationType.Schema)
 	}
+	data.SchemaSHA256 = customAttestationTypeSchemaSHA256(data.Schema)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, rawSchemaSHA256Key, rawSchemaSHA256Private(attestationType))...)
 
 	// Convert jq_rules back to list, handling empty list as null
 	if len(attestationType.JqRules) == 0 {
@@ -319,6 +528,20 @@ func (r *customAttestationTypeResource) Update(ctx context.Context, req resource
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// versionCountWarning warns when a custom attestation type has more than
+// versionCountWarningThreshold versions.
+func versionCountWarning(name string, versions int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if versions > versionCountWarningThreshold {
+		diags.AddWarning(
+			"Custom Attestation Type Has Many Versions",
+			fmt.Sprintf("Custom attestation type %q now has %d versions. Every update creates a new version and Kosli keeps all of them. "+
+				"If the type changes often, consider whether all of its changes need to go through Terraform.", name, versions),
+		)
+	}
+	return diags
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 // Per the API behavior, this archives the attestation type (soft delete).
 func (r *customAttestationTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -330,11 +553,14 @@ func (r *customAttestationTypeResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	// Keep other operations on the same custom attestation type from interleaving with this one
+	defer lockObject(ctx, r.client, "custom_attestation_type", data.Name.ValueString())()
+
 	// Archive the custom attestation type
 	if err := r.client.ArchiveCustomAttestationType(ctx, data.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Custom Attestation Type",
-			fmt.Sprintf("Could not archive custom attestation type %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not archive custom attestation type %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -347,3 +573,77 @@ func (r *customAttestationTypeResource) ImportState(ctx context.Context, req res
 	// Import by name
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// customAttestationTypeIndexed reports whether a custom attestation type read
+// back after creation includes its first version, which holds the schema and
+// jq rules.
+func customAttestationTypeIndexed(at *client.CustomAttestationType) bool {
+	return len(at.Versions) > 0
+}
+
+// latestCustomAttestationTypeVersion returns the number of the latest version
+// of a custom attestation type, or null if it has no versions.
+func latestCustomAttestationTypeVersion(at *client.CustomAttestationType) types.Int64 {
+	if len(at.Versions) == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(at.Versions[0].Version))
+}
+
+// customAttestationTypeMatches reports whether the latest version of a custom
+// attestation type read back after an update has the content that was sent,
+// comparing schemas as JSON and descriptions as descriptionDiffSuppressor
+// does.
+func customAttestationTypeMatches(at *client.CustomAttestationType, req *client.CreateCustomAttestationTypeRequest) bool {
+	if normalizeDescription(at.Description) != normalizeDescription(req.Description) {
+		return false
+	}
+	if !slices.Equal(at.JqRules, req.JqRules) {
+		return false
+	}
+
+	schema := at.Schema
+	if schema == "None" {
+		schema = ""
+	}
+	if schema == "" || req.Schema == "" {
+		return schema == req.Schema
+	}
+	var got, want any
+	if json.Unmarshal([]byte(schema), &got) != nil || json.Unmarshal([]byte(req.Schema), &want) != nil {
+		return schema == req.Schema
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// customAttestationTypeSchemaSHA256 returns the hex-encoded SHA-256 of a
+// schema in compact JSON with sorted keys, so that formatting and key order
+// do not change it, or null if there is no schema.
+func customAttestationTypeSchemaSHA256(s jsontypes.Normalized) types.String {
+	if s.IsNull() || s.IsUnknown() {
+		return types.StringNull()
+	}
+
+	normalized := []byte(s.ValueString())
+	var v any
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.UseNumber() // Keep numbers as written, e.g. large integers
+	if decoder.Decode(&v) == nil {
+		if compact, err := json.Marshal(v); err == nil {
+			normalized = compact
+		}
+	}
+
+	sum := sha256.Sum256(normalized)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+// rawSchemaSHA256Private returns the private state value recording the raw
+// schema hash of at, or nil to remove it if at has no schema.
+func rawSchemaSHA256Private(at *client.CustomAttestationType) []byte {
+	if at.RawSchemaSHA256 == "" {
+		return nil
+	}
+	value, _ := json.Marshal(at.RawSchemaSHA256)
+	return value
+}
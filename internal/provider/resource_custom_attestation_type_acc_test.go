@@ -34,6 +34,7 @@ func TestAccCustomAttestationTypeResource_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttrSet(resourceName, "schema"),
+					resource.TestCheckResourceAttrSet(resourceName, "schema_sha256"),
 					resource.TestCheckResourceAttr(resourceName, "jq_rules.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "jq_rules.0", ".coverage >= 80"),
 				),
@@ -345,3 +346,53 @@ resource "kosli_custom_attestation_type" "test" {
 }
 `, name)
 }
+
+// TestAccCustomAttestationTypeResource_unorderedRules tests that reordering
+// jq_rules is ignored with unordered_rules, and published without it
+func TestAccCustomAttestationTypeResource_unorderedRules(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "kosli_custom_attestation_type.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Step 1: Create with unordered rules
+			{
+				Config: testAccCustomAttestationTypeResourceConfigUnorderedRules(rName, true, `".coverage >= 80", ".age > 21"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "unordered_rules", "true"),
+					resource.TestCheckResourceAttr(resourceName, "jq_rules.0", ".coverage >= 80"),
+					resource.TestCheckResourceAttr(resourceName, "jq_rules.1", ".age > 21"),
+				),
+			},
+			// Step 2: Reordering the rules plans no changes
+			{
+				Config:   testAccCustomAttestationTypeResourceConfigUnorderedRules(rName, true, `".age > 21", ".coverage >= 80"`),
+				PlanOnly: true,
+			},
+			// Step 3: Without unordered_rules, the new order is published
+			{
+				Config: testAccCustomAttestationTypeResourceConfigUnorderedRules(rName, false, `".age > 21", ".coverage >= 80"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "unordered_rules", "false"),
+					resource.TestCheckResourceAttr(resourceName, "jq_rules.0", ".age > 21"),
+					resource.TestCheckResourceAttr(resourceName, "jq_rules.1", ".coverage >= 80"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCustomAttestationTypeResourceConfigUnorderedRules returns
+// configuration with the given rules and unordered_rules setting
+func testAccCustomAttestationTypeResourceConfigUnorderedRules(name string, unordered bool, rules string) string {
+	return fmt.Sprintf(`
+resource "kosli_custom_attestation_type" "test" {
+  name            = %[1]q
+  description     = "Attestation type with unordered rules"
+  unordered_rules = %[2]t
+  jq_rules        = [%[3]s]
+}
+`, name, unordered, rules)
+}
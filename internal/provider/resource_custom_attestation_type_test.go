@@ -2,11 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
 func TestCustomAttestationTypeResource_Metadata(t *testing.T) {
@@ -41,7 +48,7 @@ func TestCustomAttestationTypeResource_Schema(t *testing.T) {
 
 	// Verify required attributes exist
 	attrs := resp.Schema.Attributes
-	requiredAttrs := []string{"name", "description", "schema", "jq_rules"}
+	requiredAttrs := []string{"name", "description", "schema", "schema_sha256", "jq_rules", "unordered_rules", "app_url"}
 	for _, attr := range requiredAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -154,6 +161,20 @@ func TestCustomAttestationTypeResource_Implements(t *testing.T) {
 	var _ resource.ResourceWithImportState = &customAttestationTypeResource{}
 }
 
+func TestVersionCountWarning(t *testing.T) {
+	if diags := versionCountWarning("coverage", versionCountWarningThreshold); len(diags) != 0 {
+		t.Errorf("expected no warning at the threshold, got %v", diags)
+	}
+
+	diags := versionCountWarning("coverage", versionCountWarningThreshold+1)
+	if diags.WarningsCount() != 1 || diags.HasError() {
+		t.Fatalf("expected a single warning above the threshold, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, `"coverage"`) || !strings.Contains(detail, "51 versions") {
+		t.Errorf("expected detail to name the type and version count, got %q", detail)
+	}
+}
+
 // Note: Full CRUD operation tests require acceptance testing (issue #17)
 // These tests verify the resource structure and basic configuration,
 // while acceptance tests will verify the full lifecycle against a real API.
@@ -168,3 +189,227 @@ func TestCustomAttestationTypeResource_Implements(t *testing.T) {
 // - Real resources are created/updated/deleted in a test Kosli organization
 // - The full Terraform lifecycle is exercised
 // - API integration is validated end-to-end
+
+func TestCustomAttestationTypeIndexed(t *testing.T) {
+	if customAttestationTypeIndexed(&client.CustomAttestationType{Name: "security-scan"}) {
+		t.Error("Expected a type without versions not to be indexed")
+	}
+	if !customAttestationTypeIndexed(&client.CustomAttestationType{Name: "security-scan", Versions: []client.Version{{Version: 1}}}) {
+		t.Error("Expected a type with a version to be indexed")
+	}
+}
+
+func TestCustomAttestationTypeMatches(t *testing.T) {
+	req := &client.CreateCustomAttestationTypeRequest{
+		Name:        "security-scan",
+		Description: "Security scan results",
+		Schema:      `{"type": "object", "required": ["critical"]}`,
+		JqRules:     []string{".critical == 0"},
+	}
+	current := client.CustomAttestationType{
+		Name:        "security-scan",
+		Description: "Security scan results\n",
+		Schema:      `{"required":["critical"],"type":"object"}`,
+		JqRules:     []string{".critical == 0"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(at *client.CustomAttestationType)
+		want   bool
+	}{
+		{"current", func(at *client.CustomAttestationType) {}, true},
+		{"stale description", func(at *client.CustomAttestationType) { at.Description = "Old" }, false},
+		{"stale schema", func(at *client.CustomAttestationType) { at.Schema = `{"type":"object"}` }, false},
+		{"stale jq rules", func(at *client.CustomAttestationType) { at.JqRules = []string{".high == 0"} }, false},
+		{"stale schema removal", func(at *client.CustomAttestationType) { at.Schema = "None" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := current
+			tt.modify(&at)
+			if got := customAttestationTypeMatches(&at, req); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	noSchema := &client.CreateCustomAttestationTypeRequest{Name: "security-scan"}
+	if !customAttestationTypeMatches(&client.CustomAttestationType{Name: "security-scan", Schema: "None"}, noSchema) {
+		t.Error("Expected a \"None\" schema to match no schema")
+	}
+}
+
+func TestCustomAttestationTypeResource_ModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := &customAttestationTypeResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	value := func(description string, version, latestVersion any) tftypes.Value {
+		return tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"name":            tftypes.NewValue(tftypes.String, "security-scan"),
+			"description":     tftypes.NewValue(tftypes.String, description),
+			"schema":          tftypes.NewValue(tftypes.String, nil),
+			"jq_rules":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"unordered_rules": tftypes.NewValue(tftypes.Bool, nil),
+			"version":         tftypes.NewValue(tftypes.Number, version),
+			"latest_version":  tftypes.NewValue(tftypes.Number, latestVersion),
+			"app_url":         tftypes.NewValue(tftypes.String, nil),
+			"schema_sha256":   tftypes.NewValue(tftypes.String, nil),
+		})
+	}
+
+	tests := []struct {
+		name        string
+		state       tftypes.Value
+		plan        tftypes.Value
+		wantUnknown bool
+		wantWarning bool
+	}{
+		{"unchanged", value("Scans", 3, 3), value("Scans", 3, 3), false, false},
+		{"content changed", value("Scans", 3, 3), value("Security scans", 3, 3), true, false},
+		{"published outside Terraform", value("Scans", 3, 4), value("Scans", 3, 4), true, true},
+		{"only unpublished settings changed", value("Scans", 3, 3), value("Scans", tftypes.UnknownValue, tftypes.UnknownValue), false, false},
+		{"written with skip_read_after_write", value("Scans", nil, nil), value("Scans", nil, nil), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tt.state},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tt.plan},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			var plan customAttestationTypeResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+			if got := plan.Version.IsUnknown() && plan.LatestVersion.IsUnknown(); got != tt.wantUnknown {
+				t.Errorf("Expected unknown versions %v, got version %v and latest_version %v", tt.wantUnknown, plan.Version, plan.LatestVersion)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("Expected warning %v, got %v", tt.wantWarning, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+// TestCustomAttestationTypeResource_ModifyPlan_SchemaSHA256 tests that the
+// schema hash is known at plan time when creating a custom attestation type.
+func TestCustomAttestationTypeResource_ModifyPlan_SchemaSHA256(t *testing.T) {
+	ctx := context.Background()
+	r := &customAttestationTypeResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+	plan := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":            tftypes.NewValue(tftypes.String, "security-scan"),
+		"description":     tftypes.NewValue(tftypes.String, nil),
+		"schema":          tftypes.NewValue(tftypes.String, `{"type": "object"}`),
+		"jq_rules":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"unordered_rules": tftypes.NewValue(tftypes.Bool, nil),
+		"version":         tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+		"latest_version":  tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+		"app_url":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"schema_sha256":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors: %v", resp.Diagnostics.Errors())
+	}
+	var got types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("schema_sha256"), &got)...)
+	want := customAttestationTypeSchemaSHA256(jsontypes.NewNormalizedValue(`{"type":"object"}`))
+	if !got.Equal(want) {
+		t.Errorf("Expected planned schema_sha256 %v, got %v", want, got)
+	}
+}
+
+func TestCustomAttestationTypeSchemaSHA256(t *testing.T) {
+	compact := customAttestationTypeSchemaSHA256(jsontypes.NewNormalizedValue(`{"type":"object","required":["coverage"]}`))
+	if len(compact.ValueString()) != 64 {
+		t.Fatalf("Expected a hex-encoded SHA-256, got %v", compact)
+	}
+
+	reformatted := customAttestationTypeSchemaSHA256(jsontypes.NewNormalizedValue("{\n  \"required\": [\"coverage\"],\n  \"type\": \"object\"\n}"))
+	if !reformatted.Equal(compact) {
+		t.Errorf("Expected formatting and key order not to change the hash, got %v and %v", compact, reformatted)
+	}
+
+	changed := customAttestationTypeSchemaSHA256(jsontypes.NewNormalizedValue(`{"type":"object","required":["coverage","branch"]}`))
+	if changed.Equal(compact) {
+		t.Error("Expected a different schema to change the hash")
+	}
+
+	if got := customAttestationTypeSchemaSHA256(jsontypes.NewNormalizedNull()); !got.IsNull() {
+		t.Errorf("Expected null without a schema, got %v", got)
+	}
+}
+
+func TestLatestCustomAttestationTypeVersion(t *testing.T) {
+	at := &client.CustomAttestationType{Versions: []client.Version{{Version: 4}, {Version: 3}}}
+	if got := latestCustomAttestationTypeVersion(at); !got.Equal(types.Int64Value(4)) {
+		t.Errorf("Expected 4, got %v", got)
+	}
+	if got := latestCustomAttestationTypeVersion(&client.CustomAttestationType{}); !got.IsNull() {
+		t.Errorf("Expected null without versions, got %v", got)
+	}
+}
+
+// benchmarkSchema returns a JSON Schema of about size bytes with many
+// properties, and the same schema compacted as the API returns it.
+func benchmarkSchema(size int) (indented, compact string) {
+	properties := map[string]any{}
+	for i := 0; i < size/80; i++ {
+		properties[fmt.Sprintf("field_%d", i)] = map[string]any{"type": "number", "minimum": 0, "description": fmt.Sprintf("Measurement %d", i)}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	indentedJSON, _ := json.MarshalIndent(schema, "", "  ")
+	compactJSON, _ := json.Marshal(schema)
+	return string(indentedJSON), string(compactJSON)
+}
+
+func BenchmarkCustomAttestationTypeMatches(b *testing.B) {
+	for _, size := range []int{1 << 20, client.MaxCustomAttestationTypeSchemaSize} {
+		indented, compact := benchmarkSchema(size)
+		at := &client.CustomAttestationType{Name: "coverage", Schema: compact}
+		req := &client.CreateCustomAttestationTypeRequest{Name: "coverage", Schema: indented}
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(indented)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !customAttestationTypeMatches(at, req) {
+					b.Fatal("Expected the schemas to match")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCustomAttestationTypeSchemaSHA256(b *testing.B) {
+	for _, size := range []int{1 << 20, client.MaxCustomAttestationTypeSchemaSize} {
+		indented, _ := benchmarkSchema(size)
+		schema := jsontypes.NewNormalizedValue(indented)
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(indented)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				customAttestationTypeSchemaSHA256(schema)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &environmentResource{}
 var _ resource.ResourceWithImportState = &environmentResource{}
+var _ resource.ResourceWithModifyPlan = &environmentResource{}
+var _ resource.ResourceWithConfigValidators = &environmentResource{}
 
 // NewEnvironmentResource creates a new environment resource.
 func NewEnvironmentResource() resource.Resource {
@@ -27,6 +29,24 @@ func NewEnvironmentResource() resource.Resource {
 // environmentResource defines the resource implementation.
 type environmentResource struct {
 	client *client.Client
+
+	// skipReadAfterWrite trusts planned values after a successful write
+	// instead of reading the object back. See the provider's
+	// skip_read_after_write attribute.
+	skipReadAfterWrite bool
+
+	// keepEmptyDescriptions keeps an explicit empty description in state.
+	// See the provider's keep_empty_descriptions attribute.
+	keepEmptyDescriptions bool
+
+	// defaultTags are merged into the tags of every environment. See the
+	// provider's default_tags attribute.
+	defaultTags map[string]string
+
+	// environmentNames catches a physical and a logical environment planned
+	// with the same name. Shared by the resources of one provider
+	// configuration.
+	environmentNames *environmentNames
 }
 
 // environmentResourceModel describes the resource data model.
@@ -36,6 +56,16 @@ type environmentResourceModel struct {
 	Description    types.String `tfsdk:"description"`
 	IncludeScaling types.Bool   `tfsdk:"include_scaling"`
 	Tags           types.Map    `tfsdk:"tags"`
+	TagsAll        types.Map    `tfsdk:"tags_all"`
+
+	Policies          types.Set  `tfsdk:"policies"`
+	ExclusivePolicies types.Bool `tfsdk:"exclusive_policies"`
+
+	ComplianceStatus types.String  `tfsdk:"compliance_status"`
+	CompliantSince   types.Float64 `tfsdk:"compliant_since"`
+	LastModifiedAt   types.Float64 `tfsdk:"last_modified_at"`
+	LastReportedAt   types.Float64 `tfsdk:"last_reported_at"`
+	AppURL           types.String  `tfsdk:"app_url"`
 }
 
 // Metadata returns the resource type name.
@@ -47,8 +77,7 @@ func (r *environmentResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Kosli environment. Environments represent deployment targets where artifacts are deployed. Supports physical environment types: K8S, ECS, S3, docker, server, and lambda.\n\n" +
-			"~> **Note:** Environment policies will be available in a future release. " +
-			"For querying environment metadata such as `last_modified_at`, `last_reported_at`, and `archived` status, use the `kosli_environment` data source.",
+			"~> **Note:** For querying other environment metadata such as `archived` status, use the `kosli_environment` data source.",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -68,6 +97,9 @@ func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaReq
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the environment. Explains the purpose and characteristics of this deployment target.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					descriptionDiffSuppressor{},
+				},
 			},
 			"include_scaling": schema.BoolAttribute{
 				MarkdownDescription: "Whether to include scaling information when reporting environment snapshots. Defaults to `false`.",
@@ -81,6 +113,47 @@ func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "All tags of the environment: the provider's `default_tags` merged with `tags`, where `tags` wins for keys set in both. While `tags` is not set, it also includes tags set outside this resource, such as by `kosli_environment_tag`, which are left in place.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"policies": schema.SetAttribute{
+				MarkdownDescription: "Names of the policies to attach to the environment. Omit it to leave policies to `kosli_policy_attachment` resources.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"exclusive_policies": schema.BoolAttribute{
+				MarkdownDescription: "Whether `policies` are the only policies attached to the environment. " +
+					"If `true`, policies attached outside Terraform show up as drift and are detached on the next apply, so Terraform is the single source of truth; " +
+					"do not combine it with `kosli_policy_attachment` resources for the same environment. If `false` (the default), the listed policies are attached, policies removed from `policies` are detached, and policies attached outside Terraform are left alone. Requires `policies`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"compliance_status": schema.StringAttribute{
+				MarkdownDescription: "Compliance of the latest snapshot reported for the environment: `COMPLIANT`, `NON-COMPLIANT`, or `UNKNOWN` if the environment has never reported a snapshot or its snapshots could not be read.",
+				Computed:            true,
+			},
+			"compliant_since": schema.Float64Attribute{
+				MarkdownDescription: "Unix timestamp (with fractional seconds) of the first snapshot of the current unbroken run of compliant snapshots, looking back at most 10 pages of snapshots; for a longer run, the timestamp of the oldest snapshot in those pages. Null unless `compliance_status` is `COMPLIANT`.",
+				Computed:            true,
+			},
+			"last_modified_at": schema.Float64Attribute{
+				MarkdownDescription: "Unix timestamp (with fractional seconds) of when the environment was last modified. Null after a write with `skip_read_after_write` until the next refresh.",
+				Computed:            true,
+			},
+			"last_reported_at": schema.Float64Attribute{
+				MarkdownDescription: "Unix timestamp (with fractional seconds) of when a snapshot of the environment was last reported. Null if the environment has never reported a snapshot.",
+				Computed:            true,
+			},
+			"app_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the environment in the Kosli web UI.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -91,16 +164,72 @@ func (r *environmentResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.skipReadAfterWrite = providerData.SkipReadAfterWrite
+	r.keepEmptyDescriptions = providerData.KeepEmptyDescriptions
+	r.environmentNames = providerData.EnvironmentNames
+	r.defaultTags = providerData.DefaultTags
+}
+
+// ConfigValidators returns validators that check the configuration as a whole.
+func (r *environmentResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		exclusivePoliciesValidator{},
+	}
+}
+
+// ModifyPlan plans tags_all as the prior tags with the provider's default
+// tags and the configured tags applied, so changes to either show up in the
+// plan while tags set outside this resource are left alone, and rejects a
+// name already planned for a logical environment.
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var name types.String
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !name.IsNull() && !name.IsUnknown() {
+		resp.Diagnostics.Append(r.environmentNames.claim(ctx, r.client, "kosli_environment", name.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var priorTags, priorTagsAll types.Map
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tags"), &priorTags)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tags_all"), &priorTagsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		priorTags = types.MapNull(types.StringType)
+		priorTagsAll = types.MapNull(types.StringType)
+	}
+
+	tagsAll, diags := plannedTagsAll(ctx, r.defaultTags, tags, priorTags, priorTagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -113,6 +242,12 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "environments", data.Name.ValueString()))
+
+	// Keep other operations on the same environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.Name.ValueString())()
+
 	// Create API request
 	createReq := &client.CreateEnvironmentRequest{
 		Name:           data.Name.ValueString(),
@@ -125,17 +260,40 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	if err := r.client.CreateEnvironment(ctx, createReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Environment",
-			fmt.Sprintf("Could not create environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	// Apply tags via the dedicated PATCH endpoint (no prior tags on a new environment)
-	applyTags(ctx, r.client, data.Name.ValueString(), "environment", types.MapNull(types.StringType), data.Tags, &resp.Diagnostics)
+	// Apply tags, including default tags, via the dedicated PATCH endpoint
+	// (no prior tags on a new environment)
+	applyTags(ctx, r.client, data.Name.ValueString(), "environment", types.MapNull(types.StringType), data.TagsAll, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Attach the configured policies
+	if err := syncEnvironmentPolicies(ctx, r.client, data.Name.ValueString(), types.SetNull(types.StringType), data.Policies, data.ExclusivePolicies.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Attaching Environment Policies",
+			fmt.Sprintf("Could not attach policies to environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Trust the plan instead of reading the environment back, if configured.
+	// A new environment has not reported any snapshots yet.
+	if r.skipReadAfterWrite {
+		data.Tags = appliedTags(data.Tags)
+		data.TagsAll = appliedTags(data.TagsAll)
+		data.ComplianceStatus = types.StringValue(complianceStatusUnknown)
+		data.CompliantSince = types.Float64Null()
+		data.LastModifiedAt = types.Float64Null()
+		data.LastReportedAt = types.Float64Null()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Per ADR 002: PUT returns "OK", so we must GET to populate state.
 	// On 404, re-assert both the create PUT and the tags PATCH so a parallel
 	// destroy of a sibling resource sharing this name (label rename, see
@@ -145,7 +303,7 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 			if err := r.client.CreateEnvironment(ctx, createReq); err != nil {
 				return err
 			}
-			return applyTagsAsError(ctx, r.client, createReq.Name, "environment", types.MapNull(types.StringType), data.Tags)
+			return applyTagsAsError(ctx, r.client, createReq.Name, "environment", types.MapNull(types.StringType), data.TagsAll)
 		},
 		func(ctx context.Context) (*client.Environment, error) {
 			return r.client.GetEnvironment(ctx, createReq.Name)
@@ -160,10 +318,11 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Map API response to Terraform state
-	mapEnvToState(ctx, env, &data, &resp.Diagnostics)
+	mapEnvToState(ctx, env, r.defaultTags, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ComplianceStatus, data.CompliantSince = environmentCompliance(ctx, r.client, env, &resp.Diagnostics)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -179,21 +338,25 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "environments", data.Name.ValueString()))
+
 	// Get current state from API
 	env, err := r.client.GetEnvironment(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Environment",
-			fmt.Sprintf("Could not read environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to Terraform state
-	mapEnvToState(ctx, env, &data, &resp.Diagnostics)
+	mapEnvToState(ctx, env, r.defaultTags, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ComplianceStatus, data.CompliantSince = environmentCompliance(ctx, r.client, env, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -212,6 +375,12 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "environments", data.Name.ValueString()))
+
+	// Keep other operations on the same environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.Name.ValueString())()
+
 	// Read prior state to compute tag diff
 	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
 	if resp.Diagnostics.HasError() {
@@ -229,32 +398,66 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 	if err := r.client.UpdateEnvironment(ctx, data.Name.ValueString(), updateReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Environment",
-			fmt.Sprintf("Could not update environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	// Apply tag diff via the dedicated PATCH endpoint
-	applyTags(ctx, r.client, data.Name.ValueString(), "environment", oldData.Tags, data.Tags, &resp.Diagnostics)
+	// Apply tag diff via the dedicated PATCH endpoint. State written before
+	// tags_all existed only has tags.
+	oldTags := oldData.TagsAll
+	if oldTags.IsNull() {
+		oldTags = oldData.Tags
+	}
+	applyTags(ctx, r.client, data.Name.ValueString(), "environment", oldTags, data.TagsAll, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Attach the configured policies and detach the removed ones or, if
+	// exclusive, all the others
+	if err := syncEnvironmentPolicies(ctx, r.client, data.Name.ValueString(), oldData.Policies, data.Policies, data.ExclusivePolicies.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Environment Policies",
+			fmt.Sprintf("Could not update the policies of environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Trust the plan instead of reading the environment back, if configured.
+	// Updates do not change compliance or reports, so keep the prior values;
+	// the modification time is only known after the next refresh.
+	if r.skipReadAfterWrite {
+		if data.Tags.IsUnknown() {
+			// Not configured, so the tags are the same as before
+			data.Tags = oldData.Tags
+		}
+		data.Tags = appliedTags(data.Tags)
+		data.TagsAll = appliedTags(data.TagsAll)
+		data.ComplianceStatus = oldData.ComplianceStatus
+		data.CompliantSince = oldData.CompliantSince
+		data.LastModifiedAt = types.Float64Null()
+		data.LastReportedAt = oldData.LastReportedAt
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// GET to populate state
 	env, err := r.client.GetEnvironment(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Environment After Update",
-			fmt.Sprintf("Could not read environment %q after update: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read environment %q after update: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to Terraform state
-	mapEnvToState(ctx, env, &data, &resp.Diagnostics)
+	mapEnvToState(ctx, env, r.defaultTags, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ComplianceStatus, data.CompliantSince = environmentCompliance(ctx, r.client, env, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -271,11 +474,14 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	// Keep other operations on the same environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.Name.ValueString())()
+
 	// Archive the environment
 	if err := r.client.ArchiveEnvironment(ctx, data.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Environment",
-			fmt.Sprintf("Could not archive environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not archive environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -290,26 +496,38 @@ func (r *environmentResource) ImportState(ctx context.Context, req resource.Impo
 }
 
 // mapEnvToState maps an API Environment response to the Terraform resource model.
-func mapEnvToState(ctx context.Context, env *client.Environment, data *environmentResourceModel, diags *diag.Diagnostics) {
+// Tags matching defaultTags are only reported in tags_all, unless data.Tags
+// already sets them.
+func mapEnvToState(ctx context.Context, env *client.Environment, defaultTags map[string]string, keepEmptyDescriptions bool, data *environmentResourceModel, diags *diag.Diagnostics) {
 	// Map API response to data source model
 	data.Name = types.StringValue(env.Name)
 	data.Type = types.StringValue(env.Type)
-	// Handle empty description as null to avoid inconsistency when not provided in config
-	if env.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(env.Description)
-	}
+	data.Description = descriptionFromAPI(env.Description, data.Description, keepEmptyDescriptions)
 	data.IncludeScaling = types.BoolValue(env.IncludeScaling)
+	data.LastModifiedAt = types.Float64Value(env.LastModifiedAt)
+	data.LastReportedAt = types.Float64PointerValue(env.LastReportedAt)
 
 	// Normalize nil tags to empty map to prevent drift when tags = {} is set in config.
 	tags := env.Tags
 	if tags == nil {
 		tags = map[string]string{}
 	}
-	tagsValue, d := types.MapValueFrom(ctx, types.StringType, tags)
+	tagsAll, d := types.MapValueFrom(ctx, types.StringType, tags)
 	diags.Append(d...)
-	if !diags.HasError() {
-		data.Tags = tagsValue
+	if diags.HasError() {
+		return
+	}
+	ownTags, d := resourceTagsFromAPI(ctx, tags, defaultTags, data.Tags)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	data.Tags = ownTags
+	data.TagsAll = tagsAll
+
+	// Imported environments have no policy mode yet
+	if data.ExclusivePolicies.IsNull() || data.ExclusivePolicies.IsUnknown() {
+		data.ExclusivePolicies = types.BoolValue(false)
 	}
+	data.Policies = environmentPoliciesFromAPI(ctx, env, data.Policies, data.ExclusivePolicies.ValueBool(), diags)
 }
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"testing"
 
@@ -24,6 +25,9 @@ func TestAccEnvironmentResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "type", "K8S"),
 					resource.TestCheckResourceAttr(resourceName, "include_scaling", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_modified_at"),
+					resource.TestCheckNoResourceAttr(resourceName, "last_reported_at"),
+					resource.TestMatchResourceAttr(resourceName, "app_url", regexp.MustCompile(`/environments/`+regexp.QuoteMeta(rName)+`$`)),
 				),
 			},
 		},
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &environmentSnapshotReportResource{}
+
+// snapshotReportEnvironmentTypes are the environment types that can be
+// reported from a structured list of artifacts.
+var snapshotReportEnvironmentTypes = []string{"K8S", "ECS", "S3", "docker", "server", "lambda"}
+
+// NewEnvironmentSnapshotReportResource creates a new environment snapshot report resource.
+func NewEnvironmentSnapshotReportResource() resource.Resource {
+	return &environmentSnapshotReportResource{}
+}
+
+// environmentSnapshotReportResource defines the resource implementation.
+type environmentSnapshotReportResource struct {
+	client *client.Client
+}
+
+// environmentSnapshotReportResourceModel describes the resource data model.
+type environmentSnapshotReportResourceModel struct {
+	Environment types.String                        `tfsdk:"environment"`
+	Type        types.String                        `tfsdk:"type"`
+	Artifacts   []environmentSnapshotReportArtifact `tfsdk:"artifacts"`
+}
+
+// environmentSnapshotReportArtifact describes one reported running artifact.
+type environmentSnapshotReportArtifact struct {
+	Name        types.String `tfsdk:"name"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Digests     types.Map    `tfsdk:"digests"`
+}
+
+// Metadata returns the resource type name.
+func (r *environmentSnapshotReportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_snapshot_report"
+}
+
+// Schema defines the schema for the resource.
+func (r *environmentSnapshotReportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports a snapshot of an environment from a structured list of running artifacts, so data read from other providers (e.g. the pods of a Kubernetes deployment or the tasks of an ECS service) can be reported to Kosli during apply. " +
+			"A snapshot is reported when the resource is created and whenever `artifacts` change.\n\n" +
+			"-> **Note:** For `server`, `S3` and `lambda` environments whose artifacts each have a single fingerprint, `kosli_server_environment_report` is simpler.\n\n" +
+			"~> **Note:** Destroying the resource stops Terraform from reporting the environment; the snapshots already reported are kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Name of the environment to report. Changing this will force recreation of the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the environment. Valid values: `K8S`, `ECS`, `S3`, `docker`, `server`, `lambda`. Changing this will force recreation of the resource.",
+				Required:            true,
+				Validators: []validator.String{
+					reportEnvironmentTypeValidator{allowed: snapshotReportEnvironmentTypes},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"artifacts": schema.ListNestedAttribute{
+				MarkdownDescription: "The artifacts running in the environment. An empty list reports an empty environment.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the running artifact: the pod name for `K8S`, the task ARN for `ECS`, the container name for `docker`, the function name for `lambda`, the file path for `server` or the object key for `S3`.",
+							Required:            true,
+						},
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: "SHA256 fingerprint of the artifact itself. At least one of `fingerprint` and `digests` must be set.",
+							Optional:            true,
+						},
+						"digests": schema.MapAttribute{
+							MarkdownDescription: "SHA256 digests of what runs as part of the artifact, keyed by name, e.g. the digests of the container images of a pod keyed by image. At least one of `fingerprint` and `digests` must be set.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *environmentSnapshotReportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KosliResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create reports the first snapshot and sets the Terraform state.
+func (r *environmentSnapshotReportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data environmentSnapshotReportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.report(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the Terraform state: what was reported does not change, and
+// reports by others are not drift of this resource.
+func (r *environmentSnapshotReportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The response state already holds the prior state
+}
+
+// Update reports a new snapshot with the changed artifacts.
+func (r *environmentSnapshotReportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data environmentSnapshotReportResourceModel
+
+	// Read Terraform plan data (desired state) into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.report(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from the Terraform state. Reported snapshots
+// are part of the environment's history and are kept.
+func (r *environmentSnapshotReportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// State is automatically removed by the framework
+}
+
+// report reports a snapshot of data's artifacts.
+func (r *environmentSnapshotReportResource) report(ctx context.Context, data *environmentSnapshotReportResourceModel) diag.Diagnostics {
+	artifacts, diags := reportedArtifacts(ctx, data.Artifacts)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := r.client.ReportEnvironment(ctx, data.Environment.ValueString(), data.Type.ValueString(), artifacts); err != nil {
+		diags.AddError(
+			"Error Reporting Environment",
+			fmt.Sprintf("Could not report a snapshot of environment %q: %s", data.Environment.ValueString(), apiErrorDetail(err)),
+		)
+	}
+	return diags
+}
+
+// reportedArtifacts converts the configured artifacts to their client
+// format. Every artifact needs a fingerprint or digests to be reported.
+func reportedArtifacts(ctx context.Context, artifacts []environmentSnapshotReportArtifact) ([]client.ReportedArtifact, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	result := make([]client.ReportedArtifact, 0, len(artifacts))
+	for i, a := range artifacts {
+		artifact := client.ReportedArtifact{
+			Name:        a.Name.ValueString(),
+			Fingerprint: a.Fingerprint.ValueString(),
+		}
+		if !a.Digests.IsNull() {
+			diags.Append(a.Digests.ElementsAs(ctx, &artifact.Digests, false)...)
+		}
+
+		if artifact.Fingerprint == "" && len(artifact.Digests) == 0 {
+			diags.AddAttributeError(
+				path.Root("artifacts").AtListIndex(i),
+				"Missing Artifact Fingerprint",
+				fmt.Sprintf("Artifact %q needs a fingerprint or at least one digest to be reported.", artifact.Name),
+			)
+			continue
+		}
+		result = append(result, artifact)
+	}
+	return result, diags
+}
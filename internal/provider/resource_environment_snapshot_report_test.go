@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnvironmentSnapshotReportResource_Metadata(t *testing.T) {
+	r := &environmentSnapshotReportResource{}
+
+	req := resource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_environment_snapshot_report" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_environment_snapshot_report", resp.TypeName)
+	}
+}
+
+func TestEnvironmentSnapshotReportResource_Schema(t *testing.T) {
+	r := &environmentSnapshotReportResource{}
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	attrs := resp.Schema.Attributes
+	for _, name := range []string{"environment", "type", "artifacts"} {
+		if attr, exists := attrs[name]; !exists || !attr.IsRequired() {
+			t.Errorf("Expected attribute %q to be required", name)
+		}
+	}
+}
+
+func TestEnvironmentSnapshotReportResource_Configure(t *testing.T) {
+	r := &environmentSnapshotReportResource{}
+
+	req := resource.ConfigureRequest{ProviderData: nil}
+	resp := &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = resource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
+
+func TestReportedArtifacts(t *testing.T) {
+	digests := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"nginx:1.27": types.StringValue("sha256-nginx"),
+	})
+
+	artifacts, diags := reportedArtifacts(context.TODO(), []environmentSnapshotReportArtifact{
+		{Name: types.StringValue("web-0"), Fingerprint: types.StringNull(), Digests: digests},
+		{Name: types.StringValue("app.jar"), Fingerprint: types.StringValue("sha256-app"), Digests: types.MapNull(types.StringType)},
+	})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts, got %d", len(artifacts))
+	}
+	if artifacts[0].Digests["nginx:1.27"] != "sha256-nginx" || artifacts[0].Fingerprint != "" {
+		t.Errorf("Expected the digests of web-0, got %+v", artifacts[0])
+	}
+	if artifacts[1].Fingerprint != "sha256-app" || artifacts[1].Digests != nil {
+		t.Errorf("Expected the fingerprint of app.jar, got %+v", artifacts[1])
+	}
+}
+
+func TestReportedArtifacts_MissingFingerprint(t *testing.T) {
+	_, diags := reportedArtifacts(context.TODO(), []environmentSnapshotReportArtifact{
+		{Name: types.StringValue("app.jar"), Fingerprint: types.StringValue("sha256-app"), Digests: types.MapNull(types.StringType)},
+		{Name: types.StringValue("web-0"), Fingerprint: types.StringNull(), Digests: types.MapValueMust(types.StringType, map[string]attr.Value{})},
+	})
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("Expected 1 error, got %v", diags)
+	}
+	if d, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("artifacts").AtListIndex(1)) {
+		t.Errorf("Expected the error on artifacts[1], got %v", diags.Errors()[0])
+	}
+}
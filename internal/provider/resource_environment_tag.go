@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &environmentTagResource{}
+var _ resource.ResourceWithImportState = &environmentTagResource{}
+
+// NewEnvironmentTagResource creates a new environment tag resource.
+func NewEnvironmentTagResource() resource.Resource {
+	return &environmentTagResource{}
+}
+
+// environmentTagResource defines the resource implementation.
+type environmentTagResource struct {
+	client *client.Client
+}
+
+// environmentTagResourceModel describes the resource data model.
+type environmentTagResourceModel struct {
+	Environment types.String `tfsdk:"environment"`
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+}
+
+// Metadata returns the resource type name.
+func (r *environmentTagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_tag"
+}
+
+// Schema defines the schema for the resource.
+func (r *environmentTagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single tag of a Kosli environment (physical or logical), so different modules can contribute individual tags to a shared environment without owning its whole `tags` map. " +
+			"Other tags of the environment are left untouched.\n\n" +
+			"~> **Important:** Leave `tags` unset on a `kosli_environment` or `kosli_logical_environment` whose tags are managed with this resource. While `tags` is unset, the environment leaves tags it did not set alone; once it is set, the environment removes the tags it does not list.",
+
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Name of the environment to tag. Changing this will force recreation of the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Tag key. Changing this will force recreation of the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Tag value.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *environmentTagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KosliResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create sets the tag on the environment.
+func (r *environmentTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data environmentTagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetEnvironmentTag(ctx, data.Environment.ValueString(), data.Key.ValueString(), data.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Environment Tag",
+			fmt.Sprintf("Could not tag environment %q with %q: %s", data.Environment.ValueString(), data.Key.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the value of the tag.
+func (r *environmentTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data environmentTagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, err := r.client.ListEnvironmentTags(ctx, data.Environment.ValueString())
+	if client.IsNotFound(err) {
+		// The environment is gone, and the tag with it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Environment Tag",
+			fmt.Sprintf("Could not read environment %q: %s", data.Environment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Removed outside Terraform; plan to set it again
+	value, ok := tags[data.Key.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Value = types.StringValue(value)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update sets the new value of the tag.
+func (r *environmentTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data environmentTagResourceModel
+
+	// Read Terraform plan data (desired state) into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetEnvironmentTag(ctx, data.Environment.ValueString(), data.Key.ValueString(), data.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Environment Tag",
+			fmt.Sprintf("Could not update tag %q of environment %q: %s", data.Key.ValueString(), data.Environment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the tag from the environment. The other tags are left in
+// place.
+func (r *environmentTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data environmentTagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEnvironmentTag(ctx, data.Environment.ValueString(), data.Key.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Environment Tag",
+			fmt.Sprintf("Could not remove tag %q from environment %q: %s", data.Key.ValueString(), data.Environment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// State is automatically removed by the framework
+}
+
+// ImportState imports an existing tag by "<environment>/<key>".
+func (r *environmentTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	env, key, ok := strings.Cut(req.ID, "/")
+	if !ok || env == "" || key == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <environment>/<key>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccEnvironmentTagResource_basic tests setting, updating and importing
+// individual tags of one environment
+func TestAccEnvironmentTagResource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test-env")
+	resourceName := "kosli_environment_tag.tier"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Step 1: Two tags contributed separately
+			{
+				Config: testAccEnvironmentTagResourceConfig(rName, "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "environment", rName),
+					resource.TestCheckResourceAttr(resourceName, "key", "tier"),
+					resource.TestCheckResourceAttr(resourceName, "value", "prod"),
+					resource.TestCheckResourceAttr("data.kosli_environment.test", "tags.tier", "prod"),
+					resource.TestCheckResourceAttr("data.kosli_environment.test", "tags.team", "payments"),
+				),
+			},
+			// Step 2: Changing one tag leaves the other alone
+			{
+				Config: testAccEnvironmentTagResourceConfig(rName, "staging"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "staging"),
+					resource.TestCheckResourceAttr("data.kosli_environment.test", "tags.tier", "staging"),
+					resource.TestCheckResourceAttr("data.kosli_environment.test", "tags.team", "payments"),
+				),
+			},
+			// Step 3: Import using "environment/key" format
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        fmt.Sprintf("%s/tier", rName),
+				ImportStateVerifyIdentifierAttribute: "environment",
+			},
+		},
+	})
+}
+
+func testAccEnvironmentTagResourceConfig(name, tier string) string {
+	return fmt.Sprintf(`
+resource "kosli_environment" "test" {
+  name = %[1]q
+  type = "K8S"
+}
+
+resource "kosli_environment_tag" "tier" {
+  environment = kosli_environment.test.name
+  key         = "tier"
+  value       = %[2]q
+}
+
+resource "kosli_environment_tag" "team" {
+  environment = kosli_environment.test.name
+  key         = "team"
+  value       = "payments"
+}
+
+data "kosli_environment" "test" {
+  name = kosli_environment.test.name
+
+  depends_on = [kosli_environment_tag.tier, kosli_environment_tag.team]
+}
+`, name, tier)
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestEnvironmentTagResource_Metadata(t *testing.T) {
+	r := &environmentTagResource{}
+
+	req := resource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_environment_tag" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_environment_tag", resp.TypeName)
+	}
+}
+
+func TestEnvironmentTagResource_Schema(t *testing.T) {
+	r := &environmentTagResource{}
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	for _, name := range []string{"environment", "key", "value"} {
+		if attr, exists := resp.Schema.Attributes[name]; !exists || !attr.IsRequired() {
+			t.Errorf("Expected attribute %q to be required", name)
+		}
+	}
+}
+
+func TestEnvironmentTagResource_Configure(t *testing.T) {
+	r := &environmentTagResource{}
+
+	req := resource.ConfigureRequest{ProviderData: nil}
+	resp := &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = resource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
+
+func TestEnvironmentTagResource_ImportState(t *testing.T) {
+	ctx := context.Background()
+	r := &environmentTagResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		id      string
+		wantEnv string
+		wantKey string
+		wantErr bool
+	}{
+		{"production/tier", "production", "tier", false},
+		{"production/team/owner", "production", "team/owner", false},
+		{"production", "", "", true},
+		{"/tier", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			var env, key types.String
+			resp.State.GetAttribute(ctx, path.Root("environment"), &env)
+			resp.State.GetAttribute(ctx, path.Root("key"), &key)
+			if env.ValueString() != tt.wantEnv || key.ValueString() != tt.wantKey {
+				t.Errorf("Expected %q/%q, got %q/%q", tt.wantEnv, tt.wantKey, env.ValueString(), key.ValueString())
+			}
+		})
+	}
+}
+
+// TestEnvironmentTagResource_WithEnvironment checks that a kosli_environment
+// without tags neither plans nor applies the removal of a tag set by a
+// kosli_environment_tag on the same environment.
+func TestEnvironmentTagResource_WithEnvironment(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	apiTags := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/tags/test-org/environment/prod":
+			var payload client.TagResourcePayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Failed to decode tag payload: %v", err)
+			}
+			for k, v := range payload.SetTags {
+				apiTags[k] = v
+			}
+			for _, k := range payload.RemoveTags {
+				delete(apiTags, k)
+			}
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/environments/test-org/prod":
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// The tag resource tags the environment
+	tagResource := &environmentTagResource{client: c}
+	var tagSchema resource.SchemaResponse
+	tagResource.Schema(ctx, resource.SchemaRequest{}, &tagSchema)
+	tagPlan := tfsdk.Plan{Schema: tagSchema.Schema}
+	tagPlan.Set(ctx, &environmentTagResourceModel{
+		Environment: types.StringValue("prod"),
+		Key:         types.StringValue("tier"),
+		Value:       types.StringValue("prod"),
+	})
+	tagResp := &resource.CreateResponse{State: tfsdk.State{Schema: tagSchema.Schema}}
+	tagResource.Create(ctx, resource.CreateRequest{Plan: tagPlan}, tagResp)
+	if tagResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors creating the tag: %v", tagResp.Diagnostics)
+	}
+
+	// The environment's refreshed state includes the tag, and its
+	// description is then changed with tags left unset
+	envResource := &environmentResource{client: c, skipReadAfterWrite: true}
+	var envSchema resource.SchemaResponse
+	envResource.Schema(ctx, resource.SchemaRequest{}, &envSchema)
+	tierTag := types.MapValueMust(types.StringType, map[string]attr.Value{"tier": types.StringValue("prod")})
+	prior := environmentResourceModel{
+		Name:              types.StringValue("prod"),
+		Type:              types.StringValue("K8S"),
+		Description:       types.StringValue("old"),
+		IncludeScaling:    types.BoolValue(false),
+		Tags:              tierTag,
+		TagsAll:           tierTag,
+		Policies:          types.SetNull(types.StringType),
+		ExclusivePolicies: types.BoolValue(false),
+		ComplianceStatus:  types.StringNull(),
+		CompliantSince:    types.Float64Null(),
+		LastModifiedAt:    types.Float64Value(1),
+		LastReportedAt:    types.Float64Null(),
+		AppURL:            types.StringNull(),
+	}
+	config := prior
+	config.Description = types.StringValue("new")
+	config.Tags = types.MapNull(types.StringType)
+	config.TagsAll = types.MapNull(types.StringType)
+	planned := config
+	planned.Tags = types.MapUnknown(types.StringType)
+	planned.TagsAll = types.MapUnknown(types.StringType)
+
+	state := tfsdk.State{Schema: envSchema.Schema}
+	state.Set(ctx, &prior)
+	configPlan := tfsdk.Plan{Schema: envSchema.Schema}
+	configPlan.Set(ctx, &config)
+	configValue := tfsdk.Config{Schema: envSchema.Schema, Raw: configPlan.Raw}
+	plan := tfsdk.Plan{Schema: envSchema.Schema}
+	plan.Set(ctx, &planned)
+
+	planResp := &resource.ModifyPlanResponse{Plan: plan}
+	envResource.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: configValue, State: state, Plan: plan}, planResp)
+	if planResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors planning the environment: %v", planResp.Diagnostics)
+	}
+	var tagsAll types.Map
+	planResp.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)
+	if !tagsAll.Equal(tierTag) {
+		t.Errorf("Expected tags_all to keep the tag, got %v", tagsAll)
+	}
+
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: envSchema.Schema}}
+	envResource.Update(ctx, resource.UpdateRequest{Plan: planResp.Plan, State: state, Config: configValue}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors updating the environment: %v", updateResp.Diagnostics)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if apiTags["tier"] != "prod" {
+		t.Errorf("Expected the environment update to leave the tag alone, got %v", apiTags)
+	}
+}
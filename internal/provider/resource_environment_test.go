@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
 func TestEnvironmentResource_Metadata(t *testing.T) {
@@ -40,7 +42,7 @@ func TestEnvironmentResource_Schema(t *testing.T) {
 
 	// Verify required attributes exist
 	attrs := resp.Schema.Attributes
-	requiredAttrs := []string{"name", "type", "description", "include_scaling", "tags"}
+	requiredAttrs := []string{"name", "type", "description", "include_scaling", "tags", "tags_all", "last_modified_at", "last_reported_at", "app_url"}
 	for _, attr := range requiredAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -121,6 +123,31 @@ func TestEnvironmentResource_Configure_WrongType(t *testing.T) {
 	}
 }
 
+func TestEnvironmentResource_Configure_SkipReadAfterWrite(t *testing.T) {
+	r := &environmentResource{}
+	c, err := client.NewClient("token", "org")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	req := resource.ConfigureRequest{
+		ProviderData: &KosliResourceData{Client: c, SkipReadAfterWrite: true},
+	}
+	resp := &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if r.client != c {
+		t.Error("Expected client to be set from provider data")
+	}
+	if !r.skipReadAfterWrite {
+		t.Error("Expected skipReadAfterWrite to be set from provider data")
+	}
+}
+
 func TestEnvironmentResourceModel_Structure(t *testing.T) {
 	// Test that the model can be created with expected fields
 	model := environmentResourceModel{
@@ -215,6 +242,64 @@ func TestEnvironmentResourceModel_WithNullValues(t *testing.T) {
 	}
 }
 
+func TestMapEnvToState_Timestamps(t *testing.T) {
+	reportedAt := 1633123460.5
+	tests := []struct {
+		name           string
+		lastReportedAt *float64
+		wantReported   types.Float64
+	}{
+		{"reported", &reportedAt, types.Float64Value(reportedAt)},
+		{"never reported", nil, types.Float64Null()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &client.Environment{
+				Name:           "production",
+				Type:           "K8S",
+				LastModifiedAt: 1633123456.789,
+				LastReportedAt: tt.lastReportedAt,
+			}
+			var data environmentResourceModel
+			var diags diag.Diagnostics
+
+			mapEnvToState(context.Background(), env, nil, false, &data, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("Unexpected errors: %v", diags)
+			}
+			if !data.LastModifiedAt.Equal(types.Float64Value(1633123456.789)) {
+				t.Errorf("Expected last_modified_at 1633123456.789, got %v", data.LastModifiedAt)
+			}
+			if !data.LastReportedAt.Equal(tt.wantReported) {
+				t.Errorf("Expected last_reported_at %v, got %v", tt.wantReported, data.LastReportedAt)
+			}
+		})
+	}
+}
+
+// TestMapEnvToState_TypeDrift checks that a type changed outside Terraform
+// is refreshed into state, so the RequiresReplace plan modifier on type plans
+// a recreate against the configured type.
+func TestMapEnvToState_TypeDrift(t *testing.T) {
+	data := environmentResourceModel{
+		Name: types.StringValue("production"),
+		Type: types.StringValue("K8S"),
+		Tags: types.MapNull(types.StringType),
+	}
+	var diags diag.Diagnostics
+
+	mapEnvToState(context.Background(), &client.Environment{Name: "production", Type: "ECS"}, nil, false, &data, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("Unexpected errors: %v", diags)
+	}
+	if data.Type.ValueString() != "ECS" {
+		t.Errorf("Expected type 'ECS' from the API, got %v", data.Type)
+	}
+}
+
 func TestNewEnvironmentResource(t *testing.T) {
 	r := NewEnvironmentResource()
 
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
@@ -26,6 +27,15 @@ func NewFlowResource() resource.Resource {
 // flowResource defines the resource implementation.
 type flowResource struct {
 	client *client.Client
+
+	// skipReadAfterWrite trusts planned values after a successful write
+	// instead of reading the object back. See the provider's
+	// skip_read_after_write attribute.
+	skipReadAfterWrite bool
+
+	// keepEmptyDescriptions keeps an explicit empty description in state.
+	// See the provider's keep_empty_descriptions attribute.
+	keepEmptyDescriptions bool
 }
 
 // flowResourceModel describes the resource data model.
@@ -34,6 +44,8 @@ type flowResourceModel struct {
 	Description types.String `tfsdk:"description"`
 	Template    types.String `tfsdk:"template"`
 	Tags        types.Map    `tfsdk:"tags"`
+
+	DeleteOnDestroy types.Bool `tfsdk:"delete_on_destroy"`
 }
 
 // Metadata returns the resource type name.
@@ -47,7 +59,7 @@ func (r *flowResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		MarkdownDescription: "Manages a Kosli flow. A Kosli Flow represents a business or software process that requires change tracking. It allows you to monitor changes across all steps within a process or focus specifically on a subset of critical steps.\n\n" +
 			"~> **Note:** The `template` attribute accepts a YAML string defining the flow template structure. " +
 			"You can load it from a file using the `file()` function: `template = file(\"template.yml\")`. " +
-			"Minor YAML formatting differences between what you provide and what the API returns may result in a no-op change being shown in plans.",
+			"Templates are compared as YAML documents, so differences in formatting, comments, quoting or key order between what you provide and what the API returns do not show up in plans.",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -60,13 +72,23 @@ func (r *flowResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the flow. Explains the purpose and context of this pipeline.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					descriptionDiffSuppressor{},
+				},
 			},
 			"template": schema.StringAttribute{
 				MarkdownDescription: "YAML template defining the flow structure (trails, artifacts, attestations). " +
 					"Can be provided as an inline heredoc or loaded from a file using `file()`. " +
+					"The template lists the attestations expected on every trail and the artifacts, with the attestations expected for each of them. " +
 					"If omitted, the flow is created without a template.",
 				Optional: true,
 				Computed: true,
+				Validators: []validator.String{
+					flowTemplateValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					flowTemplateDiffSuppressor{},
+				},
 			},
 			"tags": schema.MapAttribute{
 				MarkdownDescription: "Key-value pairs to tag the flow.",
@@ -74,6 +96,12 @@ func (r *flowResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"delete_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the resource deletes the flow permanently, with its trails, instead of archiving it. " +
+					"The setting must be applied before the resource is destroyed to take effect. " +
+					"Where the Kosli API does not support deleting flows, the flow is archived with a warning. Defaults to `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -84,16 +112,18 @@ func (r *flowResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.skipReadAfterWrite = providerData.SkipReadAfterWrite
+	r.keepEmptyDescriptions = providerData.KeepEmptyDescriptions
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -106,6 +136,9 @@ func (r *flowResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Keep other operations on the same flow from interleaving with this one
+	defer lockObject(ctx, r.client, "flow", data.Name.ValueString())()
+
 	// Build API request
 	createReq := &client.CreateFlowRequest{
 		Name:        data.Name.ValueString(),
@@ -118,7 +151,7 @@ func (r *flowResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if err := r.client.CreateFlow(ctx, createReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Flow",
-			fmt.Sprintf("Could not create flow %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create flow %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -129,6 +162,13 @@ func (r *flowResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Trust the plan instead of reading the flow back, if configured
+	if r.skipReadAfterWrite {
+		data.Tags = appliedTags(data.Tags)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Per ADR 002: PUT returns "OK", so we must GET to populate state.
 	// On 404, re-assert both the create PUT and the tags PATCH so a parallel
 	// destroy of a sibling resource sharing this name (label rename, see
@@ -153,7 +193,7 @@ func (r *flowResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Map API response to Terraform state
-	mapFlowToModel(ctx, flow, &data, &resp.Diagnostics)
+	mapFlowToModel(ctx, flow, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -183,13 +223,13 @@ func (r *flowResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading Flow",
-			fmt.Sprintf("Could not read flow %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read flow %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to Terraform state
-	mapFlowToModel(ctx, flow, &data, &resp.Diagnostics)
+	mapFlowToModel(ctx, flow, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -209,6 +249,9 @@ func (r *flowResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Keep other operations on the same flow from interleaving with this one
+	defer lockObject(ctx, r.client, "flow", data.Name.ValueString())()
+
 	// Read prior state to compute tag diff
 	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
 	if resp.Diagnostics.HasError() {
@@ -227,7 +270,7 @@ func (r *flowResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if err := r.client.CreateFlow(ctx, updateReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Flow",
-			fmt.Sprintf("Could not update flow %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update flow %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -238,18 +281,25 @@ func (r *flowResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Trust the plan instead of reading the flow back, if configured
+	if r.skipReadAfterWrite {
+		data.Tags = appliedTags(data.Tags)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// GET to populate state
 	flow, err := r.client.GetFlow(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Flow After Update",
-			fmt.Sprintf("Could not read flow %q after update: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read flow %q after update: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to Terraform state
-	mapFlowToModel(ctx, flow, &data, &resp.Diagnostics)
+	mapFlowToModel(ctx, flow, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -259,7 +309,8 @@ func (r *flowResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
-// Per the API behavior, this archives the flow (soft delete).
+// Per the API behavior, this archives the flow (soft delete), unless
+// delete_on_destroy is set.
 func (r *flowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data flowResourceModel
 
@@ -269,11 +320,34 @@ func (r *flowResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	// Keep other operations on the same flow from interleaving with this one
+	defer lockObject(ctx, r.client, "flow", data.Name.ValueString())()
+
+	// Delete the flow permanently if configured, falling back to archiving
+	// it where the API does not support that
+	if data.DeleteOnDestroy.ValueBool() {
+		err := r.client.DeleteFlow(ctx, data.Name.ValueString())
+		if err == nil {
+			return
+		}
+		if !client.IsMethodNotAllowed(err) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Flow",
+				fmt.Sprintf("Could not delete flow %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+			)
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Flow Archived Instead of Deleted",
+			fmt.Sprintf("The Kosli API does not support deleting flow %q, so it is archived instead.", data.Name.ValueString()),
+		)
+	}
+
 	// Archive the flow
 	if err := r.client.ArchiveFlow(ctx, data.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Flow",
-			fmt.Sprintf("Could not archive flow %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not archive flow %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -288,22 +362,12 @@ func (r *flowResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 // mapFlowToModel maps a Flow API response to the Terraform resource model.
-func mapFlowToModel(ctx context.Context, flow *client.Flow, data *flowResourceModel, diags *diag.Diagnostics) {
+func mapFlowToModel(ctx context.Context, flow *client.Flow, keepEmptyDescriptions bool, data *flowResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(flow.Name)
 
-	// Handle empty description as null to avoid inconsistency when not provided in config
-	if flow.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(flow.Description)
-	}
+	data.Description = descriptionFromAPI(flow.Description, data.Description, keepEmptyDescriptions)
 
-	// Handle empty template as null
-	if flow.Template == "" {
-		data.Template = types.StringNull()
-	} else {
-		data.Template = types.StringValue(flow.Template)
-	}
+	data.Template = flowTemplateFromAPI(flow.Template, data.Template)
 
 	// Normalize nil tags to empty map to prevent drift when tags = {} is set in config.
 	tags := flow.Tags
@@ -351,3 +351,44 @@ resource "kosli_flow" "test" {
 %[2]s}
 `, name, tagsHCL)
 }
+
+// TestAccFlowResource_deleteOnDestroy tests that a flow with delete_on_destroy
+// is removed on destroy, whether the API deletes or archives it
+func TestAccFlowResource_deleteOnDestroy(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "kosli_flow.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFlowResourceConfigDeleteOnDestroy(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "delete_on_destroy", "true"),
+				),
+			},
+			// Import by name; delete_on_destroy only exists in configuration
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        rName,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"delete_on_destroy"},
+			},
+		},
+	})
+}
+
+// testAccFlowResourceConfigDeleteOnDestroy returns configuration for a flow
+// deleted on destroy
+func testAccFlowResourceConfigDeleteOnDestroy(name string) string {
+	return fmt.Sprintf(`
+resource "kosli_flow" "test" {
+  name              = %[1]q
+  delete_on_destroy = true
+}
+`, name)
+}
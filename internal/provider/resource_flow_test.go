@@ -2,11 +2,16 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
@@ -41,7 +46,7 @@ func TestFlowResource_Schema(t *testing.T) {
 
 	// Verify expected attributes exist
 	attrs := resp.Schema.Attributes
-	expectedAttrs := []string{"name", "description", "template", "tags"}
+	expectedAttrs := []string{"name", "description", "template", "tags", "delete_on_destroy"}
 	for _, attr := range expectedAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -74,6 +79,74 @@ func TestFlowResource_Schema(t *testing.T) {
 	if !tagsAttr.IsComputed() {
 		t.Error("Expected 'tags' attribute to be computed")
 	}
+
+	// Verify delete_on_destroy is optional and not sent to the API
+	deleteAttr := attrs["delete_on_destroy"]
+	if !deleteAttr.IsOptional() || deleteAttr.IsComputed() {
+		t.Error("Expected 'delete_on_destroy' attribute to be optional and not computed")
+	}
+}
+
+func TestFlowResource_Delete(t *testing.T) {
+	tests := []struct {
+		name            string
+		deleteOnDestroy any
+		deleteStatus    int
+		wantRequests    []string
+		wantError       bool
+		wantWarning     bool
+	}{
+		{"archive by default", nil, 0, []string{"PUT /flows/test-org/my-flow/archive"}, false, false},
+		{"delete", true, http.StatusOK, []string{"DELETE /flows/test-org/my-flow"}, false, false},
+		{"delete not supported", true, http.StatusMethodNotAllowed, []string{"DELETE /flows/test-org/my-flow", "PUT /flows/test-org/my-flow/archive"}, false, true},
+		{"delete forbidden", true, http.StatusForbidden, []string{"DELETE /flows/test-org/my-flow"}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(tt.deleteStatus)
+				}
+				w.Write([]byte(`"OK"`))
+			}))
+			defer server.Close()
+			c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""), client.WithHTTPClient(&http.Client{}))
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			ctx := context.Background()
+			r := &flowResource{client: c}
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name":              tftypes.NewValue(tftypes.String, "my-flow"),
+					"description":       tftypes.NewValue(tftypes.String, nil),
+					"template":          tftypes.NewValue(tftypes.String, nil),
+					"tags":              tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"delete_on_destroy": tftypes.NewValue(tftypes.Bool, tt.deleteOnDestroy),
+				}),
+			}
+			resp := &resource.DeleteResponse{State: state}
+
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("Expected warning %v, got %v", tt.wantWarning, resp.Diagnostics.Warnings())
+			}
+			if !slices.Equal(requests, tt.wantRequests) {
+				t.Errorf("Expected requests %v, got %v", tt.wantRequests, requests)
+			}
+		})
+	}
 }
 
 func TestFlowResource_Configure(t *testing.T) {
@@ -271,7 +344,7 @@ func TestMapFlowToModel_Tags(t *testing.T) {
 			var data flowResourceModel
 			var diags diag.Diagnostics
 
-			mapFlowToModel(context.Background(), flow, &data, &diags)
+			mapFlowToModel(context.Background(), flow, false, &data, &diags)
 
 			if diags.HasError() {
 				t.Fatalf("unexpected diagnostics: %v", diags)
@@ -3,21 +3,27 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &logicalEnvironmentResource{}
 var _ resource.ResourceWithImportState = &logicalEnvironmentResource{}
+var _ resource.ResourceWithConfigValidators = &logicalEnvironmentResource{}
+var _ resource.ResourceWithModifyPlan = &logicalEnvironmentResource{}
 
 // NewLogicalEnvironmentResource creates a new logical environment resource.
 func NewLogicalEnvironmentResource() resource.Resource {
@@ -27,6 +33,20 @@ func NewLogicalEnvironmentResource() resource.Resource {
 // logicalEnvironmentResource defines the resource implementation.
 type logicalEnvironmentResource struct {
 	client *client.Client
+
+	// skipReadAfterWrite trusts planned values after a successful write
+	// instead of reading the object back. See the provider's
+	// skip_read_after_write attribute.
+	skipReadAfterWrite bool
+
+	// keepEmptyDescriptions keeps an explicit empty description in state.
+	// See the provider's keep_empty_descriptions attribute.
+	keepEmptyDescriptions bool
+
+	// environmentNames catches a physical and a logical environment planned
+	// with the same name. Shared by the resources of one provider
+	// configuration.
+	environmentNames *environmentNames
 }
 
 // logicalEnvironmentResourceModel describes the resource data model.
@@ -35,7 +55,17 @@ type logicalEnvironmentResourceModel struct {
 	Type                 types.String `tfsdk:"type"`
 	Description          types.String `tfsdk:"description"`
 	IncludedEnvironments types.List   `tfsdk:"included_environments"`
+	MemberSelector       types.Object `tfsdk:"member_selector"`
+	ManageMembership     types.Bool   `tfsdk:"manage_membership"`
 	Tags                 types.Map    `tfsdk:"tags"`
+	Policies             types.Set    `tfsdk:"policies"`
+	ExclusivePolicies    types.Bool   `tfsdk:"exclusive_policies"`
+	AppURL               types.String `tfsdk:"app_url"`
+}
+
+// memberSelectorModel describes the member_selector attribute.
+type memberSelectorModel struct {
+	Tags types.Map `tfsdk:"tags"`
 }
 
 // Metadata returns the resource type name.
@@ -70,11 +100,40 @@ func (r *logicalEnvironmentResource) Schema(ctx context.Context, req resource.Sc
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the logical environment. Explains the purpose and aggregation strategy.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					descriptionDiffSuppressor{},
+				},
 			},
 			"included_environments": schema.ListAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "List of physical environment names to aggregate. Only physical environments are allowed (K8S, ECS, S3, docker, server, lambda). Can be empty.",
-				Required:            true,
+				ElementType: types.StringType,
+				MarkdownDescription: "List of physical environment names to aggregate. Only physical environments are allowed (K8S, ECS, S3, docker, server, lambda). Must not contain the logical environment's own name. Can be empty. " +
+					"Omit it to leave membership to `kosli_logical_environment_membership` resources, or set `manage_membership` to `false` to combine both. " +
+					"Conflicts with `member_selector`, which computes it instead.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"member_selector": schema.SingleNestedAttribute{
+				MarkdownDescription: "Selects the members by their tags instead of listing them in `included_environments`. " +
+					"The physical environments carrying all of the given tags are looked up on every plan, so environments tagged or untagged since the last apply show up as a change to `included_environments`.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						MarkdownDescription: "Tags a physical environment must all carry to be a member, e.g. `{ tier = \"prod\" }`. Must not be empty.",
+						Required:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+			"manage_membership": schema.BoolAttribute{
+				MarkdownDescription: "Whether `included_environments` is the complete membership of the logical environment. " +
+					"If `true` (the default), members that are not listed are removed. If `false`, the listed environments are added and no member is ever removed, so other configurations can contribute members too. " +
+					"A new logical environment always starts with exactly the listed members.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 			"tags": schema.MapAttribute{
 				MarkdownDescription: "Key-value pairs to tag the logical environment.",
@@ -82,26 +141,132 @@ func (r *logicalEnvironmentResource) Schema(ctx context.Context, req resource.Sc
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"policies": schema.SetAttribute{
+				MarkdownDescription: "Names of the policies to attach to the logical environment. Omit it to leave policies to `kosli_policy_attachment` resources.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"exclusive_policies": schema.BoolAttribute{
+				MarkdownDescription: "Whether `policies` are the only policies attached to the logical environment. " +
+					"If `true`, policies attached outside Terraform show up as drift and are detached on the next apply, so Terraform is the single source of truth; " +
+					"do not combine it with `kosli_policy_attachment` resources for the same environment. If `false` (the default), the listed policies are attached, policies removed from `policies` are detached, and policies attached outside Terraform are left alone. Requires `policies`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"app_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the logical environment in the Kosli web UI.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
+// ConfigValidators returns validators that check the configuration as a whole.
+func (r *logicalEnvironmentResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		notSelfIncludedValidator{},
+		memberSelectorValidator{},
+		exclusivePoliciesValidator{},
+	}
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *logicalEnvironmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.skipReadAfterWrite = providerData.SkipReadAfterWrite
+	r.keepEmptyDescriptions = providerData.KeepEmptyDescriptions
+	r.environmentNames = providerData.EnvironmentNames
+}
+
+// ModifyPlan rejects a name already planned for a physical environment, and
+// plans included_environments as the environments matching member_selector.
+func (r *logicalEnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data logicalEnvironmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Name.IsNull() || data.Name.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(r.environmentNames.claim(ctx, r.client, "kosli_logical_environment", data.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve member_selector, unless it is not known yet or the provider is
+	// not configured, e.g. during validation
+	if data.MemberSelector.IsNull() || data.MemberSelector.IsUnknown() || r.client == nil {
+		return
+	}
+	var selector memberSelectorModel
+	resp.Diagnostics.Append(data.MemberSelector.As(ctx, &selector, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if selector.Tags.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("included_environments"), types.ListUnknown(types.StringType))...)
+		return
+	}
+	var tags map[string]string
+	resp.Diagnostics.Append(selector.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := selectLogicalEnvMembers(ctx, r.client, data.Name.ValueString(), tags)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_selector"),
+			"Error Selecting Logical Environment Members",
+			fmt.Sprintf("Could not list the environments matching the member_selector of logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
+	included := logicalEnvIncludedList(ctx, members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("included_environments"), included)...)
+}
 
-	r.client = client
+// selectLogicalEnvMembers returns the names, sorted, of the physical
+// environments carrying all of tags, other than the logical environment
+// named name itself.
+func selectLogicalEnvMembers(ctx context.Context, c *client.Client, name string, tags map[string]string) ([]string, error) {
+	envs, err := c.ListAllEnvironments(ctx, &client.ListEnvironmentsOptions{Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+	for _, env := range envs {
+		if env.Type == "logical" || env.Name == name || !environmentMatches(env, "", tags) {
+			continue
+		}
+		members = append(members, env.Name)
+	}
+	slices.Sort(members)
+	return members, nil
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -114,8 +279,19 @@ func (r *logicalEnvironmentResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	// Extract included_environments from types.List to []string
-	var includedEnvironments []string
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "environments", data.Name.ValueString()))
+
+	// Keep other operations on the same environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.Name.ValueString())()
+
+	// Extract included_environments from types.List to []string. When it is
+	// not configured, membership is managed by membership resources, which
+	// are created after the logical environment, so it starts empty.
+	includedEnvironments := []string{}
+	if data.IncludedEnvironments.IsUnknown() || data.IncludedEnvironments.IsNull() {
+		data.IncludedEnvironments = types.ListValueMust(types.StringType, nil)
+	}
 	resp.Diagnostics.Append(data.IncludedEnvironments.ElementsAs(ctx, &includedEnvironments, false)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -134,7 +310,7 @@ func (r *logicalEnvironmentResource) Create(ctx context.Context, req resource.Cr
 	if err := r.client.CreateEnvironment(ctx, createReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Logical Environment",
-			fmt.Sprintf("Could not create logical environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -154,6 +330,22 @@ func (r *logicalEnvironmentResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	// Attach the configured policies
+	if err := syncEnvironmentPolicies(ctx, r.client, data.Name.ValueString(), types.SetNull(types.StringType), data.Policies, data.ExclusivePolicies.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Attaching Logical Environment Policies",
+			fmt.Sprintf("Could not attach policies to logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Trust the plan instead of reading the logical environment back, if configured
+	if r.skipReadAfterWrite {
+		data.Tags = appliedTags(data.Tags)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Per ADR 002: PUT returns "OK", so we must GET to populate state.
 	// On 404, re-assert both the create PUT and the tags PATCH so a parallel
 	// destroy of a sibling resource sharing this name (label rename, see
@@ -178,7 +370,7 @@ func (r *logicalEnvironmentResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	// Map API response to Terraform state
-	mapLogicalEnvToState(ctx, env, &data, &resp.Diagnostics)
+	mapLogicalEnvToState(ctx, env, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -197,18 +389,31 @@ func (r *logicalEnvironmentResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "environments", data.Name.ValueString()))
+
 	// Get current state from API
 	env, err := r.client.GetEnvironment(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Logical Environment",
-			fmt.Sprintf("Could not read logical environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Resolve members the response omits, so they are not recorded as removed
+	env.IncludedEnvironments, err = logicalEnvMembers(ctx, r.client, env)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Logical Environment",
+			fmt.Sprintf("Could not read the members of logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to Terraform state
-	mapLogicalEnvToState(ctx, env, &data, &resp.Diagnostics)
+	mapLogicalEnvToState(ctx, env, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -230,6 +435,12 @@ func (r *logicalEnvironmentResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	// Link to the page of the object in the Kosli web UI
+	data.AppURL = types.StringValue(r.client.AppURL(ctx, "environments", data.Name.ValueString()))
+
+	// Keep other operations on the same environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.Name.ValueString())()
+
 	// Read prior state to compute tag diff
 	resp.Diagnostics.Append(req.State.Get(ctx, &oldData)...)
 	if resp.Diagnostics.HasError() {
@@ -239,11 +450,43 @@ func (r *logicalEnvironmentResource) Update(ctx context.Context, req resource.Up
 	// Extract included_environments from types.List to []string. Always send a
 	// non-nil slice for logical environments so the field is included in the
 	// PATCH body (an empty list is still a valid logical-environment update).
-	includedEnvironments := []string{}
-	resp.Diagnostics.Append(data.IncludedEnvironments.ElementsAs(ctx, &includedEnvironments, false)...)
+	// When it is not configured, nor selected by member_selector, the planned
+	// value is only the prior state, so it is omitted to leave membership
+	// resources' changes in place.
+	var configIncluded types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("included_environments"), &configIncluded)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	var includedEnvironments []string
+	if !configIncluded.IsNull() || !data.MemberSelector.IsNull() {
+		includedEnvironments = []string{}
+		resp.Diagnostics.Append(data.IncludedEnvironments.ElementsAs(ctx, &includedEnvironments, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// In additive mode, add the configured members to the current ones
+	// instead of replacing them, and leave them out of the PATCH
+	if includedEnvironments != nil && !data.ManageMembership.ValueBool() {
+		err := updateLogicalEnvMembers(ctx, r.client, data.Name.ValueString(), func(members []string) []string {
+			for _, env := range includedEnvironments {
+				if !slices.Contains(members, env) {
+					members = append(members, env)
+				}
+			}
+			return members
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Logical Environment",
+				fmt.Sprintf("Could not add members to logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+			)
+			return
+		}
+		includedEnvironments = nil
+	}
 
 	description := data.Description.ValueString()
 	updateReq := &client.UpdateEnvironmentRequest{
@@ -255,11 +498,17 @@ func (r *logicalEnvironmentResource) Update(ctx context.Context, req resource.Up
 	if err := r.client.UpdateEnvironment(ctx, data.Name.ValueString(), updateReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Logical Environment",
-			fmt.Sprintf("Could not update logical environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
+	// Tags that are not configured are planned as unknown; leave them, and
+	// any set by kosli_environment_tag, alone
+	if data.Tags.IsUnknown() {
+		data.Tags = oldData.Tags
+	}
+
 	// Apply tag diff via the dedicated PATCH endpoint.
 	// Collect separately so errors can be surfaced with the correct resource label.
 	var updateTagDiags diag.Diagnostics
@@ -275,18 +524,35 @@ func (r *logicalEnvironmentResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	// Attach the configured policies and detach the removed ones or, if
+	// exclusive, all the others
+	if err := syncEnvironmentPolicies(ctx, r.client, data.Name.ValueString(), oldData.Policies, data.Policies, data.ExclusivePolicies.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Logical Environment Policies",
+			fmt.Sprintf("Could not update the policies of logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Trust the plan instead of reading the logical environment back, if configured
+	if r.skipReadAfterWrite {
+		data.Tags = appliedTags(data.Tags)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// GET to populate state
 	env, err := r.client.GetEnvironment(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Logical Environment After Update",
-			fmt.Sprintf("Could not read logical environment %q after update: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read logical environment %q after update: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
 	// Map API response to Terraform state
-	mapLogicalEnvToState(ctx, env, &data, &resp.Diagnostics)
+	mapLogicalEnvToState(ctx, env, r.keepEmptyDescriptions, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -306,11 +572,14 @@ func (r *logicalEnvironmentResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	// Keep other operations on the same environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.Name.ValueString())()
+
 	// Archive the environment
 	if err := r.client.ArchiveEnvironment(ctx, data.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Logical Environment",
-			fmt.Sprintf("Could not archive logical environment %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not archive logical environment %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -318,30 +587,98 @@ func (r *logicalEnvironmentResource) Delete(ctx context.Context, req resource.De
 	// State is automatically removed by the framework
 }
 
-// ImportState imports an existing resource into Terraform state.
+// ImportState imports an existing logical environment by name. Its members
+// are resolved here, from the latest snapshot if the environment response
+// omits them, so the first plan after an import does not show them as added.
 func (r *logicalEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by name
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	env, err := r.client.GetEnvironment(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Logical Environment",
+			fmt.Sprintf("Could not read logical environment %q: %s", req.ID, apiErrorDetail(err)),
+		)
+		return
+	}
+	if env.Type != "logical" {
+		resp.Diagnostics.AddError(
+			"Error Importing Logical Environment",
+			fmt.Sprintf("Environment %q is a %s environment, not a logical environment. Import it as a kosli_environment instead.", req.ID, env.Type),
+		)
+		return
+	}
+
+	members, err := logicalEnvMembers(ctx, r.client, env)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Logical Environment",
+			fmt.Sprintf("Could not read the members of logical environment %q: %s", req.ID, apiErrorDetail(err)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("included_environments"), logicalEnvIncludedList(ctx, members, &resp.Diagnostics))...)
 }
 
 // mapLogicalEnvToState maps an API Environment response to the logical environment resource model.
-func mapLogicalEnvToState(ctx context.Context, env *client.Environment, data *logicalEnvironmentResourceModel, diags *diag.Diagnostics) {
+func mapLogicalEnvToState(ctx context.Context, env *client.Environment, keepEmptyDescriptions bool, data *logicalEnvironmentResourceModel, diags *diag.Diagnostics) {
 	data.Type = types.StringValue(env.Type)
-	data.Description = logicalEnvDescription(env.Description)
-	data.IncludedEnvironments = logicalEnvIncludedList(ctx, env.IncludedEnvironments, diags)
+	data.Description = descriptionFromAPI(env.Description, data.Description, keepEmptyDescriptions)
+	// Imported logical environments have no mode yet
+	if data.ManageMembership.IsNull() || data.ManageMembership.IsUnknown() {
+		data.ManageMembership = types.BoolValue(true)
+	}
+
+	included := env.IncludedEnvironments
+	if !data.ManageMembership.ValueBool() {
+		included = additiveMembers(ctx, data.IncludedEnvironments, included, diags)
+	}
+	data.IncludedEnvironments = logicalEnvIncludedList(ctx, included, diags)
 	if diags.HasError() {
 		return
 	}
 	data.Tags = logicalEnvTags(ctx, env.Tags, diags)
+
+	// Imported logical environments have no policy mode yet either
+	if data.ExclusivePolicies.IsNull() || data.ExclusivePolicies.IsUnknown() {
+		data.ExclusivePolicies = types.BoolValue(false)
+	}
+	data.Policies = environmentPoliciesFromAPI(ctx, env, data.Policies, data.ExclusivePolicies.ValueBool(), diags)
 }
 
-// logicalEnvDescription converts the API description string to types.String,
-// returning null when the description is empty to match Optional schema behaviour.
-func logicalEnvDescription(desc string) types.String {
-	if desc == "" {
-		return types.StringNull()
+// additiveMembers returns the members of prior, in order, that are still
+// among members. In additive mode the state only tracks the configured
+// members, so members contributed by others do not show up as drift, while
+// configured members removed outside Terraform still do.
+func additiveMembers(ctx context.Context, prior types.List, members []string, diags *diag.Diagnostics) []string {
+	if prior.IsNull() || prior.IsUnknown() {
+		return members
+	}
+
+	var configured []string
+	diags.Append(prior.ElementsAs(ctx, &configured, false)...)
+	return slices.DeleteFunc(configured, func(env string) bool {
+		return !slices.Contains(members, env)
+	})
+}
+
+// logicalEnvMembers returns the environments aggregated by env. Some API
+// responses omit included_environments altogether (as opposed to returning
+// an empty list); in that case the membership is read from the latest
+// snapshot instead. An environment that has never reported has no members.
+func logicalEnvMembers(ctx context.Context, c *client.Client, env *client.Environment) ([]string, error) {
+	if env.IncludedEnvironments != nil {
+		return env.IncludedEnvironments, nil
+	}
+
+	snapshot, err := c.GetLatestSnapshot(ctx, env.Name)
+	if client.IsNotFound(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
-	return types.StringValue(desc)
+	return snapshot.IncludedEnvironments, nil
 }
 
 // logicalEnvIncludedList converts the API included_environments slice to types.List,
@@ -471,3 +471,82 @@ resource "kosli_logical_environment" "test" {
 %[4]s}
 `, name, env1, env2, tagsHCL)
 }
+
+// TestAccLogicalEnvironmentResource_memberSelector tests selecting members by
+// tags, including an environment tagged after the logical environment was
+// created
+func TestAccLogicalEnvironmentResource_memberSelector(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test-logical")
+	envName1 := acctest.RandomWithPrefix("tf-acc-test-env1")
+	envName2 := acctest.RandomWithPrefix("tf-acc-test-env2")
+	tier := acctest.RandomWithPrefix("tier")
+	resourceName := "kosli_logical_environment.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Step 1: Create the physical environments, only the first tagged.
+			// Members are selected at plan time, so they must exist first.
+			{
+				Config: testAccLogicalEnvironmentResourceConfigSelector(rName, envName1, envName2, tier, "other", false),
+			},
+			// Step 2: Select the tagged environment
+			{
+				Config: testAccLogicalEnvironmentResourceConfigSelector(rName, envName1, envName2, tier, "other", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "member_selector.tags.tier", tier),
+					resource.TestCheckResourceAttr(resourceName, "included_environments.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "included_environments.0", envName1),
+				),
+			},
+			// Step 3: Tag the second environment; it joins on the next apply
+			{
+				Config:             testAccLogicalEnvironmentResourceConfigSelector(rName, envName1, envName2, tier, tier, true),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccLogicalEnvironmentResourceConfigSelector(rName, envName1, envName2, tier, tier, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "included_environments.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "included_environments.*", envName2),
+				),
+			},
+		},
+	})
+}
+
+func testAccLogicalEnvironmentResourceConfigSelector(name, env1, env2, tier1, tier2 string, withLogical bool) string {
+	logical := ""
+	if withLogical {
+		logical = fmt.Sprintf(`
+resource "kosli_logical_environment" "test" {
+  name = %[1]q
+
+  member_selector = {
+    tags = {
+      tier = %[2]q
+    }
+  }
+}
+`, name, tier1)
+	}
+
+	return fmt.Sprintf(`
+resource "kosli_environment" "env1" {
+  name = %[1]q
+  type = "K8S"
+  tags = {
+    tier = %[3]q
+  }
+}
+
+resource "kosli_environment" "env2" {
+  name = %[2]q
+  type = "ECS"
+  tags = {
+    tier = %[4]q
+  }
+}
+%[5]s`, env1, env2, tier1, tier2, logical)
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &logicalEnvironmentMembershipResource{}
+var _ resource.ResourceWithImportState = &logicalEnvironmentMembershipResource{}
+
+// NewLogicalEnvironmentMembershipResource creates a new logical environment membership resource.
+func NewLogicalEnvironmentMembershipResource() resource.Resource {
+	return &logicalEnvironmentMembershipResource{}
+}
+
+// logicalEnvironmentMembershipResource defines the resource implementation.
+type logicalEnvironmentMembershipResource struct {
+	client *client.Client
+}
+
+// logicalEnvironmentMembershipResourceModel describes the resource data model.
+type logicalEnvironmentMembershipResourceModel struct {
+	LogicalEnvironment types.String `tfsdk:"logical_environment"`
+	Environment        types.String `tfsdk:"environment"`
+}
+
+// Metadata returns the resource type name.
+func (r *logicalEnvironmentMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_logical_environment_membership"
+}
+
+// Schema defines the schema for the resource.
+func (r *logicalEnvironmentMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a single physical environment to a Kosli logical environment, so different teams or configurations can contribute their environments to a shared logical environment without managing one `included_environments` list.\n\n" +
+			"~> **Important:** Unless its `manage_membership` is `false`, do not set `included_environments` on a `kosli_logical_environment` whose membership is managed with this resource; the two would overwrite each other.",
+
+		Attributes: map[string]schema.Attribute{
+			"logical_environment": schema.StringAttribute{
+				MarkdownDescription: "Name of the logical environment. Changing this will force recreation of the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Name of the physical environment to include. Changing this will force recreation of the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *logicalEnvironmentMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KosliResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create adds the environment to the logical environment.
+func (r *logicalEnvironmentMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data logicalEnvironmentMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep other operations on the logical environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.LogicalEnvironment.ValueString())()
+
+	// Per ADR-004, whether the environment is physical is left to the API
+	err := updateLogicalEnvMembers(ctx, r.client, data.LogicalEnvironment.ValueString(), func(members []string) []string {
+		if slices.Contains(members, data.Environment.ValueString()) {
+			return members
+		}
+		return append(members, data.Environment.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Logical Environment Membership",
+			fmt.Sprintf("Could not add environment %q to logical environment %q: %s", data.Environment.ValueString(), data.LogicalEnvironment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks that the environment is still included in the logical environment.
+func (r *logicalEnvironmentMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data logicalEnvironmentMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env, err := r.client.GetEnvironment(ctx, data.LogicalEnvironment.ValueString())
+	if client.IsNotFound(err) {
+		// The logical environment is gone, and the membership with it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Logical Environment Membership",
+			fmt.Sprintf("Could not read logical environment %q: %s", data.LogicalEnvironment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	members, err := logicalEnvMembers(ctx, r.client, env)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Logical Environment Membership",
+			fmt.Sprintf("Could not read the members of logical environment %q: %s", data.LogicalEnvironment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// Removed outside Terraform; plan to add it again
+	if !slices.Contains(members, data.Environment.ValueString()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called with changes: every attribute forces replacement.
+func (r *logicalEnvironmentMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data logicalEnvironmentMembershipResourceModel
+
+	// Read Terraform plan data (desired state) into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the environment from the logical environment. The other
+// members are left in place.
+func (r *logicalEnvironmentMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data logicalEnvironmentMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep other operations on the logical environment from interleaving with this one
+	defer lockObject(ctx, r.client, "environment", data.LogicalEnvironment.ValueString())()
+
+	err := updateLogicalEnvMembers(ctx, r.client, data.LogicalEnvironment.ValueString(), func(members []string) []string {
+		return slices.DeleteFunc(members, func(m string) bool {
+			return m == data.Environment.ValueString()
+		})
+	})
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Logical Environment Membership",
+			fmt.Sprintf("Could not remove environment %q from logical environment %q: %s", data.Environment.ValueString(), data.LogicalEnvironment.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	// State is automatically removed by the framework
+}
+
+// ImportState imports an existing membership by "<logical_environment>/<environment>".
+func (r *logicalEnvironmentMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	logical, env, ok := strings.Cut(req.ID, "/")
+	if !ok || logical == "" || env == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <logical_environment>/<environment>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("logical_environment"), logical)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+}
+
+// updateLogicalEnvMembers replaces the members of the logical environment
+// named name with the result of change, which receives a copy of the current
+// members. Nothing is sent if the members do not change.
+//
+// The API only replaces the whole included_environments list, so the caller
+// must hold lockObject for the "environment" named name; otherwise a
+// concurrent change to the same logical environment could be lost.
+func updateLogicalEnvMembers(ctx context.Context, c *client.Client, name string, change func(members []string) []string) error {
+	env, err := c.GetEnvironment(ctx, name)
+	if err != nil {
+		return err
+	}
+	if env.Type != "logical" {
+		return fmt.Errorf("environment %q is a %s environment, not a logical environment", name, env.Type)
+	}
+
+	members, err := logicalEnvMembers(ctx, c, env)
+	if err != nil {
+		return err
+	}
+
+	updated := change(slices.Clone(members))
+	if updated == nil {
+		// An empty list is still sent, to remove the last member
+		updated = []string{}
+	}
+	if slices.Equal(updated, members) {
+		return nil
+	}
+
+	return c.UpdateEnvironment(ctx, name, &client.UpdateEnvironmentRequest{
+		IncludedEnvironments: updated,
+	})
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+func TestLogicalEnvironmentMembershipResource_Metadata(t *testing.T) {
+	r := &logicalEnvironmentMembershipResource{}
+
+	req := resource.MetadataRequest{ProviderTypeName: "kosli"}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "kosli_logical_environment_membership" {
+		t.Errorf("Expected TypeName %q, got %q", "kosli_logical_environment_membership", resp.TypeName)
+	}
+}
+
+func TestLogicalEnvironmentMembershipResource_Schema(t *testing.T) {
+	r := &logicalEnvironmentMembershipResource{}
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected non-empty schema description")
+	}
+
+	for _, name := range []string{"logical_environment", "environment"} {
+		if attr, exists := resp.Schema.Attributes[name]; !exists || !attr.IsRequired() {
+			t.Errorf("Expected attribute %q to be required", name)
+		}
+	}
+}
+
+func TestLogicalEnvironmentMembershipResource_Configure(t *testing.T) {
+	r := &logicalEnvironmentMembershipResource{}
+
+	req := resource.ConfigureRequest{ProviderData: nil}
+	resp := &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Expected no errors when provider data is nil")
+	}
+
+	req = resource.ConfigureRequest{ProviderData: "invalid"}
+	resp = &resource.ConfigureResponse{}
+
+	r.Configure(context.TODO(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for invalid provider data")
+	}
+}
+
+// fakeLogicalEnvServer serves a logical environment whose members are
+// replaced by PATCH requests, counting the PATCHes.
+func fakeLogicalEnvServer(t *testing.T, envType string, members []string) (*httptest.Server, func() ([]string, int)) {
+	t.Helper()
+
+	var mu sync.Mutex
+	patches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path != "/environments/test-org/prod-all" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"name": "prod-all", "type": envType, "included_environments": members})
+		case http.MethodPatch:
+			var body struct {
+				IncludedEnvironments []string `json:"included_environments"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.IncludedEnvironments == nil {
+				t.Errorf("Expected included_environments in the PATCH body (err %v)", err)
+			}
+			members = body.IncludedEnvironments
+			patches++
+			w.Write([]byte(`"OK"`))
+		}
+	}))
+
+	return server, func() ([]string, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		return members, patches
+	}
+}
+
+func TestUpdateLogicalEnvMembers(t *testing.T) {
+	server, current := fakeLogicalEnvServer(t, "logical", []string{"prod-k8s"})
+	defer server.Close()
+
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	add := func(env string) func([]string) []string {
+		return func(members []string) []string {
+			if slices.Contains(members, env) {
+				return members
+			}
+			return append(members, env)
+		}
+	}
+
+	// Concurrent additions to the same logical environment must not lose updates
+	var wg sync.WaitGroup
+	for _, env := range []string{"prod-ecs", "prod-lambda", "prod-s3"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer lockObject(ctx, c, "environment", "prod-all")()
+			if err := updateLogicalEnvMembers(ctx, c, "prod-all", add(env)); err != nil {
+				t.Errorf("Expected no error adding %q, got %v", env, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	members, patches := current()
+	slices.Sort(members)
+	if want := []string{"prod-ecs", "prod-k8s", "prod-lambda", "prod-s3"}; !slices.Equal(members, want) {
+		t.Errorf("Expected members %v, got %v", want, members)
+	}
+
+	// Adding an existing member sends nothing
+	if err := updateLogicalEnvMembers(ctx, c, "prod-all", add("prod-k8s")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, after := current(); after != patches {
+		t.Errorf("Expected no PATCH for an unchanged membership, got %d more", after-patches)
+	}
+
+	// Removing the last member sends an empty list
+	err = updateLogicalEnvMembers(ctx, c, "prod-all", func([]string) []string { return nil })
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if members, _ := current(); len(members) != 0 {
+		t.Errorf("Expected no members, got %v", members)
+	}
+
+	// A missing logical environment is reported as not found
+	err = updateLogicalEnvMembers(ctx, c, "missing", add("prod-k8s"))
+	if !client.IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestUpdateLogicalEnvMembers_NotLogical(t *testing.T) {
+	server, current := fakeLogicalEnvServer(t, "K8S", nil)
+	defer server.Close()
+
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithAPIPath(""))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = updateLogicalEnvMembers(context.Background(), c, "prod-all", func(members []string) []string {
+		return append(members, "prod-ecs")
+	})
+	if err == nil {
+		t.Fatal("Expected an error for a physical environment")
+	}
+	if _, patches := current(); patches != 0 {
+		t.Errorf("Expected no PATCH, got %d", patches)
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// scaleMembers is the number of physical environments aggregated by the
+// logical environment of the scale test.
+const scaleMembers = 120
+
+// scaleUpdateBudget is how long updating the members of the logical
+// environment of the scale test may take, including refreshing the
+// physical environments.
+const scaleUpdateBudget = 3 * time.Minute
+
+// testAccPreCheckScale skips the test unless scale tests are enabled. They
+// create over a hundred environments, so they are not run by default.
+func testAccPreCheckScale(t *testing.T) {
+	t.Helper()
+	testAccPreCheck(t)
+	if v := os.Getenv("KOSLI_ACC_SCALE"); v == "" {
+		t.Skip("KOSLI_ACC_SCALE must be set for scale acceptance tests")
+	}
+}
+
+// TestAccLogicalEnvironmentResource_scale tests a logical environment
+// aggregating more than a hundred physical environments: that the requests
+// fit the API's size limits, that membership updates stay fast, and that the
+// configured order of included_environments is kept without a diff
+func TestAccLogicalEnvironmentResource_scale(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test-logical-scale")
+	envPrefix := acctest.RandomWithPrefix("tf-acc-test-scale")
+	resourceName := "kosli_logical_environment.test"
+	member := func(i int) string { return envPrefix + "-" + strconv.Itoa(i) }
+
+	var updateStart time.Time
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckScale(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Step 1: Create with all members, in creation order
+			{
+				Config: testAccLogicalEnvironmentResourceConfigScale(rName, envPrefix, "kosli_environment.member[*].name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "included_environments.#", strconv.Itoa(scaleMembers)),
+					resource.TestCheckResourceAttr(resourceName, "included_environments.0", member(0)),
+					resource.TestCheckResourceAttr(resourceName, "included_environments."+strconv.Itoa(scaleMembers-1), member(scaleMembers-1)),
+				),
+			},
+			// Step 2: Reverse the order and drop ten members in one update
+			{
+				PreConfig: func() { updateStart = time.Now() },
+				Config: testAccLogicalEnvironmentResourceConfigScale(rName, envPrefix,
+					fmt.Sprintf("slice(reverse(kosli_environment.member[*].name), 0, %d)", scaleMembers-10)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "included_environments.#", strconv.Itoa(scaleMembers-10)),
+					resource.TestCheckResourceAttr(resourceName, "included_environments.0", member(scaleMembers-1)),
+					resource.TestCheckResourceAttr(resourceName, "included_environments."+strconv.Itoa(scaleMembers-11), member(10)),
+					func(*terraform.State) error {
+						if elapsed := time.Since(updateStart); elapsed > scaleUpdateBudget {
+							return fmt.Errorf("updating %d members took %s, more than %s", scaleMembers, elapsed, scaleUpdateBudget)
+						}
+						return nil
+					},
+				),
+			},
+			// Step 3: Import by name and verify state matches
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        rName,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+// testAccLogicalEnvironmentResourceConfigScale returns configuration with
+// scaleMembers physical environments, aggregated in the order given by the
+// members expression
+func testAccLogicalEnvironmentResourceConfigScale(name, envPrefix, members string) string {
+	return fmt.Sprintf(`
+# Create prerequisite physical environments
+resource "kosli_environment" "member" {
+  count = %[3]d
+
+  name = "%[2]s-${count.index}"
+  type = "K8S"
+}
+
+resource "kosli_logical_environment" "test" {
+  name                  = %[1]q
+  included_environments = %[4]s
+}
+`, name, envPrefix, scaleMembers, members)
+}
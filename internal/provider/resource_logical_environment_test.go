@@ -2,10 +2,19 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
 func TestLogicalEnvironmentResource_Metadata(t *testing.T) {
@@ -40,7 +49,7 @@ func TestLogicalEnvironmentResource_Schema(t *testing.T) {
 
 	// Verify required attributes exist
 	attrs := resp.Schema.Attributes
-	requiredAttrs := []string{"name", "type", "description", "included_environments", "tags"}
+	requiredAttrs := []string{"name", "type", "description", "included_environments", "tags", "app_url"}
 	for _, attr := range requiredAttrs {
 		if _, exists := attrs[attr]; !exists {
 			t.Errorf("Expected attribute %q to exist in schema", attr)
@@ -68,10 +77,16 @@ func TestLogicalEnvironmentResource_Schema(t *testing.T) {
 		t.Error("Expected 'description' attribute to be optional")
 	}
 
-	// Verify included_environments is required
+	// Verify included_environments is optional, so membership resources can manage it
 	includedEnvsAttr := attrs["included_environments"]
-	if includedEnvsAttr.IsRequired() == false {
-		t.Error("Expected 'included_environments' attribute to be required")
+	if !includedEnvsAttr.IsOptional() || !includedEnvsAttr.IsComputed() {
+		t.Error("Expected 'included_environments' attribute to be optional and computed")
+	}
+
+	// Verify manage_membership is optional and computed (defaults to authoritative)
+	manageAttr := attrs["manage_membership"]
+	if !manageAttr.IsOptional() || !manageAttr.IsComputed() {
+		t.Error("Expected 'manage_membership' attribute to be optional and computed")
 	}
 
 	// Verify tags is optional and computed
@@ -299,6 +314,111 @@ func TestLogicalEnvironmentResourceModel_EmptyTags(t *testing.T) {
 	}
 }
 
+func TestLogicalEnvMembers(t *testing.T) {
+	snapshotStatus := http.StatusOK
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if snapshotStatus != http.StatusOK {
+			w.WriteHeader(snapshotStatus)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"index": 1, "included_environments": ["prod-k8s", "prod-ecs"]}`))
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	// Membership in the environment response is used as is
+	members, err := logicalEnvMembers(ctx, c, &client.Environment{Name: "prod-all", IncludedEnvironments: []string{}})
+	if err != nil || len(members) != 0 || calls != 0 {
+		t.Errorf("Expected empty members without API calls, got %v (err %v, %d calls)", members, err, calls)
+	}
+
+	// Missing membership falls back to the latest snapshot
+	members, err = logicalEnvMembers(ctx, c, &client.Environment{Name: "prod-all"})
+	if err != nil || len(members) != 2 || members[0] != "prod-k8s" {
+		t.Errorf("Expected members from snapshot, got %v (err %v)", members, err)
+	}
+
+	// No snapshot yet means no members
+	snapshotStatus = http.StatusNotFound
+	members, err = logicalEnvMembers(ctx, c, &client.Environment{Name: "prod-all"})
+	if err != nil || members == nil || len(members) != 0 {
+		t.Errorf("Expected empty members for an environment without snapshots, got %v (err %v)", members, err)
+	}
+}
+
+func TestLogicalEnvironmentResource_ImportState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/snapshots/"):
+			w.Write([]byte(`{"index": 1, "included_environments": ["prod-k8s", "prod-ecs"]}`))
+		case strings.HasSuffix(r.URL.Path, "/prod-all"):
+			// The environment response omits the membership
+			w.Write([]byte(`{"name": "prod-all", "type": "logical"}`))
+		case strings.HasSuffix(r.URL.Path, "/prod-k8s"):
+			w.Write([]byte(`{"name": "prod-k8s", "type": "K8S"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &logicalEnvironmentResource{client: c}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		id          string
+		wantMembers []string
+		wantErr     bool
+	}{
+		{"prod-all", []string{"prod-k8s", "prod-ecs"}, false},
+		{"prod-k8s", nil, true},
+		{"missing", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			var name types.String
+			var members []string
+			resp.State.GetAttribute(ctx, path.Root("name"), &name)
+			resp.State.GetAttribute(ctx, path.Root("included_environments"), &members)
+			if name.ValueString() != tt.id || !slices.Equal(members, tt.wantMembers) {
+				t.Errorf("Expected %q with members %v, got %q with %v", tt.id, tt.wantMembers, name.ValueString(), members)
+			}
+		})
+	}
+}
+
 // Note: Full CRUD operation tests require acceptance testing
 // These tests verify the resource structure and basic configuration,
 // while acceptance tests will verify the full lifecycle against a real API.
@@ -314,3 +434,85 @@ func TestLogicalEnvironmentResourceModel_EmptyTags(t *testing.T) {
 // - The full Terraform lifecycle is exercised
 // - API integration is validated end-to-end
 // - Validation of logical environment constraints is tested
+
+func TestMapLogicalEnvToState_ManageMembership(t *testing.T) {
+	ctx := context.Background()
+	env := &client.Environment{
+		Name:                 "prod-all",
+		Type:                 "logical",
+		IncludedEnvironments: []string{"prod-k8s", "team-b-ecs", "prod-ecs"},
+	}
+
+	tests := []struct {
+		name             string
+		manageMembership types.Bool
+		prior            []string
+		want             []string
+	}{
+		{"authoritative", types.BoolValue(true), []string{"prod-k8s"}, []string{"prod-k8s", "team-b-ecs", "prod-ecs"}},
+		{"imported", types.BoolNull(), nil, []string{"prod-k8s", "team-b-ecs", "prod-ecs"}},
+		{"additive ignores other members", types.BoolValue(false), []string{"prod-ecs", "prod-k8s"}, []string{"prod-ecs", "prod-k8s"}},
+		{"additive drops removed members", types.BoolValue(false), []string{"prod-k8s", "prod-lambda"}, []string{"prod-k8s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := types.ListNull(types.StringType)
+			if tt.prior != nil {
+				prior, _ = types.ListValueFrom(ctx, types.StringType, tt.prior)
+			}
+			data := logicalEnvironmentResourceModel{
+				Name:                 types.StringValue("prod-all"),
+				IncludedEnvironments: prior,
+				ManageMembership:     tt.manageMembership,
+			}
+
+			var diags diag.Diagnostics
+			mapLogicalEnvToState(ctx, env, false, &data, &diags)
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+
+			var got []string
+			data.IncludedEnvironments.ElementsAs(ctx, &got, false)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected included_environments %v, got %v", tt.want, got)
+			}
+			if data.ManageMembership.IsNull() {
+				t.Error("Expected manage_membership to be set")
+			}
+		})
+	}
+}
+
+func TestSelectLogicalEnvMembers(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name": "prod-k8s", "type": "K8S", "tags": {"tier": "prod"}},
+			{"name": "prod-all", "type": "logical", "tags": {"tier": "prod"}},
+			{"name": "prod-ecs", "type": "ECS", "tags": {"tier": "prod", "region": "eu"}},
+			{"name": "staging-k8s", "type": "K8S", "tags": {"tier": "staging"}}
+		]`))
+	}))
+	defer server.Close()
+	c, err := client.NewClient("test-token", "test-org", client.WithBaseURL(server.URL), client.WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	members, err := selectLogicalEnvMembers(context.Background(), c, "prod-all", map[string]string{"tier": "prod"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Logical environments and environments without the tags are left out
+	if !slices.Equal(members, []string{"prod-ecs", "prod-k8s"}) {
+		t.Errorf("Expected [prod-ecs prod-k8s], got %v", members)
+	}
+	if !strings.Contains(query, "tag=tier%3Aprod") {
+		t.Errorf("Expected the tags to be filtered by the API, got query %q", query)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
@@ -27,6 +28,10 @@ func NewPolicyResource() resource.Resource {
 // policyResource defines the resource implementation.
 type policyResource struct {
 	client *client.Client
+
+	// keepEmptyDescriptions keeps an explicit empty description in state.
+	// See the provider's keep_empty_descriptions attribute.
+	keepEmptyDescriptions bool
 }
 
 // policyResourceModel describes the resource data model.
@@ -65,12 +70,19 @@ func (r *policyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the policy.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					descriptionDiffSuppressor{},
+				},
 			},
 			"content": schema.StringAttribute{
 				MarkdownDescription: "YAML content of the policy, conforming to the Kosli policy schema " +
 					"(`_schema: https://docs.kosli.com/schemas/policy/v1`). " +
-					"Supports heredoc syntax for multi-line YAML. Updating this value creates a new policy version.",
+					"Supports heredoc syntax for multi-line YAML. Updating this value creates a new policy version. " +
+					"YAML syntax errors, unknown keys and values of the wrong type are reported at plan time with their line and column.",
 				Required: true,
+				Validators: []validator.String{
+					policyDocumentValidator{},
+				},
 			},
 			"latest_version": schema.Int64Attribute{
 				MarkdownDescription: "The version number of the latest policy version. Null if the policy has no versions.",
@@ -93,16 +105,17 @@ func (r *policyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = providerData.Client
+	r.keepEmptyDescriptions = providerData.KeepEmptyDescriptions
 }
 
 // ModifyPlan marks latest_version as unknown when content is changing so Terraform
@@ -143,7 +156,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 	if err := r.client.CreatePolicy(ctx, createReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Policy",
-			fmt.Sprintf("Could not create policy %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not create policy %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -153,12 +166,12 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Policy After Creation",
-			fmt.Sprintf("Could not read policy %q after creation: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read policy %q after creation: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	mapPolicyToModel(policy, &data)
+	mapPolicyToModel(policy, r.keepEmptyDescriptions, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -179,12 +192,12 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading Policy",
-			fmt.Sprintf("Could not read policy %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read policy %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	mapPolicyToModel(policy, &data)
+	mapPolicyToModel(policy, r.keepEmptyDescriptions, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -206,7 +219,7 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if err := r.client.CreatePolicy(ctx, updateReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Policy",
-			fmt.Sprintf("Could not update policy %q: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update policy %q: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -215,12 +228,12 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Policy After Update",
-			fmt.Sprintf("Could not read policy %q after update: %s", data.Name.ValueString(), err.Error()),
+			fmt.Sprintf("Could not read policy %q after update: %s", data.Name.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
 
-	mapPolicyToModel(policy, &data)
+	mapPolicyToModel(policy, r.keepEmptyDescriptions, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -253,14 +266,10 @@ func latestPolicyVersion(versions []client.PolicyVersion) (client.PolicyVersion,
 }
 
 // mapPolicyToModel maps an API Policy response to the resource model.
-func mapPolicyToModel(policy *client.Policy, data *policyResourceModel) {
+func mapPolicyToModel(policy *client.Policy, keepEmptyDescriptions bool, data *policyResourceModel) {
 	data.Name = types.StringValue(policy.Name)
 
-	if policy.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(policy.Description)
-	}
+	data.Description = descriptionFromAPI(policy.Description, data.Description, keepEmptyDescriptions)
 
 	data.CreatedAt = types.Float64Value(policy.CreatedAt)
 
@@ -73,16 +73,16 @@ func (r *policyAttachmentResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*KosliResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = providerData.Client
 }
 
 // Create attaches the policy to the environment.
@@ -100,7 +100,7 @@ func (r *policyAttachmentResource) Create(ctx context.Context, req resource.Crea
 	if err := r.client.AttachPolicy(ctx, environmentName, policyName); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Attaching Policy",
-			fmt.Sprintf("Could not attach policy %q to environment %q: %s", policyName, environmentName, err.Error()),
+			fmt.Sprintf("Could not attach policy %q to environment %q: %s", policyName, environmentName, apiErrorDetail(err)),
 		)
 		return
 	}
@@ -129,7 +129,7 @@ func (r *policyAttachmentResource) Read(ctx context.Context, req resource.ReadRe
 		}
 		resp.Diagnostics.AddError(
 			"Error Reading Policy Attachment",
-			fmt.Sprintf("Could not read policies for environment %q: %s", environmentName, err.Error()),
+			fmt.Sprintf("Could not read policies for environment %q: %s", environmentName, apiErrorDetail(err)),
 		)
 		return
 	}
@@ -176,7 +176,7 @@ func (r *policyAttachmentResource) Delete(ctx context.Context, req resource.Dele
 		}
 		resp.Diagnostics.AddError(
 			"Error Detaching Policy",
-			fmt.Sprintf("Could not detach policy %q from environment %q: %s", policyName, environmentName, err.Error()),
+			fmt.Sprintf("Could not detach policy %q from environment %q: %s", policyName, environmentName, apiErrorDetail(err)),
 		)
 		return
 	}
@@ -72,7 +72,7 @@ func TestPolicyAttachmentResource_Configure_ValidClient(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	req := resource.ConfigureRequest{ProviderData: c}
+	req := resource.ConfigureRequest{ProviderData: &KosliResourceData{Client: c}}
 	resp := &resource.ConfigureResponse{}
 	r.Configure(context.TODO(), req, resp)
 
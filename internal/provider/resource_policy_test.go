@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
 )
 
@@ -96,7 +97,7 @@ func TestPolicyResource_Configure_ValidClient(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	req := resource.ConfigureRequest{ProviderData: c}
+	req := resource.ConfigureRequest{ProviderData: &KosliResourceData{Client: c}}
 	resp := &resource.ConfigureResponse{}
 	r.Configure(context.TODO(), req, resp)
 
@@ -130,7 +131,7 @@ func TestMapPolicyToModel_EmptyDescription(t *testing.T) {
 	}
 
 	var data policyResourceModel
-	mapPolicyToModel(policy, &data)
+	mapPolicyToModel(policy, false, &data)
 
 	if !data.Description.IsNull() {
 		t.Errorf("expected description to be null, got %q", data.Description.ValueString())
@@ -143,6 +144,19 @@ func TestMapPolicyToModel_EmptyDescription(t *testing.T) {
 	}
 }
 
+func TestMapPolicyToModel_KeepEmptyDescription(t *testing.T) {
+	policy := &client.Policy{Name: "test-policy", Description: ""}
+
+	for _, keepEmpty := range []bool{true, false} {
+		data := policyResourceModel{Description: types.StringValue("")}
+		mapPolicyToModel(policy, keepEmpty, &data)
+
+		if got := !data.Description.IsNull(); got != keepEmpty {
+			t.Errorf("keep_empty_descriptions = %t: expected description kept = %t, got %v", keepEmpty, keepEmpty, data.Description)
+		}
+	}
+}
+
 func TestMapPolicyToModel_WithDescription(t *testing.T) {
 	policy := &client.Policy{
 		Name:        "test-policy",
@@ -154,7 +168,7 @@ func TestMapPolicyToModel_WithDescription(t *testing.T) {
 	}
 
 	var data policyResourceModel
-	mapPolicyToModel(policy, &data)
+	mapPolicyToModel(policy, false, &data)
 
 	if data.Description.ValueString() != "My policy" {
 		t.Errorf("expected description 'My policy', got %q", data.Description.ValueString())
@@ -175,7 +189,7 @@ func TestMapPolicyToModel_NoVersions(t *testing.T) {
 	}
 
 	var data policyResourceModel
-	mapPolicyToModel(policy, &data)
+	mapPolicyToModel(policy, false, &data)
 
 	// latest_version and content remain zero/null — no panic
 	if data.LatestVersion.ValueInt64() != 0 {
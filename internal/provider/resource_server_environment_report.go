@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &serverEnvironmentReportResource{}
+
+// serverReportEnvironmentTypes are the environment types whose snapshots
+// are a plain list of named artifacts.
+var serverReportEnvironmentTypes = []string{"server", "S3", "lambda"}
+
+// NewServerEnvironmentReportResource creates a new server environment report resource.
+func NewServerEnvironmentReportResource() resource.Resource {
+	return &serverEnvironmentReportResource{}
+}
+
+// serverEnvironmentReportResource defines the resource implementation.
+type serverEnvironmentReportResource struct {
+	client *client.Client
+}
+
+// serverEnvironmentReportResourceModel describes the resource data model.
+type serverEnvironmentReportResourceModel struct {
+	Environment types.String                      `tfsdk:"environment"`
+	Type        types.String                      `tfsdk:"type"`
+	Artifacts   []serverEnvironmentReportArtifact `tfsdk:"artifacts"`
+}
+
+// serverEnvironmentReportArtifact describes one reported artifact.
+type serverEnvironmentReportArtifact struct {
+	Name        types.String `tfsdk:"name"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+// Metadata returns the resource type name.
+func (r *serverEnvironmentReportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_environment_report"
+}
+
+// Schema defines the schema for the resource.
+func (r *serverEnvironmentReportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports a snapshot of the artifacts running in a `server`, `S3` or `lambda` environment, so environments managed purely with Terraform can be reported without installing the Kosli CLI. " +
+			"A snapshot is reported when the resource is created and whenever `artifacts` change.\n\n" +
+			"~> **Note:** Destroying the resource stops Terraform from reporting the environment; the snapshots already reported are kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "Name of the environment to report. Changing this will force recreation of the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the environment. Valid values: `server`, `S3`, `lambda`. Changing this will force recreation of the resource.",
+				Required:            true,
+				Validators: []validator.String{
+					reportEnvironmentTypeValidator{allowed: serverReportEnvironmentTypes},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"artifacts": schema.ListNestedAttribute{
+				MarkdownDescription: "The artifacts running in the environment. An empty list reports an empty environment.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the artifact in the environment: the file path for `server`, the object key for `S3` or the function name for `lambda`.",
+							Required:            true,
+						},
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: "SHA256 fingerprint of the artifact.",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serverEnvironmentReportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*KosliResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.KosliResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// Create reports the first snapshot and sets the Terraform state.
+func (r *serverEnvironmentReportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data serverEnvironmentReportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.report(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the Terraform state: what was reported does not change, and
+// reports by others are not drift of this resource.
+func (r *serverEnvironmentReportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The response state already holds the prior state
+}
+
+// Update reports a new snapshot with the changed artifacts.
+func (r *serverEnvironmentReportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data serverEnvironmentReportResourceModel
+
+	// Read Terraform plan data (desired state) into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.report(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from the Terraform state. Reported snapshots
+// are part of the environment's history and are kept.
+func (r *serverEnvironmentReportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// State is automatically removed by the framework
+}
+
+// report reports a snapshot of data's artifacts.
+func (r *serverEnvironmentReportResource) report(ctx context.Context, data *serverEnvironmentReportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	artifacts := make([]client.ReportedArtifact, 0, len(data.Artifacts))
+	for _, a := range data.Artifacts {
+		artifacts = append(artifacts, client.ReportedArtifact{
+			Name:        a.Name.ValueString(),
+			Fingerprint: a.Fingerprint.ValueString(),
+		})
+	}
+
+	if err := r.client.ReportEnvironment(ctx, data.Environment.ValueString(), data.Type.ValueString(), artifacts); err != nil {
+		diags.AddError(
+			"Error Reporting Environment",
+			fmt.Sprintf("Could not report a snapshot of environment %q: %s", data.Environment.ValueString(), apiErrorDetail(err)),
+		)
+	}
+	return diags
+}
@@ -28,6 +28,12 @@ var retryAfterCreateBackoffs = []time.Duration{
 	4 * time.Second,
 }
 
+// ErrNotYetVisible is returned by retryReadAfterCreateUntil and
+// retryReadAfterUpdate when every read succeeded but returned an object that
+// did not reflect the write yet, e.g. a custom attestation type whose newest
+// version was still being indexed.
+var ErrNotYetVisible = errors.New("read after write kept returning an incomplete or outdated object (the API may still be indexing it)")
+
 // retryReadAfterCreate fetches a resource immediately after Create. If the
 // initial GET returns 404, a sibling resource with the same name is being
 // destroyed in parallel (e.g. a Terraform label rename plans as parallel
@@ -46,7 +52,73 @@ func retryReadAfterCreate[T any](
 	rePut func(context.Context) error,
 	get func(context.Context) (*T, error),
 ) (*T, error) {
-	v, err := get(ctx)
+	return retryReadAfterCreateUntil(ctx, rePut, get, nil)
+}
+
+// retryReadAfterCreateUntil is retryReadAfterCreate for eventually consistent
+// resources: a read whose result visible rejects is retried like a 404, on
+// the same backoff schedule. If the last read is still rejected, the error
+// wraps ErrNotYetVisible. A nil visible accepts every successful read.
+//
+// get is called with a client.WithFreshRead context, so that each attempt
+// reaches the API rather than the client's read cache.
+func retryReadAfterCreateUntil[T any](
+	ctx context.Context,
+	rePut func(context.Context) error,
+	get func(context.Context) (*T, error),
+	visible func(*T) bool,
+) (*T, error) {
+	fresh := func(ctx context.Context) (*T, error) {
+		return get(client.WithFreshRead(ctx))
+	}
+	v, err := retryRead(ctx, rePut, fresh, visible, func(err error) bool {
+		return client.IsNotFound(err) || errors.Is(err, ErrNotYetVisible)
+	})
+	if client.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %w", ErrRenameRace, err)
+	}
+	return v, err
+}
+
+// retryReadAfterUpdate fetches a resource immediately after Update and
+// re-GETs, on the retryReadAfterCreate backoff schedule, while current
+// rejects the result as a stale read of the pre-update object. Errors,
+// including 404, are returned immediately. If the last read is still stale,
+// the error wraps ErrNotYetVisible. As with retryReadAfterCreateUntil, get is
+// called with a client.WithFreshRead context.
+func retryReadAfterUpdate[T any](
+	ctx context.Context,
+	get func(context.Context) (*T, error),
+	current func(*T) bool,
+) (*T, error) {
+	fresh := func(ctx context.Context) (*T, error) {
+		return get(client.WithFreshRead(ctx))
+	}
+	return retryRead(ctx, nil, fresh, current, func(err error) bool {
+		return errors.Is(err, ErrNotYetVisible)
+	})
+}
+
+// retryRead calls get until it returns an object accepted by accept, or an
+// error that retryable rejects, waiting retryAfterCreateBackoffs between
+// attempts and calling rePut, if set, before each retry. A rejected object
+// is reported as ErrNotYetVisible.
+func retryRead[T any](
+	ctx context.Context,
+	rePut func(context.Context) error,
+	get func(context.Context) (*T, error),
+	accept func(*T) bool,
+	retryable func(error) bool,
+) (*T, error) {
+	read := func(ctx context.Context) (*T, error) {
+		v, err := get(ctx)
+		if err == nil && accept != nil && !accept(v) {
+			return nil, ErrNotYetVisible
+		}
+		return v, err
+	}
+
+	v, err := read(ctx)
 	if err == nil {
 		return v, nil
 	}
@@ -55,7 +127,7 @@ func retryReadAfterCreate[T any](
 		if ctxErr := ctx.Err(); ctxErr != nil {
 			return nil, ctxErr
 		}
-		if !client.IsNotFound(err) {
+		if !retryable(err) {
 			return nil, err
 		}
 		select {
@@ -68,15 +140,11 @@ func retryReadAfterCreate[T any](
 				return nil, rePutErr
 			}
 		}
-		v, err = get(ctx)
+		v, err = read(ctx)
 		if err == nil {
 			return v, nil
 		}
 	}
-
-	if client.IsNotFound(err) {
-		return nil, fmt.Errorf("%w: %w", ErrRenameRace, err)
-	}
 	return nil, err
 }
 
@@ -91,7 +159,7 @@ const renameRaceHint = "\n\nThis can happen when a Terraform resource label is r
 // renameRaceDetail formats a "Could not read X after creation" diagnostic
 // detail, appending renameRaceHint only when err is an ErrRenameRace.
 func renameRaceDetail(kind, name string, err error) string {
-	detail := fmt.Sprintf("Could not read %s %q after creation: %s", kind, name, err.Error())
+	detail := fmt.Sprintf("Could not read %s %q after creation: %s", kind, name, apiErrorDetail(err))
 	if errors.Is(err, ErrRenameRace) {
 		detail += renameRaceHint
 	}
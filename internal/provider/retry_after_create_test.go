@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -184,6 +185,165 @@ func TestRetryReadAfterCreate_NilRePutSkipsReassert(t *testing.T) {
 	}
 }
 
+// TestRetryReadAfterCreateUntil_RetriesUntilVisible covers eventually
+// consistent reads, such as a custom attestation type returned before its
+// first version is indexed.
+func TestRetryReadAfterCreateUntil_RetriesUntilVisible(t *testing.T) {
+	withFastBackoffs(t)
+	notFound := &client.APIError{StatusCode: http.StatusNotFound}
+	var gets int
+	v, err := retryReadAfterCreateUntil(context.Background(),
+		nil,
+		func(context.Context) (*int, error) {
+			gets++
+			if gets == 1 {
+				return nil, notFound
+			}
+			return &gets, nil
+		},
+		func(v *int) bool { return *v >= 3 },
+	)
+	if err != nil {
+		t.Fatalf("expected success once visible, got %v", err)
+	}
+	if *v != 3 || gets != 3 {
+		t.Errorf("expected 3 GETs (404, not visible, visible), got %d", gets)
+	}
+}
+
+func TestRetryReadAfterCreateUntil_ExhaustedRetriesNotYetVisible(t *testing.T) {
+	withFastBackoffs(t)
+	var gets int
+	_, err := retryReadAfterCreateUntil(context.Background(),
+		nil,
+		func(context.Context) (*string, error) { gets++; s := "partial"; return &s, nil },
+		func(*string) bool { return false },
+	)
+	if !errors.Is(err, ErrNotYetVisible) {
+		t.Fatalf("expected ErrNotYetVisible, got %v", err)
+	}
+	if errors.Is(err, ErrRenameRace) {
+		t.Errorf("expected no ErrRenameRace wrap for an incomplete read, got %v", err)
+	}
+	if gets != len(retryAfterCreateBackoffs)+1 {
+		t.Errorf("expected %d GETs, got %d", len(retryAfterCreateBackoffs)+1, gets)
+	}
+}
+
+// newReadCacheTestClient returns a client for server with the read cache
+// enabled, as the provider configures it by default.
+func newReadCacheTestClient(t *testing.T, server *httptest.Server) *client.Client {
+	t.Helper()
+	c, err := client.NewClient("test-token", "test-org",
+		client.WithBaseURL(server.URL),
+		client.WithHTTPClient(&http.Client{}),
+		client.WithReadCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}
+
+// TestRetryReadAfterCreateUntil_BypassesReadCache tests that a custom
+// attestation type first read without its versions is read again from the
+// API rather than from the client's read cache.
+func TestRetryReadAfterCreateUntil_BypassesReadCache(t *testing.T) {
+	withFastBackoffs(t)
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.Header().Set("Content-Type", "application/json")
+		if gets == 1 {
+			w.Write([]byte(`{"name": "coverage", "versions": []}`))
+			return
+		}
+		w.Write([]byte(`{"name": "coverage", "versions": [{"version": 1, "type_schema": null}]}`))
+	}))
+	defer server.Close()
+	c := newReadCacheTestClient(t, server)
+
+	at, err := retryReadAfterCreateUntil(context.Background(),
+		nil,
+		func(ctx context.Context) (*client.CustomAttestationType, error) {
+			return c.GetCustomAttestationType(ctx, "coverage", nil)
+		},
+		customAttestationTypeIndexed,
+	)
+	if err != nil {
+		t.Fatalf("expected success once indexed, got %v", err)
+	}
+	if len(at.Versions) != 1 || gets != 2 {
+		t.Errorf("expected the second GET to return the indexed type, got %d versions after %d GETs", len(at.Versions), gets)
+	}
+}
+
+func TestRetryReadAfterUpdate_RetriesStaleRead(t *testing.T) {
+	withFastBackoffs(t)
+	var gets int
+	v, err := retryReadAfterUpdate(context.Background(),
+		func(context.Context) (*int, error) { gets++; return &gets, nil },
+		func(v *int) bool { return *v >= 2 },
+	)
+	if err != nil {
+		t.Fatalf("expected success once current, got %v", err)
+	}
+	if *v != 2 {
+		t.Errorf("expected 2 GETs (stale, current), got %d", gets)
+	}
+}
+
+// TestRetryReadAfterUpdate_BypassesReadCache tests that a stale read of the
+// pre-update custom attestation type is read again from the API rather than
+// from the client's read cache.
+func TestRetryReadAfterUpdate_BypassesReadCache(t *testing.T) {
+	withFastBackoffs(t)
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		description := "updated"
+		if gets == 1 {
+			description = "original"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"name": "coverage", "description": %q, "versions": [{"version": %d, "type_schema": null}]}`, description, gets)
+	}))
+	defer server.Close()
+	c := newReadCacheTestClient(t, server)
+
+	want := &client.CreateCustomAttestationTypeRequest{Name: "coverage", Description: "updated"}
+	at, err := retryReadAfterUpdate(context.Background(),
+		func(ctx context.Context) (*client.CustomAttestationType, error) {
+			return c.GetCustomAttestationType(ctx, "coverage", nil)
+		},
+		func(at *client.CustomAttestationType) bool {
+			return customAttestationTypeMatches(at, want)
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected success once updated, got %v", err)
+	}
+	if at.Description != "updated" || gets != 2 {
+		t.Errorf("expected the second GET to return the updated type, got %q after %d GETs", at.Description, gets)
+	}
+}
+
+func TestRetryReadAfterUpdate_NotFoundReturnsImmediately(t *testing.T) {
+	withFastBackoffs(t)
+	notFound := &client.APIError{StatusCode: http.StatusNotFound}
+	var gets int
+	_, err := retryReadAfterUpdate(context.Background(),
+		func(context.Context) (*string, error) { gets++; return nil, notFound },
+		func(*string) bool { return true },
+	)
+	if !client.IsNotFound(err) || errors.Is(err, ErrRenameRace) {
+		t.Errorf("expected the 404 as-is, got %v", err)
+	}
+	if gets != 1 {
+		t.Errorf("expected 1 GET, got %d", gets)
+	}
+}
+
 func TestRenameRaceDetail_AppendsHintOnlyForRenameRace(t *testing.T) {
 	notFound := &client.APIError{StatusCode: http.StatusNotFound, Message: "archived"}
 	raceErr := errors.Join(ErrRenameRace, notFound)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
@@ -18,6 +19,16 @@ func titleCase(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
+// appliedTags returns the tags a resource has after applyTags was called with
+// planned as the new tags. An unknown or null plan (tags not configured)
+// leaves the resource with no tags, which Read reports as an empty map.
+func appliedTags(planned types.Map) types.Map {
+	if planned.IsNull() || planned.IsUnknown() {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+	return planned
+}
+
 // applyTags computes the tag diff between oldTags and newTags and calls the API
 // PATCH tags endpoint if there are any changes. resourceType is the Kosli API
 // resource type string (e.g. "environment", "flow") and is also used in error messages.
@@ -69,7 +80,121 @@ func applyTags(ctx context.Context, c *client.Client, name, resourceType string,
 	if err := c.TagResource(ctx, resourceType, name, payload); err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error Updating %s Tags", titleCase(resourceType)),
-			fmt.Sprintf("Could not update tags for %s %q: %s", resourceType, name, err.Error()),
+			fmt.Sprintf("Could not update tags for %s %q: %s", resourceType, name, apiErrorDetail(err)),
 		)
 	}
 }
+
+// mergeDefaultTags returns defaults overridden by the tags configured on a
+// resource: the tags the resource ends up with. A null configuration adds no
+// tags of its own; an unknown one makes the result unknown.
+func mergeDefaultTags(ctx context.Context, defaults map[string]string, configured types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if configured.IsUnknown() {
+		return types.MapUnknown(types.StringType), diags
+	}
+
+	merged := make(map[string]string, len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	if !configured.IsNull() {
+		own := map[string]string{}
+		diags.Append(configured.ElementsAs(ctx, &own, false)...)
+		if diags.HasError() {
+			return types.MapUnknown(types.StringType), diags
+		}
+		for k, v := range own {
+			merged[k] = v
+		}
+	}
+
+	result, d := types.MapValueFrom(ctx, types.StringType, merged)
+	diags.Append(d...)
+	return result, diags
+}
+
+// plannedTagsAll returns the tags_all to plan for a resource whose prior
+// state has priorAll in tags_all and priorOwn in tags: the prior tags, less
+// the ones the resource set before and no longer sets, with defaults and
+// configured applied on top. The resource set its default tags, which are
+// the keys of priorAll missing from priorOwn, and, while tags is configured,
+// the keys of priorOwn. Tags added outside the resource, such as by
+// kosli_environment_tag, are kept while tags is not configured. Without
+// prior state, it is the defaults merged with configured.
+func plannedTagsAll(ctx context.Context, defaults map[string]string, configured, priorOwn, priorAll types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// State written before tags_all existed only has tags
+	if priorAll.IsNull() {
+		priorAll = priorOwn
+	}
+	if priorAll.IsNull() || priorAll.IsUnknown() || configured.IsUnknown() {
+		return mergeDefaultTags(ctx, defaults, configured)
+	}
+
+	prior := map[string]string{}
+	diags.Append(priorAll.ElementsAs(ctx, &prior, false)...)
+	own := map[string]string{}
+	if !priorOwn.IsNull() && !priorOwn.IsUnknown() {
+		diags.Append(priorOwn.ElementsAs(ctx, &own, false)...)
+	}
+	configuredTags := map[string]string{}
+	if !configured.IsNull() {
+		diags.Append(configured.ElementsAs(ctx, &configuredTags, false)...)
+	}
+	if diags.HasError() {
+		return types.MapUnknown(types.StringType), diags
+	}
+
+	planned := make(map[string]string, len(prior))
+	for k, v := range prior {
+		_, isOwn := own[k]
+		if !isOwn || !configured.IsNull() {
+			// Set by this resource before; kept only if still set below
+			continue
+		}
+		planned[k] = v
+	}
+	for k, v := range defaults {
+		planned[k] = v
+	}
+	for k, v := range configuredTags {
+		planned[k] = v
+	}
+
+	result, d := types.MapValueFrom(ctx, types.StringType, planned)
+	diags.Append(d...)
+	return result, diags
+}
+
+// resourceTagsFromAPI returns the tags of apiTags that belong to the resource
+// rather than to the provider's default tags. A tag counts as a default tag
+// when it matches a default tag's key and value and the resource's prior tags
+// do not set it themselves; any other tag, including one changed outside
+// Terraform, belongs to the resource.
+func resourceTagsFromAPI(ctx context.Context, apiTags, defaults map[string]string, prior types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	priorTags := map[string]string{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &priorTags, false)...)
+		if diags.HasError() {
+			return types.MapNull(types.StringType), diags
+		}
+	}
+
+	own := map[string]string{}
+	for k, v := range apiTags {
+		_, setOnResource := priorTags[k]
+		if defaultValue, isDefault := defaults[k]; isDefault && defaultValue == v && !setOnResource {
+			continue
+		}
+		own[k] = v
+	}
+
+	result, d := types.MapValueFrom(ctx, types.StringType, own)
+	diags.Append(d...)
+	return result, diags
+}
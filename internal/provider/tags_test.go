@@ -1,6 +1,12 @@
 package provider
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func TestTitleCase(t *testing.T) {
 	tests := []struct {
@@ -19,3 +25,201 @@ func TestTitleCase(t *testing.T) {
 		}
 	}
 }
+
+func TestAppliedTags(t *testing.T) {
+	empty := types.MapValueMust(types.StringType, map[string]attr.Value{})
+	planned := types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("platform")})
+
+	tests := []struct {
+		name    string
+		planned types.Map
+		want    types.Map
+	}{
+		{"unknown", types.MapUnknown(types.StringType), empty},
+		{"null", types.MapNull(types.StringType), empty},
+		{"empty", empty, empty},
+		{"set", planned, planned},
+	}
+	for _, tt := range tests {
+		if got := appliedTags(tt.planned); !got.Equal(tt.want) {
+			t.Errorf("%s: appliedTags() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMergeDefaultTags(t *testing.T) {
+	ctx := context.Background()
+	defaults := map[string]string{"owner": "platform", "cost-centre": "42"}
+
+	tests := []struct {
+		name       string
+		defaults   map[string]string
+		configured types.Map
+		want       types.Map
+	}{
+		{
+			name:       "no defaults",
+			configured: types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("a")}),
+			want:       types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("a")}),
+		},
+		{
+			name:       "null configuration",
+			defaults:   defaults,
+			configured: types.MapNull(types.StringType),
+			want: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"owner":       types.StringValue("platform"),
+				"cost-centre": types.StringValue("42"),
+			}),
+		},
+		{
+			name:       "configured tag wins",
+			defaults:   defaults,
+			configured: types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("payments")}),
+			want: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"owner":       types.StringValue("payments"),
+				"cost-centre": types.StringValue("42"),
+			}),
+		},
+		{
+			name:       "unknown configuration",
+			defaults:   defaults,
+			configured: types.MapUnknown(types.StringType),
+			want:       types.MapUnknown(types.StringType),
+		},
+	}
+	for _, tt := range tests {
+		got, diags := mergeDefaultTags(ctx, tt.defaults, tt.configured)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", tt.name, diags)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: mergeDefaultTags() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlannedTagsAll(t *testing.T) {
+	ctx := context.Background()
+	tagMap := func(tags map[string]string) types.Map {
+		m, _ := types.MapValueFrom(ctx, types.StringType, tags)
+		return m
+	}
+	null := types.MapNull(types.StringType)
+
+	tests := []struct {
+		name       string
+		defaults   map[string]string
+		configured types.Map
+		priorOwn   types.Map
+		priorAll   types.Map
+		want       types.Map
+	}{
+		{
+			name:       "create",
+			defaults:   map[string]string{"owner": "platform"},
+			configured: tagMap(map[string]string{"team": "a"}),
+			priorOwn:   null,
+			priorAll:   null,
+			want:       tagMap(map[string]string{"owner": "platform", "team": "a"}),
+		},
+		{
+			name:       "tags set outside terraform are kept while tags is not configured",
+			configured: null,
+			priorOwn:   tagMap(map[string]string{"tier": "prod"}),
+			priorAll:   tagMap(map[string]string{"tier": "prod"}),
+			want:       tagMap(map[string]string{"tier": "prod"}),
+		},
+		{
+			name:       "removed default tag",
+			configured: null,
+			priorOwn:   tagMap(map[string]string{"tier": "prod"}),
+			priorAll:   tagMap(map[string]string{"tier": "prod", "owner": "platform"}),
+			want:       tagMap(map[string]string{"tier": "prod"}),
+		},
+		{
+			name:       "changed default tag",
+			defaults:   map[string]string{"owner": "payments"},
+			configured: null,
+			priorOwn:   tagMap(map[string]string{}),
+			priorAll:   tagMap(map[string]string{"owner": "platform"}),
+			want:       tagMap(map[string]string{"owner": "payments"}),
+		},
+		{
+			name:       "configured tags replace the prior tags",
+			defaults:   map[string]string{"owner": "platform"},
+			configured: tagMap(map[string]string{"team": "b"}),
+			priorOwn:   tagMap(map[string]string{"team": "a", "tier": "prod"}),
+			priorAll:   tagMap(map[string]string{"team": "a", "tier": "prod", "owner": "platform"}),
+			want:       tagMap(map[string]string{"team": "b", "owner": "platform"}),
+		},
+		{
+			name:       "state from before tags_all",
+			configured: null,
+			priorOwn:   tagMap(map[string]string{"tier": "prod"}),
+			priorAll:   null,
+			want:       tagMap(map[string]string{"tier": "prod"}),
+		},
+		{
+			name:       "unknown configuration",
+			configured: types.MapUnknown(types.StringType),
+			priorOwn:   tagMap(map[string]string{"tier": "prod"}),
+			priorAll:   tagMap(map[string]string{"tier": "prod"}),
+			want:       types.MapUnknown(types.StringType),
+		},
+	}
+	for _, tt := range tests {
+		got, diags := plannedTagsAll(ctx, tt.defaults, tt.configured, tt.priorOwn, tt.priorAll)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", tt.name, diags)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: plannedTagsAll() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResourceTagsFromAPI(t *testing.T) {
+	ctx := context.Background()
+	defaults := map[string]string{"owner": "platform"}
+
+	tests := []struct {
+		name    string
+		apiTags map[string]string
+		prior   types.Map
+		want    types.Map
+	}{
+		{
+			name:    "default tag is omitted",
+			apiTags: map[string]string{"owner": "platform", "team": "a"},
+			prior:   types.MapNull(types.StringType),
+			want:    types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("a")}),
+		},
+		{
+			name:    "default tag changed outside terraform",
+			apiTags: map[string]string{"owner": "someone-else"},
+			prior:   types.MapNull(types.StringType),
+			want:    types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("someone-else")}),
+		},
+		{
+			name:    "default tag also set on the resource",
+			apiTags: map[string]string{"owner": "platform"},
+			prior:   types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("platform")}),
+			want:    types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("platform")}),
+		},
+		{
+			name:    "no tags",
+			apiTags: nil,
+			prior:   types.MapUnknown(types.StringType),
+			want:    types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+	}
+	for _, tt := range tests {
+		got, diags := resourceTagsFromAPI(ctx, tt.apiTags, defaults, tt.prior)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", tt.name, diags)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: resourceTagsFromAPI() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
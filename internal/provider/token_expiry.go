@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// apiTokenExpiry returns when token expires, if it is a JSON Web Token with
+// an exp claim. Opaque tokens carry no expiry the provider can read, and the
+// Kosli API has no endpoint reporting it, so they are never reported as
+// expiring.
+func apiTokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(*claims.Exp), 0).UTC(), true
+}
+
+// tokenExpiryWarning returns a warning when token has expired or expires
+// within window of now, so the token can be rotated before runs using it
+// start failing.
+func tokenExpiryWarning(token string, now time.Time, window time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	expiry, ok := apiTokenExpiry(token)
+	if !ok || window <= 0 || expiry.Sub(now) > window {
+		return diags
+	}
+
+	if !expiry.After(now) {
+		diags.AddWarning(
+			"API Token Expired",
+			fmt.Sprintf("The Kosli API token expired on %s. Requests made with it are rejected with 401 Unauthorized; "+
+				"create a new token and update `api_token` (or the KOSLI_API_TOKEN environment variable).", expiry.Format(time.RFC3339)),
+		)
+		return diags
+	}
+
+	days := int(expiry.Sub(now).Hours() / 24)
+	diags.AddWarning(
+		"API Token Expires Soon",
+		fmt.Sprintf("The Kosli API token expires on %s, in %d day(s). Rotate it before then, or runs using it will fail with 401 Unauthorized. "+
+			"The warning period can be changed with the provider `token_expiry_warning_days` attribute.", expiry.Format(time.RFC3339), days),
+	)
+	return diags
+}
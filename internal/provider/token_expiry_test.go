@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JSON Web Token with the given payload.
+func testJWT(payload string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(payload)) + ".signature"
+}
+
+func TestAPITokenExpiry(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		want   time.Time
+		wantOK bool
+	}{
+		{"jwt with exp", testJWT(`{"sub":"ci","exp":1790000000}`), time.Unix(1790000000, 0).UTC(), true},
+		{"jwt with fractional exp", testJWT(`{"exp":1790000000.5}`), time.Unix(1790000000, 0).UTC(), true},
+		{"jwt without exp", testJWT(`{"sub":"ci"}`), time.Time{}, false},
+		{"opaque token", "a1b2c3d4e5f6", time.Time{}, false},
+		{"three parts, not base64", "a.!!!.c", time.Time{}, false},
+		{"three parts, not JSON", "a." + base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".c", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := apiTokenExpiry(tt.token)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestTokenExpiryWarning(t *testing.T) {
+	now := time.Unix(1790000000, 0)
+	window := 14 * 24 * time.Hour
+	expiringIn := func(d time.Duration) string {
+		return testJWT(`{"exp":` + strconv.FormatInt(now.Add(d).Unix(), 10) + `}`)
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		window      time.Duration
+		wantSummary string // empty when no warning is expected
+	}{
+		{"expires in a week", expiringIn(7 * 24 * time.Hour), window, "API Token Expires Soon"},
+		{"expires in a month", expiringIn(30 * 24 * time.Hour), window, ""},
+		{"expired", expiringIn(-time.Hour), window, "API Token Expired"},
+		{"disabled", expiringIn(7 * 24 * time.Hour), 0, ""},
+		{"opaque token", "a1b2c3d4e5f6", window, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := tokenExpiryWarning(tt.token, now, tt.window)
+			if diags.HasError() {
+				t.Fatalf("Expected no errors, got %v", diags.Errors())
+			}
+			if tt.wantSummary == "" {
+				if len(diags) != 0 {
+					t.Errorf("Expected no warning, got %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Summary() != tt.wantSummary {
+				t.Errorf("Expected warning %q, got %v", tt.wantSummary, diags)
+			}
+		})
+	}
+}
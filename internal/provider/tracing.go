@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerProvider is created once per provider process, on the first
+// Configure call that finds an OTLP endpoint in the environment.
+var (
+	tracerProviderOnce sync.Once
+	tracerProvider     trace.TracerProvider
+	tracerProviderErr  error
+)
+
+// tracingEnabled reports whether the standard OpenTelemetry exporter
+// environment variables ask for traces to be exported.
+func tracingEnabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// tracingOptions returns client options that export a span per API request
+// over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT (or the traces-specific
+// variant) is set. The remaining OTEL_* variables (headers, service name,
+// resource attributes) are honored by the exporter and SDK. If TRACEPARENT is
+// set, as CI tracing integrations commonly do, request spans join that trace.
+func tracingOptions(ctx context.Context, version string) ([]client.ClientOption, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !tracingEnabled() {
+		return nil, diags
+	}
+
+	tracerProviderOnce.Do(func() {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			tracerProviderErr = err
+			return
+		}
+		res, err := sdkresource.Merge(
+			sdkresource.NewSchemaless(
+				attribute.String("service.name", "terraform-provider-kosli"),
+				attribute.String("service.version", version),
+			),
+			sdkresource.Environment(),
+		)
+		if err != nil {
+			tracerProviderErr = err
+			return
+		}
+		// Export synchronously: Terraform stops provider processes without a
+		// shutdown hook, so batched spans could otherwise be lost.
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithSyncer(exporter),
+			sdktrace.WithResource(res),
+		)
+	})
+	if tracerProviderErr != nil {
+		diags.AddWarning(
+			"Unable to Configure Tracing",
+			fmt.Sprintf("OpenTelemetry tracing was requested via OTEL_EXPORTER_OTLP_* environment variables but could not be set up: %s. Continuing without tracing.", tracerProviderErr),
+		)
+		return nil, diags
+	}
+
+	opts := []client.ClientOption{client.WithTracerProvider(tracerProvider)}
+	if traceparent := os.Getenv("TRACEPARENT"); traceparent != "" {
+		carrier := propagation.MapCarrier{"traceparent": traceparent}
+		if trace.SpanContextFromContext(propagation.TraceContext{}.Extract(ctx, carrier)).IsValid() {
+			opts = append(opts, client.WithTraceParent(traceparent))
+		} else {
+			diags.AddWarning(
+				"Ignoring Invalid TRACEPARENT",
+				fmt.Sprintf("The TRACEPARENT environment variable %q is not a valid W3C traceparent header; API request spans will start a new trace.", traceparent),
+			)
+		}
+	}
+	return opts, diags
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTracingOptions_DisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	opts, diags := tracingOptions(context.Background(), "test")
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(opts) != 0 {
+		t.Errorf("expected no options when tracing is disabled, got %d", len(opts))
+	}
+}
+
+func TestTracingOptions_EnabledWithEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:4318")
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	opts, diags := tracingOptions(context.Background(), "test")
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(opts) != 2 {
+		t.Errorf("expected tracer provider and trace parent options, got %d", len(opts))
+	}
+
+	t.Setenv("TRACEPARENT", "not-a-traceparent")
+	opts, diags = tracingOptions(context.Background(), "test")
+	if diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning for an invalid TRACEPARENT, got %v", diags)
+	}
+	if len(opts) != 1 {
+		t.Errorf("expected only the tracer provider option, got %d", len(opts))
+	}
+}
@@ -0,0 +1,456 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// customAttestationTypeNamePattern matches the names the Kosli API accepts
+// for custom attestation types.
+var customAttestationTypeNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*$`)
+
+// customAttestationTypeNameValidator rejects custom attestation type names
+// the API would refuse, so the mistake is reported at plan time rather than
+// as a 400 part-way through an apply.
+type customAttestationTypeNameValidator struct{}
+
+var _ validator.String = customAttestationTypeNameValidator{}
+
+// Description returns a plain text description of the validator.
+func (v customAttestationTypeNameValidator) Description(ctx context.Context) string {
+	return "must start with a letter or number and contain only letters, numbers, periods, hyphens, underscores, and tildes"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v customAttestationTypeNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString reports the first offending character of the name, if any.
+func (v customAttestationTypeNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	name := req.ConfigValue.ValueString()
+	if customAttestationTypeNamePattern.MatchString(name) {
+		return
+	}
+
+	var detail string
+	switch {
+	case name == "":
+		detail = "The name must not be empty."
+	case !customAttestationTypeNamePattern.MatchString(name[:1]):
+		first, _ := utf8.DecodeRuneInString(name)
+		detail = fmt.Sprintf("The name %q starts with %q; it must start with a letter or number.", name, first)
+	default:
+		i := strings.IndexFunc(name, func(r rune) bool {
+			return !customAttestationTypeNamePattern.MatchString("a" + string(r))
+		})
+		bad, _ := utf8.DecodeRuneInString(name[i:])
+		detail = fmt.Sprintf("The name %q contains %q at position %d; only letters, numbers, periods, hyphens, underscores, and tildes are allowed.", name, bad, i+1)
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Custom Attestation Type Name",
+		detail,
+	)
+}
+
+// jqRulesValidator rejects empty, whitespace-only and duplicate jq rules.
+// Each problem is reported against the index of the offending element.
+type jqRulesValidator struct{}
+
+var _ validator.List = jqRulesValidator{}
+
+// Description returns a plain text description of the validator.
+func (v jqRulesValidator) Description(ctx context.Context) string {
+	return "rules must be non-blank and unique"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v jqRulesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateList checks each known element of the list.
+func (v jqRulesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[string]int)
+	for i, elem := range req.ConfigValue.Elements() {
+		rule, ok := elem.(types.String)
+		if !ok || rule.IsNull() || rule.IsUnknown() {
+			continue
+		}
+
+		value := rule.ValueString()
+		elemPath := req.Path.AtListIndex(i)
+		switch {
+		case value == "":
+			resp.Diagnostics.AddAttributeError(elemPath, "Invalid jq Rule",
+				fmt.Sprintf("%s is empty. Remove it or provide a jq expression.", elemPath))
+		case strings.TrimSpace(value) == "":
+			resp.Diagnostics.AddAttributeError(elemPath, "Invalid jq Rule",
+				fmt.Sprintf("%s contains only whitespace. Remove it or provide a jq expression.", elemPath))
+		default:
+			if first, dup := seen[value]; dup {
+				resp.Diagnostics.AddAttributeError(elemPath, "Duplicate jq Rule",
+					fmt.Sprintf("%s duplicates %s (%q). Remove one of them.", elemPath, req.Path.AtListIndex(first), value))
+				continue
+			}
+			seen[value] = i
+		}
+	}
+}
+
+// schemaSizeValidator rejects schemas larger than the API's upload limit,
+// which would otherwise surface as an opaque 413 or 400 during apply.
+type schemaSizeValidator struct {
+	maxBytes int
+}
+
+var _ validator.String = schemaSizeValidator{}
+
+// customAttestationTypeSchemaSizeValidator limits schemas to what the custom
+// attestation type endpoint accepts.
+func customAttestationTypeSchemaSizeValidator() schemaSizeValidator {
+	return schemaSizeValidator{maxBytes: client.MaxCustomAttestationTypeSchemaSize}
+}
+
+// Description returns a plain text description of the validator.
+func (v schemaSizeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("must be at most %s", formatByteSize(v.maxBytes))
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v schemaSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString compares the size of the schema as uploaded with the limit.
+func (v schemaSizeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	size := len(req.ConfigValue.ValueString())
+	if size <= v.maxBytes {
+		return
+	}
+
+	// Fall back to exact byte counts when rounding hides the difference
+	got, limit := formatByteSize(size), formatByteSize(v.maxBytes)
+	if got == limit {
+		got, limit = fmt.Sprintf("%d bytes", size), fmt.Sprintf("%d bytes", v.maxBytes)
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Schema Too Large",
+		fmt.Sprintf("The schema is %s, but the Kosli API accepts at most %s. Reduce the size of the schema, for example by removing descriptions or unused definitions.", got, limit),
+	)
+}
+
+// formatByteSize formats n bytes using the largest binary unit that keeps
+// the value at or above one, e.g. "12MB" or "1.5KB".
+func formatByteSize(n int) string {
+	const unit = 1 << 10
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+
+	value := float64(n)
+	suffix := "B"
+	for _, s := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value /= unit
+		suffix = s
+	}
+	return strings.TrimSuffix(strconv.FormatFloat(value, 'f', 1, 64), ".0") + suffix
+}
+
+// notSelfIncludedValidator rejects a logical environment that lists its own
+// name in included_environments. Only the configuration is inspected; whether
+// the other included environments are physical is left to the API (ADR 004).
+type notSelfIncludedValidator struct{}
+
+var _ resource.ConfigValidator = notSelfIncludedValidator{}
+
+// Description returns a plain text description of the validator.
+func (v notSelfIncludedValidator) Description(ctx context.Context) string {
+	return "included_environments must not contain the logical environment's own name"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v notSelfIncludedValidator) MarkdownDescription(ctx context.Context) string {
+	return "`included_environments` must not contain the logical environment's own name"
+}
+
+// ValidateResource reports each element of included_environments equal to name.
+func (v notSelfIncludedValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name types.String
+	var included types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("included_environments"), &included)...)
+	if resp.Diagnostics.HasError() || name.IsNull() || name.IsUnknown() || included.IsNull() || included.IsUnknown() {
+		return
+	}
+
+	for i, elem := range included.Elements() {
+		env, ok := elem.(types.String)
+		if !ok || env.IsNull() || env.IsUnknown() || env.ValueString() != name.ValueString() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("included_environments").AtListIndex(i),
+			"Logical Environment Includes Itself",
+			fmt.Sprintf("Logical environment %q cannot include itself. Remove %q from included_environments.", name.ValueString(), env.ValueString()),
+		)
+	}
+}
+
+// memberSelectorValidator checks that a logical environment does not set both
+// included_environments and member_selector, and that member_selector has
+// tags to select by: an empty selector would match every environment.
+type memberSelectorValidator struct{}
+
+var _ resource.ConfigValidator = memberSelectorValidator{}
+
+// Description returns a plain text description of the validator.
+func (v memberSelectorValidator) Description(ctx context.Context) string {
+	return "only one of included_environments and member_selector can be set, and member_selector.tags must not be empty"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v memberSelectorValidator) MarkdownDescription(ctx context.Context) string {
+	return "only one of `included_environments` and `member_selector` can be set, and `member_selector.tags` must not be empty"
+}
+
+// ValidateResource checks member_selector against included_environments.
+func (v memberSelectorValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var included types.List
+	var tags types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("included_environments"), &included)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("member_selector").AtName("tags"), &tags)...)
+	if resp.Diagnostics.HasError() || tags.IsNull() {
+		return
+	}
+
+	if !included.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_selector"),
+			"Conflicting Logical Environment Members",
+			"Only one of included_environments and member_selector can be set. Remove included_environments to select the members by their tags.",
+		)
+	}
+	if !tags.IsUnknown() && len(tags.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_selector").AtName("tags"),
+			"Empty Member Selector",
+			"member_selector.tags must contain at least one tag, or every physical environment would be a member.",
+		)
+	}
+}
+
+// exclusivePoliciesValidator checks that an environment with
+// exclusive_policies also sets policies: without them there is nothing to
+// tell the policies attached by Terraform apart from the others.
+type exclusivePoliciesValidator struct{}
+
+var _ resource.ConfigValidator = exclusivePoliciesValidator{}
+
+// Description returns a plain text description of the validator.
+func (v exclusivePoliciesValidator) Description(ctx context.Context) string {
+	return "policies must be set when exclusive_policies is true"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v exclusivePoliciesValidator) MarkdownDescription(ctx context.Context) string {
+	return "`policies` must be set when `exclusive_policies` is `true`"
+}
+
+// ValidateResource checks exclusive_policies against policies.
+func (v exclusivePoliciesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var policies types.Set
+	var exclusive types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("exclusive_policies"), &exclusive)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if exclusive.ValueBool() && policies.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policies"),
+			"Missing Policies",
+			"policies must be set when exclusive_policies is true. Set it to an empty set to detach every policy from the environment.",
+		)
+	}
+}
+
+// webhookURLValidator checks that an action has exactly one of webhook_url
+// and webhook_url_wo, and that webhook_url_wo_version is set together with
+// webhook_url_wo: the version is how later refreshes know to keep the URL
+// out of state.
+type webhookURLValidator struct{}
+
+var _ resource.ConfigValidator = webhookURLValidator{}
+
+// Description returns a plain text description of the validator.
+func (v webhookURLValidator) Description(ctx context.Context) string {
+	return "exactly one of webhook_url and webhook_url_wo must be set, and webhook_url_wo_version must be set with webhook_url_wo"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v webhookURLValidator) MarkdownDescription(ctx context.Context) string {
+	return "exactly one of `webhook_url` and `webhook_url_wo` must be set, and `webhook_url_wo_version` must be set with `webhook_url_wo`"
+}
+
+// ValidateResource checks the webhook URL attributes of an action.
+func (v webhookURLValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var webhookURL, webhookURLWO types.String
+	var version types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("webhook_url"), &webhookURL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("webhook_url_wo"), &webhookURLWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("webhook_url_wo_version"), &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case !webhookURL.IsNull() && !webhookURLWO.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("webhook_url_wo"),
+			"Conflicting Webhook URLs",
+			"Only one of webhook_url and webhook_url_wo can be set. Use webhook_url_wo to keep the webhook URL out of the Terraform state.",
+		)
+	case webhookURL.IsNull() && webhookURLWO.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("webhook_url"),
+			"Missing Webhook URL",
+			"One of webhook_url and webhook_url_wo must be set.",
+		)
+	}
+
+	if webhookURLWO.IsNull() != version.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("webhook_url_wo_version"),
+			"Invalid Webhook URL Version",
+			"webhook_url_wo_version must be set if and only if webhook_url_wo is set. Change the version whenever the write-only webhook URL changes.",
+		)
+	}
+}
+
+// reportEnvironmentTypeValidator checks that an environment type is one
+// of the types a report resource can report.
+type reportEnvironmentTypeValidator struct {
+	allowed []string
+}
+
+var _ validator.String = reportEnvironmentTypeValidator{}
+
+// Description returns a plain text description of the validator.
+func (v reportEnvironmentTypeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.allowed, ", "))
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v reportEnvironmentTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks the configured environment type.
+func (v reportEnvironmentTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(v.allowed, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Environment Type",
+			fmt.Sprintf("Environment type %q cannot be reported with this resource; %s.", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}
+
+// atLeastValidator checks that an integer is at least min.
+type atLeastValidator struct {
+	min int64
+}
+
+var _ validator.Int64 = atLeastValidator{}
+
+// Description returns a plain text description of the validator.
+func (v atLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v atLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateInt64 checks the configured value.
+func (v atLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Got %d, but the %s.", req.ConfigValue.ValueInt64(), v.Description(ctx)),
+		)
+	}
+}
+
+// snapshotSelectorValidator checks that a string uses Kosli's snapshot
+// selector syntax, so typos are reported at plan time.
+type snapshotSelectorValidator struct{}
+
+var _ validator.String = snapshotSelectorValidator{}
+
+// Description returns a plain text description of the validator.
+func (v snapshotSelectorValidator) Description(ctx context.Context) string {
+	return "value must be a snapshot selector: ENV, ENV#N, ENV~N or ENV@{TIMESTAMP}"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v snapshotSelectorValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a snapshot selector: `ENV`, `ENV#N`, `ENV~N` or `ENV@{TIMESTAMP}`"
+}
+
+// ValidateString parses the configured selector.
+func (v snapshotSelectorValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := client.ParseSnapshotSelector(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Snapshot Selector",
+			fmt.Sprintf("%s; the %s.", err, v.Description(ctx)),
+		)
+	}
+}
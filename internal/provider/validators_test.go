@@ -0,0 +1,488 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCustomAttestationTypeNameValidator(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      types.String
+		wantDetail string // empty when the value is valid
+	}{
+		{"valid", types.StringValue("security-scan_v1.2~rc"), ""},
+		{"starts with number", types.StringValue("1st-type"), ""},
+		{"null", types.StringNull(), ""},
+		{"unknown", types.StringUnknown(), ""},
+		{"empty", types.StringValue(""), "must not be empty"},
+		{"leading hyphen", types.StringValue("-scan"), `starts with '-'`},
+		{"space", types.StringValue("security scan"), `contains ' ' at position 9`},
+		{"slash", types.StringValue("team/scan"), `contains '/' at position 5`},
+		{"non-ascii", types.StringValue("scän"), `contains 'ä' at position 3`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("name"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			customAttestationTypeNameValidator{}.ValidateString(context.Background(), req, resp)
+
+			if tt.wantDetail == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("Expected no error, got %v", resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("Expected an error")
+			}
+			if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.wantDetail) {
+				t.Errorf("Expected detail to contain %q, got %q", tt.wantDetail, detail)
+			}
+		})
+	}
+}
+
+func TestJqRulesValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []string
+		wantPaths []string // paths of the reported errors, in order
+	}{
+		{"valid", []string{".a > 0", ".b > 0"}, nil},
+		{"empty", []string{".a > 0", ""}, []string{"jq_rules[1]"}},
+		{"whitespace", []string{"  \t"}, []string{"jq_rules[0]"}},
+		{"duplicate", []string{".a > 0", ".b > 0", ".a > 0"}, []string{"jq_rules[2]"}},
+		{"several", []string{"", ".a", " ", ".a"}, []string{"jq_rules[0]", "jq_rules[2]", "jq_rules[3]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, diags := types.ListValueFrom(context.Background(), types.StringType, tt.rules)
+			if diags.HasError() {
+				t.Fatalf("Failed to build list: %v", diags)
+			}
+			req := validator.ListRequest{Path: path.Root("jq_rules"), ConfigValue: list}
+			resp := &validator.ListResponse{}
+
+			jqRulesValidator{}.ValidateList(context.Background(), req, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, withPath.Path().String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("Expected errors at %v, got %v", tt.wantPaths, got)
+			}
+		})
+	}
+}
+
+func TestJqRulesValidator_NullAndUnknown(t *testing.T) {
+	for _, list := range []types.List{types.ListNull(types.StringType), types.ListUnknown(types.StringType)} {
+		resp := &validator.ListResponse{}
+		jqRulesValidator{}.ValidateList(context.Background(), validator.ListRequest{Path: path.Root("jq_rules"), ConfigValue: list}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("Expected no error for %v, got %v", list, resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestSchemaSizeValidator(t *testing.T) {
+	v := customAttestationTypeSchemaSizeValidator()
+
+	tests := []struct {
+		name       string
+		size       int
+		wantDetail string // empty when the value is valid
+	}{
+		{"small", 100, ""},
+		{"at limit", 10 << 20, ""},
+		{"over limit", 12 << 20, "The schema is 12MB, but the Kosli API accepts at most 10MB"},
+		{"just over limit", 10<<20 + 1, "The schema is 10485761 bytes, but the Kosli API accepts at most 10485760 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("schema"), ConfigValue: types.StringValue(strings.Repeat(" ", tt.size))}
+			resp := &validator.StringResponse{}
+
+			v.ValidateString(context.Background(), req, resp)
+
+			if tt.wantDetail == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("Expected no error, got %v", resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("Expected an error")
+			}
+			if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.wantDetail) {
+				t.Errorf("Expected detail to contain %q, got %q", tt.wantDetail, detail)
+			}
+		})
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int]string{
+		512:            "512B",
+		1536:           "1.5KB",
+		10 << 20:       "10MB",
+		12<<20 + 1<<19: "12.5MB",
+		3 << 30:        "3GB",
+	}
+	for n, want := range tests {
+		if got := formatByteSize(n); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestNotSelfIncludedValidator(t *testing.T) {
+	ctx := context.Background()
+	r := &logicalEnvironmentResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		name      string
+		included  []string
+		wantPaths []string
+	}{
+		{"physical only", []string{"prod-k8s", "prod-ecs"}, nil},
+		{"includes itself", []string{"prod-k8s", "prod-aggregate"}, []string{"included_environments[1]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			included := make([]tftypes.Value, 0, len(tt.included))
+			for _, env := range tt.included {
+				included = append(included, tftypes.NewValue(tftypes.String, env))
+			}
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name":                  tftypes.NewValue(tftypes.String, "prod-aggregate"),
+					"type":                  tftypes.NewValue(tftypes.String, nil),
+					"description":           tftypes.NewValue(tftypes.String, nil),
+					"included_environments": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, included),
+					"member_selector":       tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tags": tftypes.Map{ElementType: tftypes.String}}}, nil),
+					"manage_membership":     tftypes.NewValue(tftypes.Bool, nil),
+					"tags":                  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"policies":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+					"exclusive_policies":    tftypes.NewValue(tftypes.Bool, nil),
+					"app_url":               tftypes.NewValue(tftypes.String, nil),
+				}),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			notSelfIncludedValidator{}.ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, withPath.Path().String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("Expected errors at %v, got %v", tt.wantPaths, got)
+			}
+		})
+	}
+}
+
+func TestMemberSelectorValidator(t *testing.T) {
+	ctx := context.Background()
+	r := &logicalEnvironmentResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	selectorType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tags": tftypes.Map{ElementType: tftypes.String}}}
+	tests := []struct {
+		name      string
+		included  []string
+		tags      map[string]string // nil for no member_selector
+		wantPaths []string
+	}{
+		{"included only", []string{"prod-k8s"}, nil, nil},
+		{"selector only", nil, map[string]string{"tier": "prod"}, nil},
+		{"both", []string{"prod-k8s"}, map[string]string{"tier": "prod"}, []string{"member_selector"}},
+		{"empty selector", nil, map[string]string{}, []string{"member_selector.tags"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			included := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+			if tt.included != nil {
+				elems := make([]tftypes.Value, 0, len(tt.included))
+				for _, env := range tt.included {
+					elems = append(elems, tftypes.NewValue(tftypes.String, env))
+				}
+				included = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+			}
+			selector := tftypes.NewValue(selectorType, nil)
+			if tt.tags != nil {
+				tags := map[string]tftypes.Value{}
+				for key, value := range tt.tags {
+					tags[key] = tftypes.NewValue(tftypes.String, value)
+				}
+				selector = tftypes.NewValue(selectorType, map[string]tftypes.Value{
+					"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tags),
+				})
+			}
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name":                  tftypes.NewValue(tftypes.String, "prod-aggregate"),
+					"type":                  tftypes.NewValue(tftypes.String, nil),
+					"description":           tftypes.NewValue(tftypes.String, nil),
+					"included_environments": included,
+					"member_selector":       selector,
+					"manage_membership":     tftypes.NewValue(tftypes.Bool, nil),
+					"tags":                  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"policies":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+					"exclusive_policies":    tftypes.NewValue(tftypes.Bool, nil),
+					"app_url":               tftypes.NewValue(tftypes.String, nil),
+				}),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			memberSelectorValidator{}.ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, withPath.Path().String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("Expected errors at %v, got %v", tt.wantPaths, got)
+			}
+		})
+	}
+}
+
+func TestWebhookURLValidator(t *testing.T) {
+	ctx := context.Background()
+	r := &actionResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	url := "https://hooks.example.com/kosli"
+	version := int64(1)
+
+	tests := []struct {
+		name         string
+		webhookURL   *string
+		webhookURLWO *string
+		version      *int64
+		wantPaths    []string
+	}{
+		{"webhook_url", &url, nil, nil, nil},
+		{"webhook_url_wo with version", nil, &url, &version, nil},
+		{"neither", nil, nil, nil, []string{"webhook_url"}},
+		{"both", &url, &url, &version, []string{"webhook_url_wo"}},
+		{"webhook_url_wo without version", nil, &url, nil, []string{"webhook_url_wo_version"}},
+		{"version without webhook_url_wo", &url, nil, &version, []string{"webhook_url_wo_version"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name":                   tftypes.NewValue(tftypes.String, "alerts"),
+					"environments":           tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+					"triggers":               tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+					"webhook_url":            tftypes.NewValue(tftypes.String, tt.webhookURL),
+					"webhook_url_wo":         tftypes.NewValue(tftypes.String, tt.webhookURLWO),
+					"webhook_url_wo_version": tftypes.NewValue(tftypes.Number, int64Number(tt.version)),
+					"verify_on_create":       tftypes.NewValue(tftypes.Bool, nil),
+					"number":                 tftypes.NewValue(tftypes.Number, nil),
+					"created_by":             tftypes.NewValue(tftypes.String, nil),
+					"last_modified_at":       tftypes.NewValue(tftypes.Number, nil),
+				}),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			webhookURLValidator{}.ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, withPath.Path().String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("Expected errors at %v, got %v", tt.wantPaths, got)
+			}
+		})
+	}
+}
+
+// int64Number converts an optional int64 into a tftypes.Number value.
+func int64Number(v *int64) *big.Float {
+	if v == nil {
+		return nil
+	}
+	return big.NewFloat(float64(*v))
+}
+
+func TestReportEnvironmentTypeValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"server", types.StringValue("server"), false},
+		{"S3", types.StringValue("S3"), false},
+		{"lambda", types.StringValue("lambda"), false},
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+		{"K8S", types.StringValue("K8S"), true},
+		{"lowercase s3", types.StringValue("s3"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("type"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			reportEnvironmentTypeValidator{allowed: serverReportEnvironmentTypes}.ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("Expected error %v, got %v: %v", tt.wantErr, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestAtLeastValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.Int64
+		wantErr bool
+	}{
+		{"above", types.Int64Value(500), false},
+		{"at minimum", types.Int64Value(1), false},
+		{"null", types.Int64Null(), false},
+		{"unknown", types.Int64Unknown(), false},
+		{"below", types.Int64Value(0), true},
+		{"negative", types.Int64Value(-10), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.Int64Request{Path: path.Root("page_size"), ConfigValue: tt.value}
+			resp := &validator.Int64Response{}
+
+			atLeastValidator{min: 1}.ValidateInt64(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("Expected error %v, got %v: %v", tt.wantErr, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestExclusivePoliciesValidator(t *testing.T) {
+	ctx := context.Background()
+	r := &environmentResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	exclusive := true
+	tests := []struct {
+		name      string
+		policies  []string // nil for no policies
+		exclusive *bool
+		wantPaths []string
+	}{
+		{"neither", nil, nil, nil},
+		{"policies only", []string{"prod-policy"}, nil, nil},
+		{"exclusive with policies", []string{"prod-policy"}, &exclusive, nil},
+		{"exclusive with no policies", []string{}, &exclusive, nil},
+		{"exclusive without policies", nil, &exclusive, []string{"policies"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			for name, typ := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(typ, nil)
+			}
+			if tt.policies != nil {
+				elems := make([]tftypes.Value, 0, len(tt.policies))
+				for _, p := range tt.policies {
+					elems = append(elems, tftypes.NewValue(tftypes.String, p))
+				}
+				values["policies"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems)
+			}
+			if tt.exclusive != nil {
+				values["exclusive_policies"] = tftypes.NewValue(tftypes.Bool, *tt.exclusive)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+			resp := &resource.ValidateConfigResponse{}
+
+			exclusivePoliciesValidator{}.ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, withPath.Path().String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("Expected errors at %v, got %v", tt.wantPaths, got)
+			}
+		})
+	}
+}
+
+func TestSnapshotSelectorValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"latest", types.StringValue("production"), false},
+		{"index", types.StringValue("production#42"), false},
+		{"behind latest", types.StringValue("production~3"), false},
+		{"timestamp", types.StringValue("production@{1700000000}"), false},
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+		{"empty", types.StringValue(""), true},
+		{"zero index", types.StringValue("production#0"), true},
+		{"bare timestamp", types.StringValue("production@1700000000"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("selector"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			snapshotSelectorValidator{}.ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("Expected error %v, got %v: %v", tt.wantErr, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
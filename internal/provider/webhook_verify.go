@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// webhookVerifyTimeout bounds the test event sent by verify_on_create, so an
+// unreachable URL fails the apply quickly rather than hanging it.
+const webhookVerifyTimeout = 10 * time.Second
+
+// webhookTestEvent is the body of the test event sent to a webhook. It has a
+// text field so chat webhooks such as Slack's accept it.
+type webhookTestEvent struct {
+	Event  string `json:"event"`
+	Action string `json:"action"`
+	Text   string `json:"text"`
+}
+
+// verifyWebhook sends a test event for action to webhookURL and returns an
+// error unless the webhook answers with a 2xx status.
+//
+// The event is sent directly rather than through the Kosli client: the URL
+// is not a Kosli API endpoint and must not receive the API token. Errors
+// never include the URL, since it is sensitive.
+func verifyWebhook(ctx context.Context, httpClient *http.Client, webhookURL, action string) error {
+	body, err := json.Marshal(webhookTestEvent{
+		Event:  "TEST",
+		Action: action,
+		Text:   fmt.Sprintf("Test event for Kosli action %q, sent by Terraform to verify this webhook.", action),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal test event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookVerifyTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("the webhook URL is not a valid URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// url.Error repeats the URL; keep only the cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("delivery failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
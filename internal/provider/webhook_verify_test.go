@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{"delivered", http.StatusOK, ""},
+		{"no content", http.StatusNoContent, ""},
+		{"rejected", http.StatusNotFound, "status 404"},
+		{"server error", http.StatusBadGateway, "status 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event webhookTestEvent
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Authorization") != "" {
+					t.Errorf("Unexpected request %s with Authorization %q", r.Method, r.Header.Get("Authorization"))
+				}
+				json.NewDecoder(r.Body).Decode(&event)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := verifyWebhook(context.Background(), server.Client(), server.URL+"/hooks/secret", "prod-alerts")
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if event.Event != "TEST" || event.Action != "prod-alerts" || event.Text == "" {
+					t.Errorf("Unexpected test event: %+v", event)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if strings.Contains(err.Error(), "secret") {
+				t.Errorf("Expected the URL to be kept out of the error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhook_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	webhookURL := server.URL + "/hooks/secret"
+	server.Close()
+
+	err := verifyWebhook(context.Background(), &http.Client{}, webhookURL, "prod-alerts")
+
+	if err == nil {
+		t.Fatal("Expected an error for an unreachable webhook")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("Expected the URL to be kept out of the error, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+)
+
+// wireDumpEnvVar names the file that full request/response dumps are
+// appended to. Unset disables wire dumps: they include response bodies, so
+// they are only written to a file that was asked for explicitly.
+const wireDumpEnvVar = "KOSLI_DEBUG_HTTP"
+
+// wireDumpFile is opened once per provider process and shared by every
+// configured client. It is left open for the life of the process, which
+// Terraform ends without a shutdown hook.
+var (
+	wireDumpOnce sync.Once
+	wireDumpFile io.Writer
+	wireDumpErr  error
+)
+
+// wireDumpOptions returns client options that append sanitized dumps of all
+// API traffic to the file named by KOSLI_DEBUG_HTTP. This is intended for
+// support escalations and is separate from TF_LOG output.
+func wireDumpOptions() ([]client.ClientOption, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	path := os.Getenv(wireDumpEnvVar)
+	if path == "" {
+		return nil, diags
+	}
+
+	wireDumpOnce.Do(func() {
+		wireDumpFile, wireDumpErr = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	})
+	if wireDumpErr != nil {
+		diags.AddWarning(
+			"Unable to Open HTTP Debug File",
+			fmt.Sprintf("HTTP wire dumps are enabled but %s could not be opened: %s. Continuing without HTTP wire dumps.", path, wireDumpErr),
+		)
+		return nil, diags
+	}
+
+	return []client.ClientOption{client.WithWireDump(wireDumpFile)}, diags
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWireDumpOptions(t *testing.T) {
+	t.Setenv(wireDumpEnvVar, "")
+	opts, diags := wireDumpOptions()
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(opts) != 0 {
+		t.Errorf("expected no options when %s is unset, got %d", wireDumpEnvVar, len(opts))
+	}
+
+	// The Kosli CLI's debug switch logs through tflog instead of writing a file
+	t.Chdir(t.TempDir())
+	t.Setenv(cliDebugEnvVar, "true")
+	opts, _ = wireDumpOptions()
+	if len(opts) != 0 {
+		t.Errorf("expected no options with only %s set, got %d", cliDebugEnvVar, len(opts))
+	}
+	if entries, _ := os.ReadDir("."); len(entries) != 0 {
+		t.Errorf("expected no file in the working directory, got %v", entries)
+	}
+
+	t.Setenv(wireDumpEnvVar, filepath.Join(t.TempDir(), "kosli-http.log"))
+	opts, diags = wireDumpOptions()
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(opts) != 1 {
+		t.Errorf("expected a wire dump option, got %d", len(opts))
+	}
+}
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// AppURL returns the URL of the Kosli web UI page at the given path within
+// the organization requests made with ctx act on, e.g.
+// AppURL(ctx, "environments", "production") for
+// https://app.kosli.com/my-org/environments/production. Path elements are
+// escaped.
+func (c *Client) AppURL(ctx context.Context, elem ...string) string {
+	parts := make([]string, 0, len(elem)+2)
+	parts = append(parts, c.baseURL, url.PathEscape(c.OrganizationFor(ctx)))
+	for _, e := range elem {
+		parts = append(parts, url.PathEscape(e))
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+// TestClient_AppURL tests that web UI URLs are built from the base URL, the organization and escaped path elements.
+func TestClient_AppURL(t *testing.T) {
+	client, err := NewClient("test-token", "test-org", WithBaseURL("https://app.us.kosli.com/"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		elem     []string
+		expected string
+	}{
+		{"environment", ctx, []string{"environments", "production"}, "https://app.us.kosli.com/test-org/environments/production"},
+		{"escaped", ctx, []string{"environments", "a b/c"}, "https://app.us.kosli.com/test-org/environments/a%20b%2Fc"},
+		{"org override", WithOrgOverride(ctx, "other-org"), []string{"environments", "production"}, "https://app.us.kosli.com/other-org/environments/production"},
+		{"organization page", ctx, nil, "https://app.us.kosli.com/test-org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.AppURL(tt.ctx, tt.elem...); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ArtifactRequest is the user-facing request format for reporting an
+// artifact to a trail.
+type ArtifactRequest struct {
+	FlowName     string
+	TrailName    string
+	Fingerprint  string // SHA256 of the artifact's content, e.g. an image digest
+	DisplayName  string // Name shown in Kosli, e.g. the image name or file name
+	TemplateName string // Optional; the artifact's name in the flow template
+	CommitSHA    string // Git commit the artifact was built from
+	BuildURL     string // Link to the build that produced the artifact
+	CommitURL    string // Optional link to the commit
+}
+
+// artifactPayload is the request body of ReportArtifact.
+type artifactPayload struct {
+	Fingerprint  string `json:"fingerprint"`
+	Filename     string `json:"filename"`
+	TrailName    string `json:"trail_name"`
+	TemplateName string `json:"template_reference_name,omitempty"`
+	GitCommit    string `json:"git_commit"`
+	BuildURL     string `json:"build_url"`
+	CommitURL    string `json:"commit_url,omitempty"`
+}
+
+// toAPIFormat converts the request into the API payload.
+func (req *ArtifactRequest) toAPIFormat() *artifactPayload {
+	return &artifactPayload{
+		Fingerprint:  req.Fingerprint,
+		Filename:     req.DisplayName,
+		TrailName:    req.TrailName,
+		TemplateName: req.TemplateName,
+		GitCommit:    req.CommitSHA,
+		BuildURL:     req.BuildURL,
+		CommitURL:    req.CommitURL,
+	}
+}
+
+// ReportArtifact reports an artifact to a trail of a flow. Reporting the same
+// fingerprint again updates the artifact's details.
+func (c *Client) ReportArtifact(ctx context.Context, req *ArtifactRequest) error {
+	// Build path: PUT /api/v2/artifacts/{org}/{flow_name}
+	path := fmt.Sprintf("/artifacts/%s/%s", c.OrganizationFor(ctx), req.FlowName)
+
+	resp, err := c.Put(ctx, path, req.toAPIFormat())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
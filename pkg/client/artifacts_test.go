@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestReportArtifact_Success tests reporting an artifact to a trail
+func TestReportArtifact_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if r.URL.Path != "/artifacts/test-org/backend" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var data map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		want := map[string]string{
+			"fingerprint":             "3b7f9a1c",
+			"filename":                "acme/backend:1.4.2",
+			"trail_name":              "abc123",
+			"template_reference_name": "backend-image",
+			"git_commit":              "abc123",
+			"build_url":               "https://ci.example.com/builds/42",
+		}
+		for key, value := range want {
+			if data[key] != value {
+				t.Errorf("expected %s %q, got %v", key, value, data[key])
+			}
+		}
+		if _, ok := data["commit_url"]; ok {
+			t.Errorf("expected commit_url to be omitted, got %v", data["commit_url"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.ReportArtifact(context.Background(), &ArtifactRequest{
+		FlowName:     "backend",
+		TrailName:    "abc123",
+		Fingerprint:  "3b7f9a1c",
+		DisplayName:  "acme/backend:1.4.2",
+		TemplateName: "backend-image",
+		CommitSHA:    "abc123",
+		BuildURL:     "https://ci.example.com/builds/42",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestReportArtifact_FlowNotFound tests reporting to a flow that does not exist
+func TestReportArtifact_FlowNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "flow not found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.ReportArtifact(context.Background(), &ArtifactRequest{FlowName: "missing", TrailName: "abc123", Fingerprint: "3b7f9a1c"})
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "flow not found") {
+		t.Errorf("expected the API message in the error, got %v", err)
+	}
+}
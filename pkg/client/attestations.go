@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SonarAttestationRequest is the user-facing request format for reporting a
+// SonarQube or SonarCloud scan result to a trail.
+type SonarAttestationRequest struct {
+	FlowName            string
+	TrailName           string
+	Name                string // Attestation name, e.g. the slot in the flow template
+	ArtifactFingerprint string // Optional; attests the artifact instead of the trail
+	ProjectKey          string
+	QualityGateStatus   string         // OK, WARN, ERROR or NONE
+	ScanURL             string         // Optional link to the analysis
+	EvidenceFiles       []EvidenceFile // Optional files, e.g. the scan report, in EvidenceFileField
+}
+
+// sonarResults is the sonar_results object of a sonar attestation.
+type sonarResults struct {
+	Project struct {
+		Key string `json:"key"`
+		URL string `json:"url,omitempty"`
+	} `json:"project"`
+	QualityGate struct {
+		Status string `json:"status"`
+	} `json:"qualityGate"`
+}
+
+// toAPIFormat converts the request into the data_json payload.
+func (req *SonarAttestationRequest) toAPIFormat() map[string]any {
+	var results sonarResults
+	results.Project.Key = req.ProjectKey
+	results.Project.URL = req.ScanURL
+	results.QualityGate.Status = req.QualityGateStatus
+
+	data := map[string]any{
+		"attestation_name": req.Name,
+		"sonar_results":    results,
+	}
+	if req.ArtifactFingerprint != "" {
+		data["artifact_fingerprint"] = req.ArtifactFingerprint
+	}
+	return data
+}
+
+// CreateSonarAttestation reports a sonar attestation to a trail. The API
+// returns "OK" (201 Created), not the created attestation.
+func (c *Client) CreateSonarAttestation(ctx context.Context, req *SonarAttestationRequest) error {
+	path := fmt.Sprintf("/attestations/%s/%s/trail/%s/sonar", c.OrganizationFor(ctx), req.FlowName, req.TrailName)
+
+	return c.UploadEvidence(ctx, path, req.toAPIFormat(), req.EvidenceFiles)
+}
+
+// Attestation represents an attestation reported to a trail or an artifact,
+// as returned by the API.
+type Attestation struct {
+	ID                  string          `json:"attestation_id"`
+	Name                string          `json:"attestation_name"`
+	Type                string          `json:"attestation_type"`
+	IsCompliant         bool            `json:"is_compliant"`
+	CreatedAt           float64         `json:"created_at"`           // Unix timestamp
+	ArtifactFingerprint string          `json:"artifact_fingerprint"` // Empty for attestations on the trail itself
+	UserData            json.RawMessage `json:"user_data"`            // Custom data reported with the attestation, if any
+	HTMLURL             string          `json:"html_url"`
+
+	// Raw is the complete attestation document, including the payload
+	// specific to its type (e.g. sonar_results or junit_results).
+	Raw json.RawMessage `json:"-"`
+}
+
+// GetLatestAttestationOptions selects where GetLatestAttestation looks.
+type GetLatestAttestationOptions struct {
+	// ArtifactFingerprint looks up the attestation of an artifact instead of
+	// the trail itself.
+	ArtifactFingerprint string
+}
+
+// GetLatestAttestation retrieves the most recently reported attestation
+// named name on a trail, or on one of its artifacts when opts selects one.
+// It returns a not found error if no such attestation has been reported.
+func (c *Client) GetLatestAttestation(ctx context.Context, flowName, trailName, name string, opts *GetLatestAttestationOptions) (*Attestation, error) {
+	// Build path: GET /api/v2/attestations/{org}/{flow_name}/trail/{trail_name}/{attestation_name}
+	path := fmt.Sprintf("/attestations/%s/%s/trail/%s/%s", c.OrganizationFor(ctx), flowName, trailName, name)
+	if opts != nil && opts.ArtifactFingerprint != "" {
+		// GET /api/v2/attestations/{org}/{flow_name}/artifact/{fingerprint}/{attestation_name}
+		path = fmt.Sprintf("/attestations/%s/%s/artifact/%s/%s", c.OrganizationFor(ctx), flowName, opts.ArtifactFingerprint, name)
+	}
+
+	// Call API
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attestations carry a payload that depends on their type, so they are
+	// kept raw and only the common fields are decoded.
+	var raw []json.RawMessage
+	if err := ParseResponse(resp, &raw); err != nil {
+		return nil, err
+	}
+
+	var latest *Attestation
+	for _, r := range raw {
+		var a Attestation
+		if err := json.Unmarshal(r, &a); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attestation: %w", err)
+		}
+		if latest == nil || a.CreatedAt > latest.CreatedAt {
+			a.Raw = r
+			latest = &a
+		}
+	}
+	if latest == nil {
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("no attestation named %q has been reported", name)}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestCreateSonarAttestation_Success tests reporting a sonar attestation for an artifact
+func TestCreateSonarAttestation_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/attestations/test-org/backend/trail/abc123/sonar" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "multipart/form-data") {
+			t.Errorf("expected multipart/form-data, got %s", ct)
+		}
+
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("failed to parse multipart form: %v", err)
+		}
+
+		var data map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("data_json")), &data); err != nil {
+			t.Fatalf("failed to unmarshal data_json: %v", err)
+		}
+		if data["attestation_name"] != "code-quality" {
+			t.Errorf("expected attestation_name 'code-quality', got %v", data["attestation_name"])
+		}
+		if data["artifact_fingerprint"] != "sha256:1234" {
+			t.Errorf("expected artifact_fingerprint 'sha256:1234', got %v", data["artifact_fingerprint"])
+		}
+
+		results, ok := data["sonar_results"].(map[string]any)
+		if !ok {
+			t.Fatal("sonar_results not found or not a map")
+		}
+		project := results["project"].(map[string]any)
+		if project["key"] != "acme_backend" || project["url"] != "https://sonarcloud.io/dashboard?id=acme_backend" {
+			t.Errorf("unexpected project: %v", project)
+		}
+		if gate := results["qualityGate"].(map[string]any); gate["status"] != "OK" {
+			t.Errorf("expected quality gate status 'OK', got %v", gate["status"])
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.CreateSonarAttestation(context.Background(), &SonarAttestationRequest{
+		FlowName:            "backend",
+		TrailName:           "abc123",
+		Name:                "code-quality",
+		ArtifactFingerprint: "sha256:1234",
+		ProjectKey:          "acme_backend",
+		QualityGateStatus:   "OK",
+		ScanURL:             "https://sonarcloud.io/dashboard?id=acme_backend",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestCreateSonarAttestation_TrailAttestation tests that optional fields are omitted
+func TestCreateSonarAttestation_TrailAttestation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("failed to parse multipart form: %v", err)
+		}
+
+		var data map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("data_json")), &data); err != nil {
+			t.Fatalf("failed to unmarshal data_json: %v", err)
+		}
+		if _, ok := data["artifact_fingerprint"]; ok {
+			t.Error("expected artifact_fingerprint to be omitted")
+		}
+		project := data["sonar_results"].(map[string]any)["project"].(map[string]any)
+		if _, ok := project["url"]; ok {
+			t.Error("expected project url to be omitted")
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.CreateSonarAttestation(context.Background(), &SonarAttestationRequest{
+		FlowName:          "backend",
+		TrailName:         "abc123",
+		Name:              "code-quality",
+		ProjectKey:        "acme_backend",
+		QualityGateStatus: "ERROR",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestCreateSonarAttestation_NotFound tests reporting to a trail that does not exist
+func TestCreateSonarAttestation_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Trail not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.CreateSonarAttestation(context.Background(), &SonarAttestationRequest{
+		FlowName:          "backend",
+		TrailName:         "missing",
+		Name:              "code-quality",
+		ProjectKey:        "acme_backend",
+		QualityGateStatus: "OK",
+	})
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+// TestGetLatestAttestation_Trail tests picking the newest attestation on a trail
+func TestGetLatestAttestation_Trail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/attestations/test-org/backend/trail/abc123/code-quality" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"attestation_id": "a1", "attestation_name": "code-quality", "attestation_type": "sonar", "is_compliant": false, "created_at": 1700000100},
+			{"attestation_id": "a2", "attestation_name": "code-quality", "attestation_type": "sonar", "is_compliant": true, "created_at": 1700000200,
+			 "user_data": {"scanner": "sonarcloud"}, "sonar_results": {"qualityGate": {"status": "OK"}}}
+		]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithStrictDecoding(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	attestation, err := client.GetLatestAttestation(context.Background(), "backend", "abc123", "code-quality", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attestation.ID != "a2" || !attestation.IsCompliant || attestation.Type != "sonar" {
+		t.Errorf("expected the newest attestation, got %+v", attestation)
+	}
+	if string(attestation.UserData) != `{"scanner": "sonarcloud"}` {
+		t.Errorf("unexpected user data: %s", attestation.UserData)
+	}
+	if !strings.Contains(string(attestation.Raw), "sonar_results") {
+		t.Errorf("expected the raw attestation to keep its type-specific payload, got %s", attestation.Raw)
+	}
+}
+
+// TestGetLatestAttestation_Artifact tests looking up the attestation of an artifact
+func TestGetLatestAttestation_Artifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/attestations/test-org/backend/artifact/sha256-backend/unit-tests" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"attestation_id": "a3", "attestation_name": "unit-tests", "attestation_type": "junit", "artifact_fingerprint": "sha256-backend"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	attestation, err := client.GetLatestAttestation(context.Background(), "backend", "abc123", "unit-tests",
+		&GetLatestAttestationOptions{ArtifactFingerprint: "sha256-backend"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attestation.ID != "a3" || attestation.ArtifactFingerprint != "sha256-backend" {
+		t.Errorf("unexpected attestation: %+v", attestation)
+	}
+}
+
+// TestGetLatestAttestation_NoneReported tests that an empty list is reported as not found
+func TestGetLatestAttestation_NoneReported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetLatestAttestation(context.Background(), "backend", "abc123", "code-quality", nil)
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// BackoffStrategy computes the wait before retry attemptNum (starting at 0),
+// given the minimum and maximum waits configured via WithRetryPolicy.
+// Retry-After handling (see WithRetryAfter) is applied before the strategy
+// is consulted, so strategies only describe the client's own schedule.
+type BackoffStrategy func(retryWaitMin, retryWaitMax time.Duration, attemptNum int) time.Duration
+
+// ExponentialBackoff doubles the wait on every attempt, starting at
+// retryWaitMin and capped at retryWaitMax. It is the default strategy.
+func ExponentialBackoff(retryWaitMin, retryWaitMax time.Duration, attemptNum int) time.Duration {
+	// Pass a nil response so the library doesn't apply its own (uncapped)
+	// Retry-After handling on top of ours.
+	return retryablehttp.DefaultBackoff(retryWaitMin, retryWaitMax, attemptNum, nil)
+}
+
+// LinearBackoff waits retryWaitMin longer on every attempt, capped at
+// retryWaitMax.
+func LinearBackoff(retryWaitMin, retryWaitMax time.Duration, attemptNum int) time.Duration {
+	wait := retryWaitMin * time.Duration(attemptNum+1)
+	if wait <= 0 || wait > retryWaitMax {
+		return retryWaitMax
+	}
+	return wait
+}
+
+// JitteredBackoff picks a uniformly random wait between retryWaitMin and the
+// exponential wait for the attempt, so clients retrying after a shared
+// outage don't all hit the API at the same moment.
+func JitteredBackoff(retryWaitMin, retryWaitMax time.Duration, attemptNum int) time.Duration {
+	ceiling := ExponentialBackoff(retryWaitMin, retryWaitMax, attemptNum)
+	if ceiling <= retryWaitMin {
+		return ceiling
+	}
+	return retryWaitMin + rand.N(ceiling-retryWaitMin+1)
+}
+
+// WithBackoffStrategy sets the schedule of waits between retries. It has no
+// effect unless retries are enabled via WithRetryPolicy.
+func WithBackoffStrategy(strategy BackoffStrategy) ClientOption {
+	return func(c *Client) error {
+		if strategy == nil {
+			return fmt.Errorf("backoff strategy cannot be nil")
+		}
+		c.backoffStrategy = strategy
+		return nil
+	}
+}
+
+// Clock is the client's source of time. It is used for Retry-After dates,
+// the circuit breaker cooldown and the waits between retries.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep blocks for d. It is called in place of the retry policy's own
+	// timer, so a fake clock can record the wait and return immediately.
+	Sleep(d time.Duration)
+}
+
+// WithClock replaces the wall clock, making retry schedules and the circuit
+// breaker deterministic in tests. Waits between retries go through
+// clock.Sleep and, unlike with the wall clock, aren't interrupted when the
+// request context is cancelled.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) error {
+		if clock == nil {
+			return fmt.Errorf("clock cannot be nil")
+		}
+		c.clock = clock
+		return nil
+	}
+}
+
+// now returns the current time according to the client's clock.
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that records sleeps and advances by them instead of waiting.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Sleep(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sleeps = append(f.sleeps, d)
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeClock) Sleeps() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.sleeps...)
+}
+
+// TestBackoffStrategies tests the built-in backoff schedules.
+func TestBackoffStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy BackoffStrategy
+		expected []time.Duration
+	}{
+		{
+			name:     "exponential",
+			strategy: ExponentialBackoff,
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second},
+		},
+		{
+			name:     "linear",
+			strategy: LinearBackoff,
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 5 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []time.Duration
+			for attempt := range len(tt.expected) {
+				got = append(got, tt.strategy(1*time.Second, 10*time.Second, attempt))
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("linear capped at max", func(t *testing.T) {
+		if got := LinearBackoff(1*time.Second, 10*time.Second, 20); got != 10*time.Second {
+			t.Errorf("expected 10s, got %v", got)
+		}
+	})
+
+	t.Run("jittered within exponential bounds", func(t *testing.T) {
+		for attempt := range 5 {
+			ceiling := ExponentialBackoff(1*time.Second, 10*time.Second, attempt)
+			for range 100 {
+				got := JitteredBackoff(1*time.Second, 10*time.Second, attempt)
+				if got < 1*time.Second || got > ceiling {
+					t.Fatalf("attempt %d: expected wait in [1s, %v], got %v", attempt, ceiling, got)
+				}
+			}
+		}
+	})
+}
+
+// TestClient_RetryWithClock tests that retry waits go through the injected clock.
+func TestClient_RetryWithClock(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		header   string
+		expected []time.Duration
+	}{
+		{
+			name:     "default exponential",
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:     "linear",
+			opts:     []ClientOption{WithBackoffStrategy(LinearBackoff)},
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			name:     "Retry-After date relative to clock",
+			header:   "Mon, 01 Jan 2024 00:00:07 GMT",
+			expected: []time.Duration{7 * time.Second, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("Retry-After", tt.header)
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			clock := newFakeClock()
+			opts := append([]ClientOption{
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+				WithRetryPolicy(3, 1*time.Second, 30*time.Second),
+				WithClock(clock),
+			}, tt.opts...)
+			client, err := NewClient("test-token", "test-org", opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			start := time.Now()
+			if _, err := client.Get(context.Background(), "/test"); err == nil {
+				t.Fatal("expected error after exhausting retries, got nil")
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("expected retries not to wait in real time, took %v", elapsed)
+			}
+			if got := clock.Sleeps(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected sleeps %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestClient_CircuitBreakerWithClock tests that the circuit breaker cooldown follows the injected clock.
+func TestClient_CircuitBreakerWithClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	// The clock is applied after the breaker to check it is picked up regardless of order.
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithHTTPClient(&http.Client{}),
+		WithCircuitBreaker(1, 30*time.Second),
+		WithClock(clock),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Get(context.Background(), "/test")
+	if _, err := client.Get(context.Background(), "/test"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	clock.Advance(31 * time.Second)
+	if _, err := client.Get(context.Background(), "/test"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected probe after cooldown, got %v", err)
+	}
+}
+
+// TestClientOptions_BackoffValidation tests validation of the backoff and clock options.
+func TestClientOptions_BackoffValidation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithBackoffStrategy(nil)); err == nil {
+		t.Error("expected error for nil backoff strategy, got nil")
+	}
+	if _, err := NewClient("test-token", "test-org", WithClock(nil)); err == nil {
+		t.Error("expected error for nil clock, got nil")
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// bufferBody reads resp.Body and replaces it with an equivalent reader so the
+// caller can still consume it. A read failure is surfaced to the caller after
+// the bytes that did arrive, and returned so that an incomplete body isn't
+// kept.
+func bufferBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var rest io.Reader = bytes.NewReader(body)
+	if readErr != nil {
+		rest = io.MultiReader(rest, errReader{readErr})
+	}
+	resp.Body = io.NopCloser(rest)
+	return body, readErr
+}
+
+// errReader is an io.Reader that always fails with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
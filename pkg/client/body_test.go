@@ -0,0 +1,41 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestBufferBody tests that a buffered body can still be read by the caller
+func TestBufferBody(t *testing.T) {
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(`{"name": "prod"}`))}
+
+	body, err := bufferBody(resp)
+
+	if err != nil || string(body) != `{"name": "prod"}` {
+		t.Errorf("unexpected buffered body: %q, %v", body, err)
+	}
+	rest, err := io.ReadAll(resp.Body)
+	if err != nil || string(rest) != `{"name": "prod"}` {
+		t.Errorf("expected the caller to read the same body, got %q, %v", rest, err)
+	}
+}
+
+// TestBufferBody_ReadError tests that a read failure reaches the caller after
+// the bytes that arrived
+func TestBufferBody_ReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+	resp := &http.Response{Body: io.NopCloser(io.MultiReader(strings.NewReader("partial"), errReader{readErr}))}
+
+	body, err := bufferBody(resp)
+
+	if string(body) != "partial" || !errors.Is(err, readErr) {
+		t.Errorf("expected %q and %v, got %q, %v", "partial", readErr, body, err)
+	}
+	rest, err := io.ReadAll(resp.Body)
+	if string(rest) != "partial" || !errors.Is(err, readErr) {
+		t.Errorf("expected %q then %v, got %q, %v", "partial", readErr, rest, err)
+	}
+}
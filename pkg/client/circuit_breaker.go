@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned (wrapped) when a request is rejected without
+// being sent because the circuit breaker is open. Use IsCircuitOpen to check.
+var ErrCircuitOpen = errors.New("circuit breaker open: Kosli API appears to be unavailable")
+
+// IsCircuitOpen returns true if the error was caused by an open circuit breaker.
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
+
+// circuitState is the state of a circuitBreaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker fails requests fast during an API outage. It opens after
+// threshold consecutive failed calls (5xx responses or connection errors,
+// counted after the retry policy has given up), rejects calls while open,
+// and after cooldown lets a single probe through (half-open). A successful
+// probe closes the circuit; a failed one re-opens it for another cooldown; a
+// cancelled one frees the slot for the next caller to probe.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	state    circuitState
+	probing  bool // A half-open probe is in flight
+	failures int
+	openedAt time.Time
+	lastErr  error
+}
+
+// WithCircuitBreaker enables a circuit breaker that opens after threshold
+// consecutive server-side failures and probes the API again after cooldown.
+// While open, requests fail immediately with an error wrapping ErrCircuitOpen
+// instead of each going through a full retry cycle.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) error {
+		if threshold < 1 {
+			return fmt.Errorf("circuit breaker threshold must be at least 1")
+		}
+		if cooldown <= 0 {
+			return fmt.Errorf("circuit breaker cooldown must be greater than 0")
+		}
+		c.breaker = &circuitBreaker{
+			threshold: threshold,
+			cooldown:  cooldown,
+			now:       c.now,
+		}
+		return nil
+	}
+}
+
+// allow reports whether a request may be sent. When the cooldown of an open
+// circuit has elapsed, the first caller becomes the half-open probe; other
+// callers are rejected until the probe's outcome is recorded.
+func (cb *circuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return cb.openError()
+		}
+		cb.state = circuitHalfOpen
+		cb.probing = true
+		return nil
+	case circuitHalfOpen:
+		if cb.probing {
+			return cb.openError()
+		}
+		cb.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a request that was allowed.
+func (cb *circuitBreaker) record(resp *http.Response, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probing = false
+	if errors.Is(err, context.Canceled) {
+		// The caller gave up, which says nothing about the server either
+		// way; stay in the current state, so a cancelled probe leaves the
+		// circuit half-open for the next caller to probe
+		return
+	}
+
+	if !isBreakerFailure(resp, err) {
+		cb.state = circuitClosed
+		cb.failures = 0
+		cb.lastErr = nil
+		return
+	}
+
+	cb.failures++
+	if err != nil {
+		cb.lastErr = err
+	} else {
+		cb.lastErr = fmt.Errorf("status %d", resp.StatusCode)
+	}
+	if cb.state == circuitHalfOpen || cb.failures >= cb.threshold {
+		cb.state = circuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// openError builds the error returned while the circuit is open. Callers
+// must hold cb.mu.
+func (cb *circuitBreaker) openError() error {
+	retryIn := max(cb.cooldown-cb.now().Sub(cb.openedAt), 0)
+	return fmt.Errorf("%w (%d consecutive failures, last: %v; next probe in %s)",
+		ErrCircuitOpen, cb.failures, cb.lastErr, retryIn.Round(time.Second))
+}
+
+// isBreakerFailure reports whether a request outcome indicates the API is
+// unhealthy. Client errors (4xx) are healthy responses from the breaker's
+// point of view.
+func isBreakerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker_StateTransitions tests closed -> open -> half-open -> closed/open transitions.
+func TestCircuitBreaker_StateTransitions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := &circuitBreaker{threshold: 2, cooldown: 10 * time.Second, now: func() time.Time { return now }}
+
+	fail := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	ok := &http.Response{StatusCode: http.StatusOK}
+	clientErr := &http.Response{StatusCode: http.StatusBadRequest}
+
+	// A 4xx response resets the failure count.
+	cb.record(fail, nil)
+	cb.record(clientErr, nil)
+	cb.record(fail, nil)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected circuit closed after non-consecutive failures, got %v", err)
+	}
+
+	// Second consecutive failure opens the circuit.
+	cb.record(fail, nil)
+	err := cb.allow()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	// After cooldown, a single half-open probe is allowed.
+	now = now.Add(11 * time.Second)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected half-open probe to be allowed, got %v", err)
+	}
+	if err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected concurrent calls to be rejected during probe, got %v", err)
+	}
+
+	// A failed probe re-opens the circuit.
+	cb.record(nil, errors.New("connection refused"))
+	if err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected circuit re-opened after failed probe, got %v", err)
+	}
+
+	// A successful probe closes it.
+	now = now.Add(11 * time.Second)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected half-open probe to be allowed, got %v", err)
+	}
+	cb.record(ok, nil)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected circuit closed after successful probe, got %v", err)
+	}
+}
+
+// TestCircuitBreaker_IgnoresCancellation tests that cancelled requests don't count as failures.
+func TestCircuitBreaker_IgnoresCancellation(t *testing.T) {
+	cb := &circuitBreaker{threshold: 1, cooldown: time.Minute, now: time.Now}
+
+	cb.record(nil, context.Canceled)
+	if err := cb.allow(); err != nil {
+		t.Errorf("expected cancellation not to open the circuit, got %v", err)
+	}
+}
+
+// TestCircuitBreaker_CancelledProbe tests that a cancelled half-open probe
+// neither closes nor re-opens the circuit, and lets the next caller probe.
+func TestCircuitBreaker_CancelledProbe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := &circuitBreaker{threshold: 1, cooldown: 10 * time.Second, now: func() time.Time { return now }}
+
+	cb.record(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	now = now.Add(11 * time.Second)
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected half-open probe to be allowed, got %v", err)
+	}
+
+	cb.record(nil, fmt.Errorf("request failed: %w", context.Canceled))
+	if cb.state != circuitHalfOpen {
+		t.Errorf("expected circuit to stay half-open after a cancelled probe, got state %d", cb.state)
+	}
+	if err := cb.allow(); err != nil {
+		t.Fatalf("expected the next caller to probe, got %v", err)
+	}
+	if err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected concurrent calls to be rejected during the new probe, got %v", err)
+	}
+}
+
+// TestClient_CircuitBreaker tests that an open circuit fails requests without hitting the server.
+func TestClient_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "down"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+		WithCircuitBreaker(2, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "/test"); !IsServerError(err) {
+			t.Fatalf("request %d: expected server error, got %v", i, err)
+		}
+	}
+
+	_, err = client.Get(context.Background(), "/test")
+	if !IsCircuitOpen(err) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2 consecutive failures") {
+		t.Errorf("expected failure count in error, got %q", err.Error())
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests to reach the server, got %d", got)
+	}
+}
+
+// TestWithCircuitBreaker_ValidationErrors tests validation in the circuit breaker option.
+func TestWithCircuitBreaker_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		threshold   int
+		cooldown    time.Duration
+		expectedErr string
+	}{
+		{name: "zero threshold", threshold: 0, cooldown: time.Second, expectedErr: "threshold must be at least 1"},
+		{name: "zero cooldown", threshold: 3, cooldown: 0, expectedErr: "cooldown must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient("test-token", "test-org", WithCircuitBreaker(tt.threshold, tt.cooldown))
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
@@ -19,15 +19,23 @@ package client
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -70,11 +78,101 @@ type Client struct {
 	// apiToken is the API token for authentication.
 	apiToken string
 
+	// tokenSource, if set, replaces apiToken with a token fetched before
+	// every request. See WithTokenSource.
+	tokenSource TokenSource
+
+	// sourcedToken is the last token returned by tokenSource, kept so it is
+	// redacted from logs.
+	sourcedToken atomic.Value
+
 	// organization is the Kosli organization name.
 	organization string
 
 	// userAgent is the User-Agent header value.
 	userAgent string
+
+	// limiter throttles outgoing requests client-side. Nil means unlimited.
+	// See WithRateLimit.
+	limiter *rate.Limiter
+
+	// slots bounds the number of requests in flight. Nil means unlimited.
+	// See WithMaxConcurrentRequests.
+	slots *semaphore.Weighted
+
+	// breaker fails requests fast during an API outage. Nil means disabled.
+	// See WithCircuitBreaker.
+	breaker *circuitBreaker
+
+	// tracerProvider creates request spans. Nil means the global provider.
+	// See WithTracerProvider.
+	tracerProvider trace.TracerProvider
+
+	// traceParent is the remote parent for request spans started without
+	// one. See WithTraceParent.
+	traceParent trace.SpanContext
+
+	// ignoreRetryAfter disables honoring the Retry-After response header when
+	// computing the backoff between retries. See WithRetryAfter.
+	ignoreRetryAfter bool
+
+	// wireDump receives full request/response dumps. Nil means disabled.
+	// See WithWireDump.
+	wireDump *wireDumper
+
+	// logger receives the client's log entries. See WithLogger.
+	logger *slog.Logger
+
+	// metrics receives per-request observations. Nil means disabled.
+	// See WithMetricsRecorder.
+	metrics MetricsRecorder
+
+	// etags holds GET responses for conditional requests. Nil means
+	// disabled. See WithETagCache.
+	etags *etagCache
+
+	// inflight deduplicates concurrent GETs. Nil means disabled.
+	// See WithGetDeduplication.
+	inflight *singleflight.Group
+
+	// reads caches GET responses until the next write. Nil means disabled.
+	// See WithReadCache.
+	reads *readCache
+
+	// strictDecoding makes unknown response fields an error. See
+	// WithStrictDecoding.
+	strictDecoding bool
+
+	// readTimeout and writeTimeout are the deadlines for read and write
+	// operations. See WithOperationTimeouts.
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+
+	// readAttemptTimeout and writeAttemptTimeout replace the client-wide
+	// timeout for individual attempts. Zero means the client-wide timeout.
+	// See WithAttemptTimeouts.
+	readAttemptTimeout  time.Duration
+	writeAttemptTimeout time.Duration
+
+	// transportRetries is the number of immediate resends of idempotent
+	// requests after network errors. See WithTransportRetries.
+	transportRetries int
+
+	// maxResponseSize caps the bytes read from a response body.
+	// See WithMaxResponseSize.
+	maxResponseSize int64
+
+	// backoffStrategy schedules the waits between retries. Nil means
+	// ExponentialBackoff. See WithBackoffStrategy.
+	backoffStrategy BackoffStrategy
+
+	// defaultPageSize is the page size of paginated calls that don't set
+	// one. Zero means each call's own default. See WithDefaultPageSize.
+	defaultPageSize int
+
+	// clock replaces the wall clock. Nil means time.Now and real waits.
+	// See WithClock.
+	clock Clock
 }
 
 // ClientOption is a function that configures a Client.
@@ -147,7 +245,11 @@ func WithAPIPath(apiPath string) ClientOption {
 	}
 }
 
-// WithRetryPolicy enables retry with exponential backoff.
+// WithRetryPolicy enables retry with exponential backoff (see
+// WithBackoffStrategy). Idempotent requests are retried on connection
+// errors, 429 and 5xx responses (other than 501); POST and PATCH requests
+// only when they can't have reached the server (see IdempotencyKeyHeader to
+// opt such a request in).
 func WithRetryPolicy(retryMax int, retryWaitMin, retryWaitMax time.Duration) ClientOption {
 	return func(c *Client) error {
 		if retryMax < 0 {
@@ -168,10 +270,31 @@ func WithRetryPolicy(retryMax int, retryWaitMin, retryWaitMax time.Duration) Cli
 		retryClient.RetryMax = retryMax
 		retryClient.RetryWaitMin = retryWaitMin
 		retryClient.RetryWaitMax = retryWaitMax
-		retryClient.CheckRetry = retryablehttp.DefaultRetryPolicy
-		retryClient.Backoff = retryablehttp.DefaultBackoff
+		retryClient.CheckRetry = checkRetry
+		retryClient.Backoff = c.backoff
+		retryClient.ErrorHandler = giveUp
+		retryClient.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+			countAttempt(req, attempt)
+		}
+		// Resend idempotent requests after network noise within each attempt
+		transport := http.DefaultTransport
+		if c.transportRetries > 0 {
+			transport = &transportRetrier{base: transport, retries: c.transportRetries, wait: transportRetryWait}
+		}
 		retryClient.HTTPClient = &http.Client{
-			Timeout: c.httpClient.Timeout,
+			Transport: transport,
+			Timeout:   c.httpClient.Timeout,
+		}
+		if c.readAttemptTimeout > 0 || c.writeAttemptTimeout > 0 {
+			// Limit each attempt by its own timeout rather than the
+			// client-wide one
+			retryClient.HTTPClient = &http.Client{
+				Transport: &attemptTimeoutTransport{
+					base:  transport,
+					read:  cmp.Or(c.readAttemptTimeout, c.httpClient.Timeout),
+					write: cmp.Or(c.writeAttemptTimeout, c.httpClient.Timeout),
+				},
+			}
 		}
 
 		// Replace the standard client with the retryable client's standard client
@@ -180,10 +303,101 @@ func WithRetryPolicy(retryMax int, retryWaitMin, retryWaitMax time.Duration) Cli
 	}
 }
 
+// WithRetryAfter controls whether the Retry-After header on 429 and 503
+// responses is honored when computing the wait before the next retry.
+// It is enabled by default; when disabled, the exponential schedule
+// configured via WithRetryPolicy is always used.
+func WithRetryAfter(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.ignoreRetryAfter = !enabled
+		return nil
+	}
+}
+
+// WithRateLimit throttles outgoing requests to requestsPerSecond on average,
+// allowing bursts of up to burst requests. Waiting for the limiter respects
+// the request context, so a cancelled apply doesn't block on the queue.
+// Retries issued by the retry policy reuse the original request's slot.
+//
+// This is useful for large applies touching hundreds of resources, where
+// Terraform's parallelism would otherwise trip server-side throttling.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("requests per second must be greater than 0")
+		}
+		if burst < 1 {
+			return fmt.Errorf("burst must be at least 1")
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		return nil
+	}
+}
+
+// backoff computes the wait before retry attemptNum. For 429 Too Many
+// Requests and 503 Service Unavailable responses carrying a Retry-After
+// header (either delay-seconds or an HTTP date), the server-provided delay
+// is used, capped at retryWaitMax so a misbehaving server can't stall an
+// apply indefinitely. Otherwise the backoff strategy applies.
+//
+// With an injected clock the wait is spent in clock.Sleep and the retry
+// policy is told not to wait at all.
+func (c *Client) backoff(retryWaitMin, retryWaitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	wait := c.retryWait(retryWaitMin, retryWaitMax, attemptNum, resp)
+	if c.clock == nil {
+		return wait
+	}
+	c.clock.Sleep(wait)
+	return 0
+}
+
+// retryWait is the wait before retry attemptNum; see backoff.
+func (c *Client) retryWait(retryWaitMin, retryWaitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if !c.ignoreRetryAfter && resp != nil &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), c.now()); ok {
+			return min(wait, retryWaitMax)
+		}
+	}
+
+	strategy := c.backoffStrategy
+	if strategy == nil {
+		strategy = ExponentialBackoff
+	}
+	return strategy(retryWaitMin, retryWaitMax, attemptNum)
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a
+// non-negative number of seconds or an HTTP date (RFC 9110 section 10.2.3).
+// Dates in the past yield a zero wait. The bool is false if the header is
+// absent or malformed.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := at.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
 // NewClient creates a new Kosli API client.
 //
 // Required parameters:
-//   - apiToken: The Kosli API token for authentication
+//   - apiToken: The Kosli API token for authentication; may be empty with WithTokenSource
 //   - organization: The Kosli organization name
 //
 // Optional parameters can be provided via ClientOption functions.
@@ -197,10 +411,8 @@ func WithRetryPolicy(retryMax int, retryWaitMin, retryWaitMax time.Duration) Cli
 //	    WithRetryPolicy(5, 2*time.Second, 60*time.Second),
 //	)
 func NewClient(apiToken, organization string, opts ...ClientOption) (*Client, error) {
-	// Validate required parameters
-	if apiToken == "" {
-		return nil, fmt.Errorf("API token is required")
-	}
+	// Validate required parameters. The API token may come from a token
+	// source instead, which is checked once the options are applied.
 	if organization == "" {
 		return nil, fmt.Errorf("organization is required")
 	}
@@ -215,6 +427,12 @@ func NewClient(apiToken, organization string, opts ...ClientOption) (*Client, er
 		apiToken:     apiToken,
 		organization: organization,
 		userAgent:    DefaultUserAgent,
+		readTimeout:  DefaultReadTimeout,
+		writeTimeout: DefaultWriteTimeout,
+
+		transportRetries: DefaultTransportRetries,
+		maxResponseSize:  DefaultMaxResponseSize,
+		logger:           discardLogger,
 	}
 
 	// Compute full API URL
@@ -230,6 +448,10 @@ func NewClient(apiToken, organization string, opts ...ClientOption) (*Client, er
 		}
 	}
 
+	if apiToken == "" && client.tokenSource == nil {
+		return nil, fmt.Errorf("API token is required")
+	}
+
 	// If the user provided a custom HTTP client (via WithHTTPClient), don't apply retry
 	// Otherwise, apply default retry policy
 	if client.httpClient == originalClient {
@@ -243,7 +465,26 @@ func NewClient(apiToken, organization string, opts ...ClientOption) (*Client, er
 
 // Get performs a GET request to the specified path.
 func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
-	return c.doRequest(ctx, http.MethodGet, path, nil)
+	fresh := isFreshRead(ctx)
+	var generation uint64
+	if c.reads != nil {
+		if resp, ok := c.reads.get(path); ok && !fresh {
+			return resp, nil
+		}
+		generation = c.reads.current()
+	}
+
+	var resp *http.Response
+	var err error
+	if c.inflight != nil && !fresh {
+		resp, err = c.sharedGet(ctx, path)
+	} else {
+		resp, err = c.doRequest(ctx, http.MethodGet, path, nil)
+	}
+	if err == nil && c.reads != nil {
+		c.reads.put(path, generation, resp)
+	}
+	return resp, err
 }
 
 // Post performs a POST request to the specified path with the given body.
@@ -288,14 +529,16 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 	}
 
 	// Add headers
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
 	req.Header.Set("User-Agent", c.userAgent)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -309,9 +552,80 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 	return resp, nil
 }
 
+// do executes a fully-built request. Every request the client issues,
+// including the multipart ones that can't go through doRequest, must be
+// sent through here so client-wide policies (circuit breaking, rate
+// limiting, deadlines, tracing, logging, wire dumps and metrics) apply.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.withOperationDeadline(req, c.send)
+}
+
+// send applies the client-wide policies to a request running under its
+// operation deadline.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.slots != nil {
+		if err := c.slots.Acquire(req.Context(), 1); err != nil {
+			return nil, fmt.Errorf("waiting for a request slot: %w", err)
+		}
+		defer c.slots.Release(1)
+	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+	// Checked after the limiter wait so an allowed half-open probe is always
+	// sent and recorded.
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Any write may change what a cached read returned. Invalidated both
+	// before and after the write so that reads overlapping it aren't cached.
+	if c.reads != nil && req.Method != http.MethodGet {
+		c.reads.invalidate()
+		defer c.reads.invalidate()
+	}
+
+	setRequestID(req)
+
+	conditional := c.conditional(req)
+	if conditional {
+		c.addIfNoneMatch(req)
+	}
+
+	ctx := context.WithValue(withRetrySafety(req.Context(), req), strictDecodingKey{}, c.strictDecoding)
+	ctx = context.WithValue(ctx, loggerKey{}, c.logger)
+	ctx, attempts := withAttemptCounter(ctx)
+	req, endSpan := c.startSpan(req.WithContext(ctx), attempts)
+	c.logRequest(req.Context(), req)
+	dumpResponse := c.dumpRequest(req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	elapsed := time.Since(start)
+	if err == nil {
+		resp.Body = newLimitedBody(resp.Body, c.maxResponseSize)
+	}
+	c.logResponse(req.Context(), req, resp, err, elapsed)
+	dumpResponse(resp, err, elapsed)
+	c.recordMetrics(req, resp, err, retries(attempts), elapsed)
+	endSpan(resp, err)
+	if c.breaker != nil {
+		c.breaker.record(resp, err)
+	}
+	if conditional && err == nil {
+		resp = c.resolveETag(req, resp)
+	}
+	return resp, err
+}
+
 // ParseResponse reads and unmarshals a JSON response body into the provided interface.
 //
-// The response body is closed after reading.
+// The response body is closed after reading. Fields in the response that v
+// doesn't declare are logged as a warning, or are an error if the client
+// was created WithStrictDecoding.
 //
 // Example:
 //
@@ -325,7 +639,11 @@ func ParseResponse(resp *http.Response, v any) error {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if err := json.Unmarshal(body, v); err != nil {
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	if err := decodeJSON(ctx, body, v); err != nil {
 		return fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -333,6 +651,8 @@ func ParseResponse(resp *http.Response, v any) error {
 }
 
 // Organization returns the organization name configured for this client.
+// Individual calls may act on another organization; see WithOrgOverride and
+// OrganizationFor.
 func (c *Client) Organization() string {
 	return c.organization
 }
@@ -95,6 +95,7 @@ func TestNewClient_WithOptions(t *testing.T) {
 			WithBaseURL(server.URL),
 			WithAPIPath(""),
 			WithTimeout(timeout),
+			WithClock(newFakeClock()),
 		)
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
@@ -358,6 +359,78 @@ func TestClient_Put_Success(t *testing.T) {
 	}
 }
 
+// TestClient_Patch_Success tests a successful PATCH request.
+func TestClient_Patch_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected Authorization header, got %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected Content-Type application/json, got %q", r.Header.Get("Content-Type"))
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if len(body) != 1 || body["description"] != "only this" {
+			t.Errorf("expected partial body with description only, got %v", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"updated": true}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Patch(context.Background(), "/test-path", map[string]string{"description": "only this"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestClient_Patch_Error tests that PATCH errors are parsed like other methods.
+func TestClient_Patch_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "environment not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Patch(context.Background(), "/test-path", map[string]string{"description": "x"})
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Method != http.MethodPatch {
+		t.Errorf("expected APIError for PATCH, got %v", err)
+	}
+}
+
 // TestClient_Delete_Success tests a successful DELETE request.
 func TestClient_Delete_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -425,6 +498,13 @@ func TestClient_ErrorHandling(t *testing.T) {
 			expectedErrMsg: "not found",
 			checkFunc:      IsNotFound,
 		},
+		{
+			name:           "405 Method Not Allowed",
+			statusCode:     http.StatusMethodNotAllowed,
+			responseBody:   `{"message": "method not allowed"}`,
+			expectedErrMsg: "method not allowed",
+			checkFunc:      IsMethodNotAllowed,
+		},
 		{
 			name:           "409 Conflict",
 			statusCode:     http.StatusConflict,
@@ -521,6 +601,120 @@ func TestClient_ErrorHandling(t *testing.T) {
 	}
 }
 
+// TestClient_ErrorDetails tests that APIError captures the request ID, raw body and retryability.
+func TestClient_ErrorDetails(t *testing.T) {
+	tests := []struct {
+		name              string
+		statusCode        int
+		headers           map[string]string
+		responseBody      string
+		expectedRequestID string
+		expectedBody      string
+		expectedRetryable bool
+	}{
+		{
+			name:              "X-Request-ID header",
+			statusCode:        http.StatusBadRequest,
+			headers:           map[string]string{"X-Request-ID": "req-123"},
+			responseBody:      `{"message": "invalid request"}`,
+			expectedRequestID: "req-123",
+			expectedBody:      `{"message": "invalid request"}`,
+			expectedRetryable: false,
+		},
+		{
+			name:              "correlation ID fallback",
+			statusCode:        http.StatusServiceUnavailable,
+			headers:           map[string]string{"X-Correlation-ID": "corr-456"},
+			responseBody:      "<html>maintenance</html>",
+			expectedRequestID: "corr-456",
+			expectedBody:      "<html>maintenance</html>",
+			expectedRetryable: true,
+		},
+		{
+			name:              "rate limited is retryable",
+			statusCode:        http.StatusTooManyRequests,
+			responseBody:      `{"message": "slow down"}`,
+			expectedBody:      `{"message": "slow down"}`,
+			expectedRetryable: true,
+		},
+		{
+			name:              "not implemented is not retryable",
+			statusCode:        http.StatusNotImplemented,
+			responseBody:      "",
+			expectedBody:      "",
+			expectedRetryable: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+				WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.Get(context.Background(), "/test-path")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected APIError, got %v", err)
+			}
+			if apiErr.RequestID != tt.expectedRequestID {
+				t.Errorf("expected request ID %q, got %q", tt.expectedRequestID, apiErr.RequestID)
+			}
+			if apiErr.Body != tt.expectedBody {
+				t.Errorf("expected body %q, got %q", tt.expectedBody, apiErr.Body)
+			}
+			if apiErr.Retryable != tt.expectedRetryable {
+				t.Errorf("expected retryable %v, got %v", tt.expectedRetryable, apiErr.Retryable)
+			}
+			if IsRetryable(err) != tt.expectedRetryable {
+				t.Errorf("expected IsRetryable %v, got %v", tt.expectedRetryable, IsRetryable(err))
+			}
+		})
+	}
+
+	t.Run("large body is truncated", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(strings.Repeat("x", maxErrorBodySize*2)))
+		}))
+		defer server.Close()
+
+		client, err := NewClient("test-token", "test-org",
+			WithBaseURL(server.URL),
+			WithAPIPath(""),
+			WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		_, err = client.Get(context.Background(), "/test-path")
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected APIError, got %v", err)
+		}
+		if len(apiErr.Body) != maxErrorBodySize {
+			t.Errorf("expected body truncated to %d bytes, got %d", maxErrorBodySize, len(apiErr.Body))
+		}
+	})
+}
+
 // TestClient_ContextCancellation tests request cancellation via context.
 func TestClient_ContextCancellation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -771,6 +965,220 @@ func TestClient_RetryPolicy(t *testing.T) {
 	})
 }
 
+// TestClient_RetryOn429 tests that rate-limited requests are retried.
+func TestClient_RetryOn429(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message": "rate limited"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result": "success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithRetryPolicy(3, 10*time.Millisecond, 100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+// TestClient_Backoff tests that Retry-After is honored (and capped) when computing retry waits.
+func TestClient_Backoff(t *testing.T) {
+	newResp := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name          string
+		disableHeader bool
+		resp          *http.Response
+		attempt       int
+		expected      time.Duration
+	}{
+		{
+			name:     "429 with seconds",
+			resp:     newResp(http.StatusTooManyRequests, "5"),
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "503 with seconds",
+			resp:     newResp(http.StatusServiceUnavailable, "2"),
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "capped at max wait",
+			resp:     newResp(http.StatusTooManyRequests, "3600"),
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "date in the past",
+			resp:     newResp(http.StatusTooManyRequests, "Fri, 31 Dec 1999 23:59:59 GMT"),
+			expected: 0,
+		},
+		{
+			name:     "malformed header falls back to exponential",
+			resp:     newResp(http.StatusTooManyRequests, "soon"),
+			attempt:  2,
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "500 ignores header",
+			resp:     newResp(http.StatusInternalServerError, "5"),
+			attempt:  1,
+			expected: 2 * time.Second,
+		},
+		{
+			name:          "disabled via option",
+			disableHeader: true,
+			resp:          newResp(http.StatusTooManyRequests, "5"),
+			expected:      1 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("test-token", "test-org", WithRetryAfter(!tt.disableHeader))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			got := client.backoff(1*time.Second, 30*time.Second, tt.attempt, tt.resp)
+			if got != tt.expected {
+				t.Errorf("expected wait %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestClient_RateLimit tests that the client-side rate limiter throttles requests.
+func TestClient_RateLimit(t *testing.T) {
+	t.Run("throttles requests beyond burst", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := NewClient("test-token", "test-org",
+			WithBaseURL(server.URL),
+			WithAPIPath(""),
+			WithRateLimit(20, 1),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(context.Background(), "/test")
+			if err != nil {
+				t.Fatalf("request %d failed: %v", i, err)
+			}
+			resp.Body.Close()
+		}
+
+		// First request uses the burst token; the next two wait ~50ms each.
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Errorf("expected requests to be throttled, took %v", elapsed)
+		}
+		if requests != 3 {
+			t.Errorf("expected 3 requests, got %d", requests)
+		}
+	})
+
+	t.Run("wait respects context cancellation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := NewClient("test-token", "test-org",
+			WithBaseURL(server.URL),
+			WithAPIPath(""),
+			WithRateLimit(0.1, 1),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(context.Background(), "/test")
+		if err != nil {
+			t.Fatalf("first request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := client.Get(ctx, "/test"); err == nil {
+			t.Fatal("expected rate limiter wait to fail, got nil")
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		if _, err := NewClient("test-token", "test-org", WithRateLimit(0, 1)); err == nil || !strings.Contains(err.Error(), "requests per second must be greater than 0") {
+			t.Errorf("expected requests per second error, got %v", err)
+		}
+		if _, err := NewClient("test-token", "test-org", WithRateLimit(5, 0)); err == nil || !strings.Contains(err.Error(), "burst must be at least 1") {
+			t.Errorf("expected burst error, got %v", err)
+		}
+	})
+}
+
+// TestParseRetryAfter tests parsing of both Retry-After header formats.
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "120", expected: 120 * time.Second, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "http date", value: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "past http date", value: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0, ok: true},
+		{name: "garbage", value: "later", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v", tt.ok, ok)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 // TestWithRetryPolicy_ValidationErrors tests validation in retry policy option.
 func TestWithRetryPolicy_ValidationErrors(t *testing.T) {
 	tests := []struct {
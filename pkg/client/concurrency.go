@@ -0,0 +1,24 @@
+package client
+
+import (
+	"fmt"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// WithMaxConcurrentRequests limits the number of requests in flight at once
+// to n. Further requests wait for a free slot, respecting their context.
+// A slot is held for the whole retry cycle of a request, including the waits
+// between attempts, so retries don't add to the load on the API.
+//
+// Unlike WithRateLimit, which spaces requests out over time, this bounds how
+// many run in parallel, regardless of how quickly the API responds.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("max concurrent requests must be at least 1")
+		}
+		c.slots = semaphore.NewWeighted(int64(n))
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_MaxConcurrentRequests tests that no more than the configured number of requests run at once.
+func TestClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithMaxConcurrentRequests(2),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(context.Background(), fmt.Sprintf("/test/%d", i))
+			if err != nil {
+				t.Errorf("request %d: unexpected error: %v", i, err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+// TestClient_MaxConcurrentRequests_ContextCancelled tests that waiting for a slot respects the request context.
+func TestClient_MaxConcurrentRequests_ContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithMaxConcurrentRequests(1),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		if resp, err := client.Get(context.Background(), "/busy"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	// Give the first request time to take the only slot.
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Get(ctx, "/waiting")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestWithMaxConcurrentRequests_Validation tests that the limit must be positive.
+func TestWithMaxConcurrentRequests_Validation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithMaxConcurrentRequests(0)); err == nil {
+		t.Error("expected error for zero max concurrent requests, got nil")
+	}
+}
@@ -1,16 +1,19 @@
 package client
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"mime/multipart"
 	"net/http"
-	"net/url"
+	"strings"
 )
 
+// MaxCustomAttestationTypeSchemaSize is the largest type_schema file, in
+// bytes, the API accepts in a multipart upload.
+const MaxCustomAttestationTypeSchemaSize = 10 << 20
+
 // CustomAttestationType represents a custom attestation type in Kosli.
 // Contains both API format (Versions) and user-facing format (Schema, JqRules).
 type CustomAttestationType struct {
@@ -21,6 +24,11 @@ type CustomAttestationType struct {
 	Versions    []Version `json:"versions"` // API format (contains schema and evaluator)
 	Archived    bool      `json:"archived"`
 	Org         string    `json:"org"`
+
+	// RawSchemaSHA256 is the hex-encoded SHA-256 of the latest version's
+	// type_schema as the API returned it, or "" if it has none. Pass it back
+	// in KnownSchema to skip normalizing an unchanged schema.
+	RawSchemaSHA256 string `json:"-"`
 }
 
 // Version represents a version of a custom attestation type.
@@ -48,7 +56,16 @@ type CreateCustomAttestationTypeRequest struct {
 
 // GetCustomAttestationTypeOptions contains optional parameters for GetCustomAttestationType.
 type GetCustomAttestationTypeOptions struct {
-	Version string // Optional version parameter
+	Version string       // Optional version parameter
+	Known   *KnownSchema // Optional schema from a previous read, reused if unchanged
+}
+
+// KnownSchema is a schema the caller read before. Normalizing a large schema
+// is costly, so when the latest type_schema still has RawSHA256, Schema is
+// returned as is instead.
+type KnownSchema struct {
+	RawSHA256 string // RawSchemaSHA256 of the custom attestation type when Schema was read
+	Schema    string // The schema read then, in any equivalent formatting
 }
 
 // toAPIFormat converts user-facing jq_rules to API's evaluator format.
@@ -71,65 +88,76 @@ func (req *CreateCustomAttestationTypeRequest) toAPIFormat() map[string]any {
 
 // fromAPIFormat converts API response to user-facing format.
 // Extracts schema and jq_rules from the latest version in the versions array.
-func (at *CustomAttestationType) fromAPIFormat() error {
-	if len(at.Versions) > 0 {
-		latestVersion := at.Versions[0]
-		raw := latestVersion.TypeSchema
-
-		if len(raw) == 0 || string(raw) == "null" {
-			at.Schema = ""
-		} else {
-			// Re-marshal to canonical compact JSON
-			var schemaObj any
-			if err := json.Unmarshal(raw, &schemaObj); err != nil {
-				return fmt.Errorf("invalid JSON in type_schema: %w", err)
-			}
-			normalizedJSON, err := json.Marshal(schemaObj)
-			if err != nil {
-				return fmt.Errorf("failed to normalize schema JSON: %w", err)
-			}
-			at.Schema = string(normalizedJSON)
-		}
+// The schema is only normalized if it differs from known, which may be nil.
+func (at *CustomAttestationType) fromAPIFormat(known *KnownSchema) error {
+	if len(at.Versions) == 0 {
+		return nil
+	}
 
-		if latestVersion.Evaluator != nil && latestVersion.Evaluator.ContentType == "jq" {
-			at.JqRules = latestVersion.Evaluator.Rules
-		}
+	latest := &at.Versions[0]
+	at.RawSchemaSHA256 = latest.rawSchemaSHA256()
+	if known != nil && at.RawSchemaSHA256 != "" && known.RawSHA256 == at.RawSchemaSHA256 {
+		at.Schema = known.Schema
+		at.JqRules = latest.jqRules()
+		return nil
 	}
 
+	schema, jqRules, err := latest.content()
+	if err != nil {
+		return err
+	}
+	at.Schema = schema
+	at.JqRules = jqRules
 	return nil
 }
 
-// createMultipartRequest builds multipart/form-data request for POST.
-func createMultipartRequest(data map[string]any, schema string) (io.Reader, string, error) {
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
-	// Add data_json field
-	dataJSON, err := json.Marshal(data)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to marshal data: %w", err)
-	}
-	if err := writer.WriteField("data_json", string(dataJSON)); err != nil {
-		return nil, "", fmt.Errorf("failed to write data_json field: %w", err)
+// VersionContent returns the user-facing schema and jq_rules of the given
+// version. It fails if the version is not among at.Versions.
+func (at *CustomAttestationType) VersionContent(version int) (schema string, jqRules []string, err error) {
+	for _, v := range at.Versions {
+		if v.Version == version {
+			return v.content()
+		}
 	}
+	return "", nil, fmt.Errorf("version %d of custom attestation type %q not found", version, at.Name)
+}
 
-	// Add type_schema field if provided
-	if schema != "" {
-		part, err := writer.CreateFormFile("type_schema", "schema.json")
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create type_schema field: %w", err)
+// content converts the version's type_schema and evaluator to the
+// user-facing schema and jq_rules.
+func (v *Version) content() (schema string, jqRules []string, err error) {
+	raw := v.TypeSchema
+	if len(raw) != 0 && string(raw) != "null" {
+		// Re-marshal to canonical compact JSON
+		var schemaObj any
+		if err := json.Unmarshal(raw, &schemaObj); err != nil {
+			return "", nil, fmt.Errorf("invalid JSON in type_schema: %w", err)
 		}
-		if _, err := part.Write([]byte(schema)); err != nil {
-			return nil, "", fmt.Errorf("failed to write type_schema content: %w", err)
+		normalizedJSON, err := json.Marshal(schemaObj)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to normalize schema JSON: %w", err)
 		}
+		schema = string(normalizedJSON)
 	}
 
-	contentType := writer.FormDataContentType()
-	if err := writer.Close(); err != nil {
-		return nil, "", fmt.Errorf("failed to close multipart writer: %w", err)
+	return schema, v.jqRules(), nil
+}
+
+// jqRules returns the jq rules of the version's evaluator, if it has any.
+func (v *Version) jqRules() []string {
+	if v.Evaluator != nil && v.Evaluator.ContentType == "jq" {
+		return v.Evaluator.Rules
 	}
+	return nil
+}
 
-	return &buf, contentType, nil
+// rawSchemaSHA256 returns the hex-encoded SHA-256 of the version's
+// type_schema as returned by the API, or "" if it has none.
+func (v *Version) rawSchemaSHA256() string {
+	if len(v.TypeSchema) == 0 || string(v.TypeSchema) == "null" {
+		return ""
+	}
+	sum := sha256.Sum256(v.TypeSchema)
+	return hex.EncodeToString(sum[:])
 }
 
 // CreateCustomAttestationType creates a new custom attestation type.
@@ -139,38 +167,22 @@ func (c *Client) CreateCustomAttestationType(ctx context.Context, req *CreateCus
 	// Build API-format data
 	data := req.toAPIFormat()
 
-	// Create multipart form body
-	body, contentType, err := createMultipartRequest(data, req.Schema)
-	if err != nil {
-		return fmt.Errorf("failed to create multipart request: %w", err)
+	// Attach the schema file if provided
+	var files []EvidenceFile
+	if req.Schema != "" {
+		files = append(files, EvidenceBytes("type_schema", "schema.json", []byte(req.Schema)))
 	}
 
 	// Build path
-	path := fmt.Sprintf("/custom-attestation-types/%s", c.Organization())
-
-	// Create custom HTTP request (not using client.Post because it sends JSON)
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+path, body)
-	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	// Set headers manually
-	httpReq.Header.Set("Content-Type", contentType)
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
-	httpReq.Header.Set("User-Agent", c.userAgent)
+	path := fmt.Sprintf("/custom-attestation-types/%s", c.OrganizationFor(ctx))
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.doMultipart(ctx, http.MethodPost, path, data, files)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	// Handle errors
-	if resp.StatusCode >= 400 {
-		return parseErrorResponse(resp)
-	}
-
 	// Verify 201 status
 	if resp.StatusCode != http.StatusCreated {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
@@ -183,13 +195,11 @@ func (c *Client) CreateCustomAttestationType(ctx context.Context, req *CreateCus
 // GetCustomAttestationType retrieves a specific custom attestation type.
 func (c *Client) GetCustomAttestationType(ctx context.Context, name string, opts *GetCustomAttestationTypeOptions) (*CustomAttestationType, error) {
 	// Build path
-	path := fmt.Sprintf("/custom-attestation-types/%s/%s", c.Organization(), name)
+	path := fmt.Sprintf("/custom-attestation-types/%s/%s", c.OrganizationFor(ctx), name)
 
 	// Add optional version query parameter
-	if opts != nil && opts.Version != "" {
-		params := url.Values{}
-		params.Add("version", opts.Version)
-		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	if opts != nil {
+		path = newQuery().String("version", opts.Version).Path(path)
 	}
 
 	// Call API
@@ -205,17 +215,45 @@ func (c *Client) GetCustomAttestationType(ctx context.Context, name string, opts
 	}
 
 	// Transform from API format to user format
-	if err := result.fromAPIFormat(); err != nil {
+	var known *KnownSchema
+	if opts != nil {
+		known = opts.Known
+	}
+	if err := result.fromAPIFormat(known); err != nil {
 		return nil, fmt.Errorf("failed to transform API response: %w", err)
 	}
 
 	return &result, nil
 }
 
-// ListCustomAttestationTypes retrieves all custom attestation types for the organization.
-func (c *Client) ListCustomAttestationTypes(ctx context.Context) ([]CustomAttestationType, error) {
+// ListCustomAttestationTypesOptions contains optional filters for
+// ListCustomAttestationTypes. Except for IncludeArchived, the API has no
+// filter parameters for this endpoint, so filters are applied to the
+// response by the client.
+type ListCustomAttestationTypesOptions struct {
+	IncludeArchived bool   // Also request archived types from the API
+	Archived        *bool  // Only types with this archived status; nil returns both
+	NamePrefix      string // Only types whose name starts with this prefix
+}
+
+// matches reports whether at passes the filters in opts.
+func (opts *ListCustomAttestationTypesOptions) matches(at *CustomAttestationType) bool {
+	if opts.Archived != nil && at.Archived != *opts.Archived {
+		return false
+	}
+	return strings.HasPrefix(at.Name, opts.NamePrefix)
+}
+
+// ListCustomAttestationTypes retrieves the custom attestation types for the
+// organization. Pass nil opts to retrieve all of them.
+func (c *Client) ListCustomAttestationTypes(ctx context.Context, opts *ListCustomAttestationTypesOptions) ([]CustomAttestationType, error) {
 	// Build path
-	path := fmt.Sprintf("/custom-attestation-types/%s", c.Organization())
+	path := fmt.Sprintf("/custom-attestation-types/%s", c.OrganizationFor(ctx))
+
+	// Add optional include_archived query parameter
+	if opts != nil {
+		path = newQuery().Bool("include_archived", opts.IncludeArchived).Path(path)
+	}
 
 	// Call API
 	resp, err := c.Get(ctx, path)
@@ -229,9 +267,20 @@ func (c *Client) ListCustomAttestationTypes(ctx context.Context) ([]CustomAttest
 		return nil, err
 	}
 
+	// Apply filters before transforming so skipped items are not parsed
+	if opts != nil {
+		filtered := result[:0]
+		for i := range result {
+			if opts.matches(&result[i]) {
+				filtered = append(filtered, result[i])
+			}
+		}
+		result = filtered
+	}
+
 	// Transform each item from API format to user format
 	for i := range result {
-		if err := result[i].fromAPIFormat(); err != nil {
+		if err := result[i].fromAPIFormat(nil); err != nil {
 			return nil, fmt.Errorf("failed to transform item %d: %w", i, err)
 		}
 	}
@@ -242,7 +291,7 @@ func (c *Client) ListCustomAttestationTypes(ctx context.Context) ([]CustomAttest
 // ArchiveCustomAttestationType archives a custom attestation type.
 func (c *Client) ArchiveCustomAttestationType(ctx context.Context, name string) error {
 	// Build path
-	path := fmt.Sprintf("/custom-attestation-types/%s/%s/archive", c.Organization(), name)
+	path := fmt.Sprintf("/custom-attestation-types/%s/%s/archive", c.OrganizationFor(ctx), name)
 
 	// Call API with no body
 	resp, err := c.Put(ctx, path, nil)
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -402,7 +403,7 @@ func TestListCustomAttestationTypes_Success(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	result, err := client.ListCustomAttestationTypes(context.Background())
+	result, err := client.ListCustomAttestationTypes(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -459,7 +460,7 @@ func TestListCustomAttestationTypes_Empty(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	result, err := client.ListCustomAttestationTypes(context.Background())
+	result, err := client.ListCustomAttestationTypes(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -591,7 +592,7 @@ func TestTransformation_FromAPIFormat(t *testing.T) {
 		},
 	}
 
-	if err := at.fromAPIFormat(); err != nil {
+	if err := at.fromAPIFormat(nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -614,6 +615,54 @@ func TestTransformation_FromAPIFormat(t *testing.T) {
 	}
 }
 
+// TestTransformation_FromAPIFormat_KnownSchema tests that an unchanged
+// schema is taken from a previous read instead of being normalized again
+func TestTransformation_FromAPIFormat_KnownSchema(t *testing.T) {
+	newType := func(schema string) *CustomAttestationType {
+		return &CustomAttestationType{Versions: []Version{{
+			Version:    2,
+			TypeSchema: json.RawMessage(schema),
+			Evaluator:  &Evaluator{ContentType: "jq", Rules: []string{".coverage >= 80"}},
+		}}}
+	}
+
+	previous := newType(`{"type": "object"}`)
+	if err := previous.fromAPIFormat(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(previous.RawSchemaSHA256) != 64 {
+		t.Fatalf("expected a hex-encoded SHA-256, got %q", previous.RawSchemaSHA256)
+	}
+	known := &KnownSchema{RawSHA256: previous.RawSchemaSHA256, Schema: "{\n  \"type\": \"object\"\n}"}
+
+	// Unchanged: the known schema is returned as is
+	at := newType(`{"type": "object"}`)
+	if err := at.fromAPIFormat(known); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if at.Schema != known.Schema || len(at.JqRules) != 1 {
+		t.Errorf("expected the known schema and the jq rules, got %q and %v", at.Schema, at.JqRules)
+	}
+
+	// Changed: the new schema is normalized
+	at = newType(`{"type": "array"}`)
+	if err := at.fromAPIFormat(known); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if at.Schema != `{"type":"array"}` || at.RawSchemaSHA256 == known.RawSHA256 {
+		t.Errorf("expected the changed schema to be normalized, got %q (hash %q)", at.Schema, at.RawSchemaSHA256)
+	}
+
+	// No schema has no hash, so a known schema never applies
+	at = &CustomAttestationType{Versions: []Version{{Version: 3}}}
+	if err := at.fromAPIFormat(&KnownSchema{Schema: `{"type":"object"}`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if at.Schema != "" || at.RawSchemaSHA256 != "" {
+		t.Errorf("expected no schema, got %q (hash %q)", at.Schema, at.RawSchemaSHA256)
+	}
+}
+
 // TestTransformation_FromAPIFormat_JSONObject tests the new API format where type_schema
 // is returned as a JSON object rather than a Python repr string.
 func TestTransformation_FromAPIFormat_JSONObject(t *testing.T) {
@@ -632,7 +681,7 @@ func TestTransformation_FromAPIFormat_JSONObject(t *testing.T) {
 		},
 	}
 
-	if err := at.fromAPIFormat(); err != nil {
+	if err := at.fromAPIFormat(nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -662,7 +711,7 @@ func TestTransformation_FromAPIFormat_NonJQ(t *testing.T) {
 		},
 	}
 
-	if err := at.fromAPIFormat(); err != nil {
+	if err := at.fromAPIFormat(nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -693,3 +742,182 @@ func TestTransformation_EmptyRules(t *testing.T) {
 		t.Error("expected evaluator to be absent when jq_rules is empty")
 	}
 }
+
+// TestCustomAttestationType_VersionContent tests extracting the content of a specific version
+func TestCustomAttestationType_VersionContent(t *testing.T) {
+	at := &CustomAttestationType{
+		Name: "test-type",
+		Versions: []Version{
+			{
+				Version:    2,
+				TypeSchema: json.RawMessage(`{"type": "object", "required": ["coverage"]}`),
+				Evaluator:  &Evaluator{ContentType: "jq", Rules: []string{".coverage >= 90"}},
+			},
+			{
+				Version:    1,
+				TypeSchema: json.RawMessage(`{"type": "object"}`),
+				Evaluator:  &Evaluator{ContentType: "jq", Rules: []string{".coverage >= 80"}},
+			},
+		},
+	}
+
+	schema, rules, err := at.VersionContent(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema != `{"type":"object"}` {
+		t.Errorf("expected schema of version 1, got %s", schema)
+	}
+	if len(rules) != 1 || rules[0] != ".coverage >= 80" {
+		t.Errorf("expected rules of version 1, got %v", rules)
+	}
+
+	if _, _, err := at.VersionContent(3); err == nil || !strings.Contains(err.Error(), "version 3") {
+		t.Errorf("expected version not found error, got %v", err)
+	}
+}
+
+// TestListCustomAttestationTypes_Filters tests archived and name prefix filtering
+func TestListCustomAttestationTypes_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"name": "security-scan", "archived": false, "versions": []},
+			{"name": "security-audit", "archived": true, "versions": []},
+			{"name": "coverage", "archived": false, "versions": []}
+		]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	active := false
+	tests := []struct {
+		name string
+		opts *ListCustomAttestationTypesOptions
+		want []string
+	}{
+		{"no filters", &ListCustomAttestationTypesOptions{}, []string{"security-scan", "security-audit", "coverage"}},
+		{"name prefix", &ListCustomAttestationTypesOptions{NamePrefix: "security-"}, []string{"security-scan", "security-audit"}},
+		{"active only", &ListCustomAttestationTypesOptions{Archived: &active}, []string{"security-scan", "coverage"}},
+		{"both", &ListCustomAttestationTypesOptions{Archived: &active, NamePrefix: "security-"}, []string{"security-scan"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.ListCustomAttestationTypes(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var got []string
+			for _, at := range result {
+				got = append(got, at.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestListCustomAttestationTypes_IncludeArchived tests that archived types
+// are only requested when asked for
+func TestListCustomAttestationTypes_IncludeArchived(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *ListCustomAttestationTypesOptions
+		wantQuery string
+	}{
+		{"nil opts", nil, ""},
+		{"active", &ListCustomAttestationTypesOptions{}, ""},
+		{"include archived", &ListCustomAttestationTypesOptions{IncludeArchived: true}, "include_archived=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %q, got %q", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if _, err := client.ListCustomAttestationTypes(context.Background(), tt.opts); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+// benchmarkSchemaSizes are the schema sizes, in bytes, benchmarks of schema
+// handling run with, up to the largest schema the API accepts.
+var benchmarkSchemaSizes = []int{1 << 20, MaxCustomAttestationTypeSchemaSize}
+
+// largeSchema returns an indented JSON Schema of about size bytes, made of
+// many described properties like a generated schema would be.
+func largeSchema(size int) []byte {
+	var b strings.Builder
+	b.WriteString("{\n  \"type\": \"object\",\n  \"properties\": {\n")
+	for i := 0; b.Len() < size-64; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, `    "field_%d": {"type": "number", "minimum": 0, "maximum": %d, "description": "Measurement %d"}`, i, i*10, i)
+	}
+	b.WriteString("\n  }\n}")
+	return []byte(b.String())
+}
+
+func BenchmarkFromAPIFormat(b *testing.B) {
+	for _, size := range benchmarkSchemaSizes {
+		schema := largeSchema(size)
+		newType := func() *CustomAttestationType {
+			return &CustomAttestationType{Versions: []Version{{
+				Version:    1,
+				TypeSchema: schema,
+				Evaluator:  &Evaluator{ContentType: "jq", Rules: []string{".field_0 >= 0"}},
+			}}}
+		}
+
+		// An unchanged schema is reused from a previous read
+		previous := newType()
+		if err := previous.fromAPIFormat(nil); err != nil {
+			b.Fatal(err)
+		}
+		known := &KnownSchema{RawSHA256: previous.RawSchemaSHA256, Schema: previous.Schema}
+
+		for _, bm := range []struct {
+			name  string
+			known *KnownSchema
+		}{
+			{"changed", nil},
+			{"unchanged", known},
+		} {
+			b.Run(fmt.Sprintf("%dMB/%s", size>>20, bm.name), func(b *testing.B) {
+				b.SetBytes(int64(len(schema)))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := newType().fromAPIFormat(bm.known); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
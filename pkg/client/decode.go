@@ -0,0 +1,70 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// strictDecodingKey is the context key recording whether responses to the
+// request should be decoded strictly. See WithStrictDecoding.
+type strictDecodingKey struct{}
+
+// WithStrictDecoding makes ParseResponse fail when a response contains a
+// field the target type doesn't declare. By default such fields are ignored
+// and logged as a warning. Strict decoding is meant for development and
+// acceptance tests, to surface API changes before they reach users.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) error {
+		c.strictDecoding = true
+		return nil
+	}
+}
+
+// decodeJSON unmarshals body into v. If body has fields that v's type
+// doesn't declare, it returns an error when ctx asks for strict decoding and
+// logs a warning otherwise.
+func decodeJSON(ctx context.Context, body []byte, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+
+	field := unknownField(body, v)
+	if field == "" {
+		return nil
+	}
+	if strict, _ := ctx.Value(strictDecodingKey{}).(bool); strict {
+		return fmt.Errorf("unknown field %s in %T", field, v)
+	}
+	contextLogger(ctx).WarnContext(ctx, "Kosli API response contains a field the provider doesn't recognize",
+		"field", field,
+		"target_type", fmt.Sprintf("%T", v),
+	)
+	return nil
+}
+
+// unknownField returns the quoted name of the first field in body that v's
+// type doesn't declare, or "" if there is none. body is decoded into a fresh
+// value so v is left untouched.
+func unknownField(body []byte, v any) string {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return ""
+	}
+	probe := reflect.New(t.Elem()).Interface()
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(probe)
+	if err == nil {
+		return ""
+	}
+	// encoding/json doesn't export a type for this error.
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return field
+	}
+	return ""
+}
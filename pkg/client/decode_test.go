@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestParseResponse_UnknownFields tests lenient and strict handling of unexpected response fields.
+func TestParseResponse_UnknownFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production", "type": "K8S", "brand_new_field": true}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr bool
+	}{
+		{name: "lenient by default", wantErr: false},
+		{name: "strict", opts: []ClientOption{WithStrictDecoding()}, wantErr: true},
+		{name: "strict with retries", opts: []ClientOption{WithStrictDecoding(), WithRetryPolicy(1, DefaultRetryWaitMin, DefaultRetryWaitMax)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			opts := append([]ClientOption{WithBaseURL(server.URL), WithAPIPath(""), WithLogger(jsonLogger(&output))}, tt.opts...)
+			client, err := NewClient("test-token", "test-org", opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			resp, err := client.Get(context.Background(), "/environments/test-org/production")
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+
+			var env Environment
+			err = ParseResponse(resp, &env)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), `"brand_new_field"`) {
+					t.Fatalf("expected unknown field error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if env.Name != "production" || env.Type != "K8S" {
+				t.Errorf("expected known fields to be decoded, got %+v", env)
+			}
+			if !strings.Contains(output.String(), "brand_new_field") {
+				t.Errorf("expected a warning naming the unknown field, got %q", output.String())
+			}
+		})
+	}
+}
+
+// TestUnknownField tests detection of undeclared fields.
+func TestUnknownField(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		v    any
+		want string
+	}{
+		{name: "all fields known", body: `{"name": "a"}`, v: &Environment{}, want: ""},
+		{name: "unknown top-level field", body: `{"name": "a", "extra": 1}`, v: &Environment{}, want: `"extra"`},
+		{name: "unknown field in list element", body: `[{"name": "a", "extra": 1}]`, v: &[]Environment{}, want: `"extra"`},
+		{name: "map target accepts anything", body: `{"extra": 1}`, v: &map[string]any{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unknownField([]byte(tt.body), tt.v); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
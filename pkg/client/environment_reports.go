@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReportedArtifact is an artifact running in an environment, as reported in
+// a snapshot.
+type ReportedArtifact struct {
+	// Name identifies the artifact in the environment: a file path for
+	// server environments, an object key for S3, a function name for
+	// lambda, a pod name for K8S, a task ARN for ECS and a container name for
+	// docker.
+	Name string
+
+	// Fingerprint is the SHA256 digest of the artifact. Optional when
+	// Digests is set.
+	Fingerprint string
+
+	// Digests are the SHA256 digests of what runs as part of the artifact,
+	// keyed by name, e.g. the images of the containers of a pod.
+	Digests map[string]string
+}
+
+// artifactNameFields are the fields that carry ReportedArtifact.Name in the
+// reports of the environment types that have one.
+var artifactNameFields = map[string]string{
+	"K8S":    "podName",
+	"ECS":    "taskArn",
+	"docker": "containerName",
+	"lambda": "functionName",
+}
+
+// environmentReport is the API format of an environment snapshot report.
+type environmentReport struct {
+	Type      string                `json:"type"`
+	Artifacts []environmentArtifact `json:"artifacts"`
+}
+
+// environmentArtifact is the API format of a reported artifact: its digests
+// and, for some environment types, its name (see artifactNameFields).
+type environmentArtifact map[string]any
+
+// newEnvironmentReport converts artifacts into the report format of
+// environments of type envType.
+func newEnvironmentReport(envType string, artifacts []ReportedArtifact) *environmentReport {
+	report := &environmentReport{
+		Type:      envType,
+		Artifacts: make([]environmentArtifact, 0, len(artifacts)),
+	}
+	for _, a := range artifacts {
+		digests := make(map[string]string, len(a.Digests)+1)
+		for name, digest := range a.Digests {
+			digests[name] = digest
+		}
+		if a.Fingerprint != "" {
+			digests[a.Name] = a.Fingerprint
+		}
+
+		artifact := environmentArtifact{"digests": digests}
+		if field, ok := artifactNameFields[envType]; ok {
+			artifact[field] = a.Name
+		}
+		report.Artifacts = append(report.Artifacts, artifact)
+	}
+	return report
+}
+
+// ReportEnvironment reports a snapshot of the artifacts running in an
+// environment of type envType. The reported artifacts replace those of
+// the previous snapshot. The API returns "OK", not the snapshot.
+func (c *Client) ReportEnvironment(ctx context.Context, envName, envType string, artifacts []ReportedArtifact) error {
+	path := fmt.Sprintf("/environments/%s/%s/report/%s", c.OrganizationFor(ctx), envName, envType)
+
+	resp, err := c.Put(ctx, path, newEnvironmentReport(envType, artifacts))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestReportEnvironment_Server tests reporting the artifacts of a server environment
+func TestReportEnvironment_Server(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if r.URL.Path != "/environments/test-org/prod-servers/report/server" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		want := map[string]any{
+			"type": "server",
+			"artifacts": []any{
+				map[string]any{"digests": map[string]any{"/opt/app/app.jar": "sha256-a"}},
+				map[string]any{"digests": map[string]any{"/opt/app/agent.jar": "sha256-b"}},
+			},
+		}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("expected body %v, got %v", want, body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.ReportEnvironment(context.Background(), "prod-servers", "server", []ReportedArtifact{
+		{Name: "/opt/app/app.jar", Fingerprint: "sha256-a"},
+		{Name: "/opt/app/agent.jar", Fingerprint: "sha256-b"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestReportEnvironment_Lambda tests that lambda artifacts carry their function name
+func TestReportEnvironment_Lambda(t *testing.T) {
+	report := newEnvironmentReport("lambda", []ReportedArtifact{{Name: "checkout", Fingerprint: "sha256-c"}})
+
+	if len(report.Artifacts) != 1 || report.Artifacts[0]["functionName"] != "checkout" {
+		t.Errorf("expected function name 'checkout', got %+v", report.Artifacts)
+	}
+}
+
+// TestReportEnvironment_K8S tests reporting pods by their container image digests
+func TestReportEnvironment_K8S(t *testing.T) {
+	report := newEnvironmentReport("K8S", []ReportedArtifact{{
+		Name:    "checkout-7d9f8",
+		Digests: map[string]string{"registry.example.com/checkout:1.2.3": "sha256-d", "registry.example.com/envoy:1.30": "sha256-e"},
+	}})
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("failed to marshal report: %v", err)
+	}
+	want := `{"type":"K8S","artifacts":[{"digests":{"registry.example.com/checkout:1.2.3":"sha256-d","registry.example.com/envoy:1.30":"sha256-e"},"podName":"checkout-7d9f8"}]}`
+	if string(data) != want {
+		t.Errorf("expected report %s, got %s", want, data)
+	}
+}
+
+// TestReportEnvironment_FingerprintAndDigests tests that the fingerprint is reported next to the digests
+func TestReportEnvironment_FingerprintAndDigests(t *testing.T) {
+	report := newEnvironmentReport("ECS", []ReportedArtifact{{
+		Name:        "checkout",
+		Fingerprint: "sha256-f",
+		Digests:     map[string]string{"sidecar": "sha256-g"},
+	}})
+
+	want := environmentArtifact{
+		"taskArn": "checkout",
+		"digests": map[string]string{"checkout": "sha256-f", "sidecar": "sha256-g"},
+	}
+	if !reflect.DeepEqual(report.Artifacts[0], want) {
+		t.Errorf("expected artifact %v, got %v", want, report.Artifacts[0])
+	}
+}
+
+// TestReportEnvironment_Empty tests that an empty environment is reported with an empty list
+func TestReportEnvironment_Empty(t *testing.T) {
+	report := newEnvironmentReport("S3", nil)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("failed to marshal report: %v", err)
+	}
+	if string(data) != `{"type":"S3","artifacts":[]}` {
+		t.Errorf("unexpected report: %s", data)
+	}
+}
+
+// TestReportEnvironment_NotFound tests reporting to an environment that does not exist
+func TestReportEnvironment_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Environment not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.ReportEnvironment(context.Background(), "missing", "server", nil)
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
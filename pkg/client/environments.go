@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sort"
 )
 
 // Environment represents a Kosli environment as returned by the API
@@ -47,10 +48,39 @@ type UpdateEnvironmentRequest struct {
 	IncludedEnvironments []string // for logical environments only; nil to omit
 }
 
-// ListEnvironments retrieves all environments for the organization.
-func (c *Client) ListEnvironments(ctx context.Context) ([]Environment, error) {
+// ListEnvironmentsOptions contains optional filters and pagination for
+// ListEnvironments. Filters are applied by the API, so callers don't need to
+// fetch and filter every environment in the organization.
+type ListEnvironmentsOptions struct {
+	Type            string            // Only environments of this type (e.g. "K8S", "logical")
+	IncludeArchived bool              // Also return archived environments
+	Tags            map[string]string // Only environments carrying all of these tags
+	Page            int               // 1-based page number; 0 returns all results
+	PerPage         int               // Page size when Page is set; 0 uses the API default
+}
+
+// ListEnvironments retrieves the environments for the organization. Pass nil
+// opts to retrieve all of them.
+func (c *Client) ListEnvironments(ctx context.Context, opts *ListEnvironmentsOptions) ([]Environment, error) {
 	// Build path: GET /api/v2/environments/{org}
-	path := fmt.Sprintf("/environments/%s", c.Organization())
+	path := fmt.Sprintf("/environments/%s", c.OrganizationFor(ctx))
+
+	// Add optional filter and pagination query parameters
+	if opts != nil {
+		tags := make([]string, 0, len(opts.Tags))
+		for key, value := range opts.Tags {
+			tags = append(tags, key+":"+value)
+		}
+		sort.Strings(tags)
+
+		path = newQuery().
+			String("type", opts.Type).
+			Bool("include_archived", opts.IncludeArchived).
+			Strings("tag", tags).
+			Int("page", opts.Page).
+			Int("per_page", opts.PerPage).
+			Path(path)
+	}
 
 	// Call API
 	resp, err := c.Get(ctx, path)
@@ -67,10 +97,49 @@ func (c *Client) ListEnvironments(ctx context.Context) ([]Environment, error) {
 	return result, nil
 }
 
+// DefaultEnvironmentsPageSize is the page size ListAllEnvironments uses when
+// neither opts.PerPage nor WithDefaultPageSize is set.
+const DefaultEnvironmentsPageSize = 100
+
+// ListAllEnvironments retrieves every environment matching opts, one page of
+// opts.PerPage environments (the client's default page size, or
+// DefaultEnvironmentsPageSize, if unset) at a time, so large organizations are not listed in one response. opts.Page is
+// ignored. Pass nil opts to retrieve all environments.
+func (c *Client) ListAllEnvironments(ctx context.Context, opts *ListEnvironmentsOptions) ([]Environment, error) {
+	pageOpts := ListEnvironmentsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	pageOpts.PerPage = c.pageSize(pageOpts.PerPage)
+	if pageOpts.PerPage <= 0 {
+		pageOpts.PerPage = DefaultEnvironmentsPageSize
+	}
+
+	var all []Environment
+	for pageOpts.Page = 1; ; pageOpts.Page++ {
+		page, err := c.ListEnvironments(ctx, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		// An API that ignores pagination returns the first page again;
+		// stop rather than loop forever
+		if pageOpts.Page > 1 && len(page) > 0 && len(all) > 0 && page[0].Name == all[0].Name {
+			break
+		}
+
+		all = append(all, page...)
+		if len(page) < pageOpts.PerPage {
+			break
+		}
+	}
+	return all, nil
+}
+
 // GetEnvironment retrieves a specific environment by name.
 func (c *Client) GetEnvironment(ctx context.Context, name string) (*Environment, error) {
 	// Build path: GET /api/v2/environments/{org}/{name}
-	path := fmt.Sprintf("/environments/%s/%s", c.Organization(), name)
+	path := fmt.Sprintf("/environments/%s/%s", c.OrganizationFor(ctx), name)
 
 	// Call API
 	resp, err := c.Get(ctx, path)
@@ -92,7 +161,7 @@ func (c *Client) GetEnvironment(ctx context.Context, name string) (*Environment,
 // The API returns "OK" (200 OK), not the created object.
 func (c *Client) CreateEnvironment(ctx context.Context, req *CreateEnvironmentRequest) error {
 	// Build path
-	path := fmt.Sprintf("/environments/%s", c.Organization())
+	path := fmt.Sprintf("/environments/%s", c.OrganizationFor(ctx))
 
 	// Build request body with proper JSON structure
 	body := map[string]any{
@@ -122,7 +191,7 @@ func (c *Client) CreateEnvironment(ctx context.Context, req *CreateEnvironmentRe
 // clear the field. See issue #122 for context.
 func (c *Client) UpdateEnvironment(ctx context.Context, name string, req *UpdateEnvironmentRequest) error {
 	// Build path: PATCH /api/v2/environments/{org}/{env_name}
-	path := fmt.Sprintf("/environments/%s/%s", c.Organization(), name)
+	path := fmt.Sprintf("/environments/%s/%s", c.OrganizationFor(ctx), name)
 
 	// Build request body. Only include optional fields when the caller
 	// provided them, so fields that don't apply to a given environment
@@ -151,7 +220,7 @@ func (c *Client) UpdateEnvironment(ctx context.Context, name string, req *Update
 // ArchiveEnvironment archives an environment (soft delete).
 func (c *Client) ArchiveEnvironment(ctx context.Context, name string) error {
 	// Build path
-	path := fmt.Sprintf("/environments/%s/%s/archive", c.Organization(), name)
+	path := fmt.Sprintf("/environments/%s/%s/archive", c.OrganizationFor(ctx), name)
 
 	// Call API with no body
 	resp, err := c.Put(ctx, path, nil)
@@ -3,8 +3,10 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -63,7 +65,7 @@ func TestListEnvironments_Success(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	environments, err := client.ListEnvironments(context.Background())
+	environments, err := client.ListEnvironments(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -669,6 +671,64 @@ func TestUpdateEnvironment_LogicalChangedEnvironments(t *testing.T) {
 	}
 }
 
+func TestListEnvironments_WithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *ListEnvironmentsOptions
+		wantQuery string
+	}{
+		{
+			name:      "nil options",
+			opts:      nil,
+			wantQuery: "",
+		},
+		{
+			name:      "empty options",
+			opts:      &ListEnvironmentsOptions{},
+			wantQuery: "",
+		},
+		{
+			name: "all options",
+			opts: &ListEnvironmentsOptions{
+				Type:            "K8S",
+				IncludeArchived: true,
+				Tags:            map[string]string{"team": "platform", "env": "prod"},
+				Page:            2,
+				PerPage:         50,
+			},
+			wantQuery: "include_archived=true&page=2&per_page=50&tag=env%3Aprod&tag=team%3Aplatform&type=K8S",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/environments/test-org" {
+					t.Errorf("expected path /environments/test-org, got %s", r.URL.Path)
+				}
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("expected query %q, got %q", tt.wantQuery, r.URL.RawQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if _, err := client.ListEnvironments(context.Background(), tt.opts); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
 // TestListEnvironments_WithLogical tests listing environments returns both physical and logical
 func TestListEnvironments_WithLogical(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -716,7 +776,7 @@ func TestListEnvironments_WithLogical(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	environments, err := client.ListEnvironments(context.Background())
+	environments, err := client.ListEnvironments(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -998,3 +1058,79 @@ func TestUpdateEnvironment_NotFound(t *testing.T) {
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+// TestListAllEnvironments_Pages tests that every page is requested until a
+// short page is returned
+func TestListAllEnvironments_Pages(t *testing.T) {
+	const total = 250
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		resp := []Environment{}
+		for i := (page - 1) * perPage; i < min(page*perPage, total); i++ {
+			resp = append(resp, Environment{Name: fmt.Sprintf("env-%03d", i), Type: "K8S"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	environments, err := client.ListAllEnvironments(context.Background(), &ListEnvironmentsOptions{Type: "K8S"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(environments) != total {
+		t.Fatalf("expected %d environments, got %d", total, len(environments))
+	}
+	if environments[total-1].Name != "env-249" {
+		t.Errorf("expected last environment 'env-249', got %s", environments[total-1].Name)
+	}
+	want := []string{"page=1&per_page=100&type=K8S", "page=2&per_page=100&type=K8S", "page=3&per_page=100&type=K8S"}
+	if strings.Join(queries, " ") != strings.Join(want, " ") {
+		t.Errorf("expected queries %v, got %v", want, queries)
+	}
+}
+
+// TestListAllEnvironments_PaginationIgnored tests that listing stops when the
+// API returns the first page again
+func TestListAllEnvironments_PaginationIgnored(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name": "env-a", "type": "K8S"}, {"name": "env-b", "type": "K8S"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	environments, err := client.ListAllEnvironments(context.Background(), &ListEnvironmentsOptions{PerPage: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(environments) != 2 {
+		t.Errorf("expected 2 environments, got %d", len(environments))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
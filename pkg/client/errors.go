@@ -16,16 +16,43 @@ type APIError struct {
 	// Message is the error message from the API.
 	Message string
 
-	// RequestID is the request ID for debugging (if provided by API).
+	// RequestID is the server-assigned request/correlation ID for debugging
+	// (if provided by API). See requestIDHeaders for the headers consulted.
 	RequestID string
 
+	// ClientRequestID is the ID the client sent with the request in the
+	// RequestIDHeader header.
+	ClientRequestID string
+
 	// Method is the HTTP method that was used.
 	Method string
 
 	// URL is the URL that was requested.
 	URL string
+
+	// Body is the raw response body, truncated to maxErrorBodySize bytes.
+	// It is kept verbatim (even when Message was extracted from JSON) so
+	// diagnostics and support tickets can include exactly what the server sent.
+	Body string
+
+	// Retryable reports whether the failure is transient and the same
+	// request may succeed if retried (429 and 5xx other than 501).
+	Retryable bool
 }
 
+// requestIDHeaders lists the response headers that may carry the server
+// request/correlation ID, in order of preference.
+var requestIDHeaders = []string{
+	"X-Request-ID",
+	"X-Kosli-Request-ID",
+	"X-Correlation-ID",
+	"X-Amzn-Trace-Id",
+}
+
+// maxErrorBodySize caps how much of an error response body is retained on
+// APIError.Body, so an unexpected HTML page doesn't bloat diagnostics.
+const maxErrorBodySize = 4096
+
 // Error implements the error interface.
 func (e *APIError) Error() string {
 	if e.Message != "" {
@@ -70,6 +97,19 @@ func IsForbidden(err error) bool {
 	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
 }
 
+// IsMethodNotAllowed returns true if the error is a 405 Method Not Allowed
+// error, e.g. because the API does not support an operation.
+func IsMethodNotAllowed(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed
+}
+
+// IsRetryable returns true if the error is an APIError marked as retryable.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Retryable
+}
+
 // IsServerError returns true if the error is a 5xx server error.
 func IsServerError(err error) bool {
 	var apiErr *APIError
@@ -89,17 +129,28 @@ func parseErrorResponse(resp *http.Response) error {
 		StatusCode: resp.StatusCode,
 		Method:     resp.Request.Method,
 		URL:        resp.Request.URL.String(),
-		RequestID:  resp.Header.Get("X-Request-ID"),
+		RequestID:  requestIDFromHeader(resp.Header),
+		Retryable:  isRetryableStatus(resp.StatusCode),
+
+		ClientRequestID: resp.Request.Header.Get(RequestIDHeader),
 	}
 
-	// Read response body
+	// Read response body. An oversized body is still reported from its
+	// beginning.
 	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	truncated := errors.Is(err, ErrResponseTooLarge)
+	if err != nil && !truncated {
 		// If we can't read the body, just use the status text
 		apiErr.Message = http.StatusText(resp.StatusCode)
 		return apiErr
 	}
 
+	if len(body) > maxErrorBodySize {
+		apiErr.Body = string(body[:maxErrorBodySize])
+	} else {
+		apiErr.Body = string(body)
+	}
+
 	// Try to parse as JSON error response
 	var errorResp apiErrorResponse
 	if err := json.Unmarshal(body, &errorResp); err == nil {
@@ -113,7 +164,7 @@ func parseErrorResponse(resp *http.Response) error {
 		}
 	} else {
 		// Not a JSON error, use the body as the message if it's not too long
-		if len(body) > 0 && len(body) < 500 {
+		if len(body) > 0 && len(body) < 500 && !truncated {
 			apiErr.Message = string(body)
 		} else {
 			apiErr.Message = http.StatusText(resp.StatusCode)
@@ -122,3 +173,23 @@ func parseErrorResponse(resp *http.Response) error {
 
 	return apiErr
 }
+
+// requestIDFromHeader returns the first non-empty request ID header value.
+func requestIDFromHeader(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// isRetryableStatus mirrors the status-code half of the retry policy:
+// 429 Too Many Requests and 5xx responses other than 501 Not Implemented
+// are considered transient.
+func isRetryableStatus(statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return statusCode >= 500 && statusCode != http.StatusNotImplemented && statusCode < 600
+}
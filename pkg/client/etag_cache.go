@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultETagCacheSize is a reasonable number of responses to keep for
+// conditional GETs; see WithETagCache.
+const DefaultETagCacheSize = 256
+
+// etagCache is a small LRU of GET responses that carried an ETag, keyed by
+// URL.
+type etagCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// etagEntry is a cached response.
+type etagEntry struct {
+	url    string
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// WithETagCache enables conditional GETs. Successful GET responses that carry
+// an ETag are kept (up to maxEntries, least recently used first out), and
+// repeated GETs of the same URL send If-None-Match. A 304 Not Modified reply
+// is returned to the caller as the cached 200 response, so callers don't need
+// to handle it. Freshness is decided by the server, so the cache never serves
+// stale data on its own.
+func WithETagCache(maxEntries int) ClientOption {
+	return func(c *Client) error {
+		if maxEntries < 1 {
+			return fmt.Errorf("etag cache size must be at least 1")
+		}
+		c.etags = &etagCache{
+			maxEntries: maxEntries,
+			order:      list.New(),
+			entries:    make(map[string]*list.Element),
+		}
+		return nil
+	}
+}
+
+// get returns the cached entry for url, marking it recently used.
+func (ec *etagCache) get(url string) (*etagEntry, bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	elem, ok := ec.entries[url]
+	if !ok {
+		return nil, false
+	}
+	ec.order.MoveToFront(elem)
+	return elem.Value.(*etagEntry), true
+}
+
+// put stores entry, evicting the least recently used entry if full.
+func (ec *etagCache) put(entry *etagEntry) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if elem, ok := ec.entries[entry.url]; ok {
+		elem.Value = entry
+		ec.order.MoveToFront(elem)
+		return
+	}
+	ec.entries[entry.url] = ec.order.PushFront(entry)
+	if ec.order.Len() > ec.maxEntries {
+		oldest := ec.order.Back()
+		ec.order.Remove(oldest)
+		delete(ec.entries, oldest.Value.(*etagEntry).url)
+	}
+}
+
+// remove drops the entry for url, if any.
+func (ec *etagCache) remove(url string) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if elem, ok := ec.entries[url]; ok {
+		ec.order.Remove(elem)
+		delete(ec.entries, url)
+	}
+}
+
+// conditional reports whether req is eligible for conditional-GET handling.
+func (c *Client) conditional(req *http.Request) bool {
+	return c.etags != nil && req.Method == http.MethodGet && req.Header.Get("If-None-Match") == ""
+}
+
+// addIfNoneMatch sets If-None-Match on req when a cached response exists.
+func (c *Client) addIfNoneMatch(req *http.Request) {
+	if entry, ok := c.etags.get(req.URL.String()); ok {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+}
+
+// resolveETag updates the cache from resp and, for a 304 Not Modified,
+// returns the cached response in its place.
+func (c *Client) resolveETag(req *http.Request, resp *http.Response) *http.Response {
+	url := req.URL.String()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		entry, ok := c.etags.get(url)
+		if !ok {
+			// Evicted after the request was sent; nothing to substitute.
+			return resp
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := bufferBody(resp)
+		if err != nil {
+			// Don't keep a body that didn't arrive in full
+			break
+		}
+		c.etags.put(&etagEntry{
+			url:    url,
+			etag:   resp.Header.Get("ETag"),
+			header: resp.Header.Clone(),
+			body:   body,
+		})
+	case resp.StatusCode == http.StatusNotFound:
+		c.etags.remove(url)
+	}
+	return resp
+}
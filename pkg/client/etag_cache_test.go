@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestClient_ETagCache tests that repeated GETs are revalidated and 304s return the cached body.
+func TestClient_ETagCache(t *testing.T) {
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithETagCache(10),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(context.Background(), "/environments/test-org/production")
+		if err != nil {
+			t.Fatalf("request %d: expected success, got %v", i, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("request %d: expected status 200, got %d", i, resp.StatusCode)
+		}
+		if string(body) != `{"name": "production"}` {
+			t.Errorf("request %d: unexpected body %q", i, body)
+		}
+		if resp.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request %d: expected cached headers, got %v", i, resp.Header)
+		}
+	}
+
+	if len(ifNoneMatch) != 2 || ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+		t.Errorf("expected second request to be conditional, got If-None-Match %q", ifNoneMatch)
+	}
+}
+
+// TestClient_ETagCacheSkipsWrites tests that only GETs are made conditional.
+func TestClient_ETagCacheSkipsWrites(t *testing.T) {
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithETagCache(10),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Put(context.Background(), "/environments/test-org", map[string]string{})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	for _, v := range ifNoneMatch {
+		if v != "" {
+			t.Errorf("expected no If-None-Match on writes, got %q", v)
+		}
+	}
+}
+
+// TestETagCache_Eviction tests that the least recently used entry is evicted.
+func TestETagCache_Eviction(t *testing.T) {
+	client, err := NewClient("test-token", "test-org", WithETagCache(2))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	cache := client.etags
+
+	cache.put(&etagEntry{url: "a", etag: "1"})
+	cache.put(&etagEntry{url: "b", etag: "2"})
+	cache.get("a")
+	cache.put(&etagEntry{url: "c", etag: "3"})
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	for _, url := range []string{"a", "c"} {
+		if _, ok := cache.get(url); !ok {
+			t.Errorf("expected %q to be cached", url)
+		}
+	}
+
+	cache.remove("a")
+	if _, ok := cache.get("a"); ok {
+		t.Error("expected removed entry to be gone")
+	}
+}
+
+// TestWithETagCache_InvalidSize tests that a non-positive size is rejected.
+func TestWithETagCache_InvalidSize(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithETagCache(0)); err == nil {
+		t.Error("expected error for zero cache size")
+	}
+}
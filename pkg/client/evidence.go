@@ -0,0 +1,200 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// EvidenceFileField is the multipart field attestation endpoints read
+// attached evidence files from.
+const EvidenceFileField = "evidence_file"
+
+// EvidenceFile is a file sent in a multipart upload, next to the data_json
+// field.
+type EvidenceFile struct {
+	// FieldName is the multipart field of the file, e.g. EvidenceFileField,
+	// "type_schema" or "template_file".
+	FieldName string
+
+	// FileName is the file name reported to the API.
+	FileName string
+
+	// Open returns the content of the file. It is called each time the
+	// request body is produced, including for retries and TRACE logging, and
+	// the content is copied into the request as it is sent rather than
+	// loaded up front.
+	Open func() (io.ReadCloser, error)
+}
+
+// EvidenceBytes returns an EvidenceFile with in-memory content.
+func EvidenceBytes(fieldName, fileName string, content []byte) EvidenceFile {
+	return EvidenceFile{
+		FieldName: fieldName,
+		FileName:  fileName,
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(content)), nil
+		},
+	}
+}
+
+// EvidenceFromPath returns an EvidenceFile read from a local file, named
+// after the file's base name.
+func EvidenceFromPath(fieldName, path string) EvidenceFile {
+	return EvidenceFile{
+		FieldName: fieldName,
+		FileName:  filepath.Base(path),
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}
+}
+
+// multipartUpload is a multipart/form-data body made of a data_json field
+// followed by files. The body is written while it is read, so large files
+// are never held in memory by the upload itself.
+type multipartUpload struct {
+	dataJSON []byte
+	files    []EvidenceFile
+	boundary string
+}
+
+// newMultipartUpload validates the files and prepares an upload of data
+// and files.
+func newMultipartUpload(data map[string]any, files []EvidenceFile) (*multipartUpload, error) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	for i, f := range files {
+		if f.FieldName == "" {
+			return nil, fmt.Errorf("evidence file %d (%q) has no field name", i, f.FileName)
+		}
+		if f.Open == nil {
+			return nil, fmt.Errorf("evidence file %d (%q) has no content", i, f.FileName)
+		}
+	}
+
+	return &multipartUpload{
+		dataJSON: dataJSON,
+		files:    files,
+		// Fixed per upload so every rendering of the body is identical
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+	}, nil
+}
+
+// contentType returns the Content-Type header value of the upload.
+func (u *multipartUpload) contentType() string {
+	return "multipart/form-data; boundary=" + u.boundary
+}
+
+// open returns a new reader of the body. Errors opening or reading a file
+// are returned by the reader. The reader must be closed.
+func (u *multipartUpload) open() (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(u.writeTo(pw))
+	}()
+	return pr, nil
+}
+
+// writeTo writes the body to w.
+func (u *multipartUpload) writeTo(w io.Writer) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(u.boundary); err != nil {
+		return fmt.Errorf("failed to set multipart boundary: %w", err)
+	}
+
+	if err := writer.WriteField("data_json", string(u.dataJSON)); err != nil {
+		return fmt.Errorf("failed to write data_json field: %w", err)
+	}
+
+	for _, f := range u.files {
+		if err := writeEvidenceFile(writer, f); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// writeEvidenceFile copies f into a new part of writer.
+func writeEvidenceFile(writer *multipart.Writer, f EvidenceFile) error {
+	content, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s file %q: %w", f.FieldName, f.FileName, err)
+	}
+	defer content.Close()
+
+	part, err := writer.CreateFormFile(f.FieldName, f.FileName)
+	if err != nil {
+		return fmt.Errorf("failed to create %s field: %w", f.FieldName, err)
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return fmt.Errorf("failed to write %s content: %w", f.FieldName, err)
+	}
+	return nil
+}
+
+// doMultipart sends data and files as a multipart/form-data request. Like
+// doRequest, it returns the response of a 2xx status for the caller to parse
+// and close, and an *APIError otherwise.
+func (c *Client) doMultipart(ctx context.Context, method, path string, data map[string]any, files []EvidenceFile) (*http.Response, error) {
+	upload, err := newMultipartUpload(data, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart request: %w", err)
+	}
+
+	body, err := upload.open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart request: %w", err)
+	}
+
+	// Create custom HTTP request (not using client.Post because it sends JSON)
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.GetBody = upload.open
+
+	req.Header.Set("Content-Type", upload.contentType())
+	if err := c.authorize(req); err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, parseErrorResponse(resp)
+	}
+
+	return resp, nil
+}
+
+// UploadEvidence POSTs an attestation's data_json and evidence files to an
+// attestation endpoint, given by its path relative to the API URL.
+func (c *Client) UploadEvidence(ctx context.Context, path string, data map[string]any, files []EvidenceFile) error {
+	resp, err := c.doMultipart(ctx, http.MethodPost, path, data, files)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// uploadedPart is a part of a received multipart upload.
+type uploadedPart struct {
+	field    string
+	fileName string
+	size     int64
+	content  []byte // only kept for small parts
+}
+
+// readUpload reads a multipart body part by part, without buffering it.
+func readUpload(t *testing.T, contentType string, body io.Reader) []uploadedPart {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("expected multipart/form-data, got %q (%v)", contentType, err)
+	}
+
+	var parts []uploadedPart
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+
+		var small bytes.Buffer
+		size, err := io.Copy(&small, io.LimitReader(part, 1<<10))
+		if err != nil {
+			t.Fatalf("failed to read part %q: %v", part.FormName(), err)
+		}
+		rest, err := io.Copy(io.Discard, part)
+		if err != nil {
+			t.Fatalf("failed to read part %q: %v", part.FormName(), err)
+		}
+
+		uploaded := uploadedPart{field: part.FormName(), fileName: part.FileName(), size: size + rest}
+		if rest == 0 {
+			uploaded.content = small.Bytes()
+		}
+		parts = append(parts, uploaded)
+	}
+}
+
+// zeroReader is an endless source of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// TestUploadEvidence_MultipleFiles tests field names, file names and order of the parts
+func TestUploadEvidence_MultipleFiles(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(reportPath, []byte(`{"issues":0}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var parts []uploadedPart
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/attestations/test-org/backend/trail/abc123/sonar" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		parts = readUpload(t, r.Header.Get("Content-Type"), r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.UploadEvidence(context.Background(), "/attestations/test-org/backend/trail/abc123/sonar",
+		map[string]any{"attestation_name": "code-quality"},
+		[]EvidenceFile{
+			EvidenceFromPath(EvidenceFileField, reportPath),
+			EvidenceBytes("sonar_results", "results.txt", []byte("passed")),
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []uploadedPart{
+		{field: "data_json", content: []byte(`{"attestation_name":"code-quality"}`)},
+		{field: EvidenceFileField, fileName: "report.json", content: []byte(`{"issues":0}`)},
+		{field: "sonar_results", fileName: "results.txt", content: []byte("passed")},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("expected %d parts, got %d: %+v", len(want), len(parts), parts)
+	}
+	for i, w := range want {
+		got := parts[i]
+		if got.field != w.field || got.fileName != w.fileName || !bytes.Equal(got.content, w.content) {
+			t.Errorf("part %d: expected %s %q %q, got %s %q %q", i, w.field, w.fileName, w.content, got.field, got.fileName, got.content)
+		}
+	}
+}
+
+// TestUploadEvidence_EmptyFile tests that an empty file is sent as an empty part
+func TestUploadEvidence_EmptyFile(t *testing.T) {
+	upload, err := newMultipartUpload(map[string]any{}, []EvidenceFile{
+		EvidenceBytes(EvidenceFileField, "empty.txt", nil),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	body, err := upload.open()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer body.Close()
+
+	parts := readUpload(t, upload.contentType(), body)
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[1].field != EvidenceFileField || parts[1].fileName != "empty.txt" || parts[1].size != 0 {
+		t.Errorf("expected an empty evidence file part, got %+v", parts[1])
+	}
+}
+
+// TestUploadEvidence_LargeFile tests that a large file is streamed rather than loaded up front
+func TestUploadEvidence_LargeFile(t *testing.T) {
+	const size = 256 << 20
+
+	opened := 0
+	upload, err := newMultipartUpload(map[string]any{}, []EvidenceFile{{
+		FieldName: EvidenceFileField,
+		FileName:  "large.bin",
+		Open: func() (io.ReadCloser, error) {
+			opened++
+			return io.NopCloser(io.LimitReader(zeroReader{}, size)), nil
+		},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if opened != 0 {
+		t.Fatal("expected the file not to be opened before the body is read")
+	}
+
+	body, err := upload.open()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer body.Close()
+
+	parts := readUpload(t, upload.contentType(), body)
+	if len(parts) != 2 || parts[1].size != size {
+		t.Fatalf("expected a %d byte evidence file part, got %+v", size, parts)
+	}
+}
+
+// TestUploadEvidence_RetryResendsFiles tests that a retried upload sends the complete body again
+func TestUploadEvidence_RetryResendsFiles(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		parts := readUpload(t, r.Header.Get("Content-Type"), r.Body)
+		if len(parts) != 2 || !bytes.Equal(parts[1].content, []byte("report")) {
+			t.Errorf("attempt %d: expected the complete body, got %+v", attempts, parts)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithRetryPolicy(3, 10*time.Millisecond, 100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.UploadEvidence(context.Background(), "/attestations/test-org/backend/trail/abc123/generic",
+		map[string]any{}, []EvidenceFile{EvidenceBytes(EvidenceFileField, "report.txt", []byte("report"))})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+// TestUploadEvidence_MissingFile tests that a file that cannot be opened fails the upload
+func TestUploadEvidence_MissingFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	err = client.UploadEvidence(context.Background(), "/attestations/test-org/backend/trail/abc123/generic",
+		map[string]any{}, []EvidenceFile{EvidenceFromPath(EvidenceFileField, missing)})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("expected error to name the file, got %v", err)
+	}
+}
+
+// TestUploadEvidence_InvalidFile tests validation of evidence files before sending
+func TestUploadEvidence_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name string
+		file EvidenceFile
+	}{
+		{"no field name", EvidenceBytes("", "report.txt", []byte("report"))},
+		{"no content", EvidenceFile{FieldName: EvidenceFileField, FileName: "report.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newMultipartUpload(map[string]any{}, []EvidenceFile{tt.file}); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestUploadEvidence_BadRequest tests that API errors are returned as *APIError
+func TestUploadEvidence_BadRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Input payload validation failed"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.UploadEvidence(context.Background(), "/attestations/test-org/backend/trail/abc123/generic", map[string]any{}, nil)
+	if !IsBadRequest(err) {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+}
+
+func BenchmarkMultipartUpload(b *testing.B) {
+	for _, size := range benchmarkSchemaSizes {
+		schema := largeSchema(size)
+		req := &CreateCustomAttestationTypeRequest{Name: "coverage", Description: "Code coverage", JqRules: []string{".field_0 >= 0"}}
+		files := []EvidenceFile{EvidenceBytes("type_schema", "schema.json", schema)}
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(schema)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				upload, err := newMultipartUpload(req.toAPIFormat(), files)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := upload.writeTo(io.Discard); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCreatePolicyMultipartRequest(b *testing.B) {
+	for _, size := range benchmarkSchemaSizes {
+		content := strings.Repeat("_schema: https://kosli.com/schemas/policy/environment/v1\n", size/57)
+		payload := map[string]any{"name": "prod-requirements", "description": "Production requirements", "type": "env"}
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(len(content)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := createPolicyMultipartRequest(payload, content); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,12 +1,8 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"mime/multipart"
 	"net/http"
 )
 
@@ -27,42 +23,6 @@ type CreateFlowRequest struct {
 	Template    string // Optional YAML template content; when empty, template_file is omitted from the multipart request
 }
 
-// createFlowMultipartRequest builds a multipart/form-data body for flow creation.
-// Fields:
-//   - data_json: JSON with name, description, visibility
-//   - template_file: YAML template content (only included when template is non-empty)
-func createFlowMultipartRequest(payload map[string]any, template string) (io.Reader, string, error) {
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
-	// Add data_json field (JSON metadata)
-	payloadJSON, err := json.Marshal(payload)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to marshal payload: %w", err)
-	}
-	if err := writer.WriteField("data_json", string(payloadJSON)); err != nil {
-		return nil, "", fmt.Errorf("failed to write data_json field: %w", err)
-	}
-
-	// Add template_file field only when template is provided
-	if template != "" {
-		part, err := writer.CreateFormFile("template_file", "template.yml")
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create template_file field: %w", err)
-		}
-		if _, err := part.Write([]byte(template)); err != nil {
-			return nil, "", fmt.Errorf("failed to write template_file content: %w", err)
-		}
-	}
-
-	contentType := writer.FormDataContentType()
-	if err := writer.Close(); err != nil {
-		return nil, "", fmt.Errorf("failed to close multipart writer: %w", err)
-	}
-
-	return &buf, contentType, nil
-}
-
 // CreateFlow creates or updates a flow via a multipart/form-data PUT request.
 // The request always includes a data_json field with flow metadata (name, description, visibility).
 // The template_file field is conditionally included when a YAML template is provided.
@@ -73,38 +33,26 @@ func (c *Client) CreateFlow(ctx context.Context, req *CreateFlowRequest) error {
 		"visibility":  req.Visibility,
 	}
 
-	path := fmt.Sprintf("/flows/%s/template_file", c.Organization())
-
-	body, contentType, err := createFlowMultipartRequest(payload, req.Template)
-	if err != nil {
-		return fmt.Errorf("failed to create multipart request: %w", err)
+	// Attach the template file only when a template is provided
+	var files []EvidenceFile
+	if req.Template != "" {
+		files = append(files, EvidenceBytes("template_file", "template.yml", []byte(req.Template)))
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, c.apiURL+path, body)
-	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %w", err)
-	}
+	path := fmt.Sprintf("/flows/%s/template_file", c.OrganizationFor(ctx))
 
-	httpReq.Header.Set("Content-Type", contentType)
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
-	httpReq.Header.Set("User-Agent", c.userAgent)
-
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.doMultipart(ctx, http.MethodPut, path, payload, files)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return parseErrorResponse(resp)
+		return err
 	}
+	resp.Body.Close()
 
 	return nil
 }
 
 // GetFlow retrieves a specific flow by name.
 func (c *Client) GetFlow(ctx context.Context, name string) (*Flow, error) {
-	path := fmt.Sprintf("/flows/%s/%s", c.Organization(), name)
+	path := fmt.Sprintf("/flows/%s/%s", c.OrganizationFor(ctx), name)
 
 	resp, err := c.Get(ctx, path)
 	if err != nil {
@@ -121,7 +69,7 @@ func (c *Client) GetFlow(ctx context.Context, name string) (*Flow, error) {
 
 // ListFlows retrieves all flows for the organization.
 func (c *Client) ListFlows(ctx context.Context) ([]Flow, error) {
-	path := fmt.Sprintf("/flows/%s", c.Organization())
+	path := fmt.Sprintf("/flows/%s", c.OrganizationFor(ctx))
 
 	resp, err := c.Get(ctx, path)
 	if err != nil {
@@ -138,7 +86,7 @@ func (c *Client) ListFlows(ctx context.Context) ([]Flow, error) {
 
 // ArchiveFlow archives a flow (soft delete).
 func (c *Client) ArchiveFlow(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/flows/%s/%s/archive", c.Organization(), name)
+	path := fmt.Sprintf("/flows/%s/%s/archive", c.OrganizationFor(ctx), name)
 
 	resp, err := c.Put(ctx, path, nil)
 	if err != nil {
@@ -148,3 +96,18 @@ func (c *Client) ArchiveFlow(ctx context.Context, name string) error {
 
 	return nil
 }
+
+// DeleteFlow permanently deletes a flow with its trails. Unlike ArchiveFlow,
+// a deleted flow cannot be restored. The API responds 405 Method Not Allowed
+// where it does not support deleting flows; see IsMethodNotAllowed.
+func (c *Client) DeleteFlow(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/flows/%s/%s", c.OrganizationFor(ctx), name)
+
+	resp, err := c.Delete(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LevelTrace is the level of the client's most detailed log entries, which
+// include redacted request and response headers and bodies. It is below
+// slog.LevelDebug, so handlers must be configured for it explicitly.
+const LevelTrace = slog.LevelDebug - 4
+
+// WithLogger sends the client's log entries to logger: a DEBUG summary of
+// every request and response, LevelTrace details with redacted headers and
+// bodies, and warnings such as unrecognized response fields. Entries are
+// logged with the request's context, so a handler can route them by context,
+// as the Terraform provider does to forward them to tflog. Without this
+// option the client doesn't log.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			return fmt.Errorf("logger cannot be nil")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// loggerKey carries the client's logger in request contexts, so code that
+// only sees a request or response, such as response decoding, logs through
+// it.
+type loggerKey struct{}
+
+// contextLogger returns the logger of the client that issued the request ctx
+// belongs to, or a logger that discards everything.
+func contextLogger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return discardLogger
+}
+
+// discardLogger is the logger of clients created without WithLogger.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// maxLoggedBodySize caps how much of a request or response body is included
+// in TRACE logs.
+const maxLoggedBodySize = 16 * 1024
+
+// redactedValue replaces secrets in logged headers and bodies.
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders are logged with their values redacted.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Proxy-Authorization": true,
+	"X-Api-Key":           true,
+}
+
+// tokenPatterns match token-looking strings in logged bodies: bearer
+// credentials, JSON fields whose names suggest secrets, and JWTs.
+var tokenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`(?i)("[a-z_]*(?:token|secret|password|api_key|apikey)[a-z_]*"\s*:\s*")[^"]*`),
+	regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`),
+}
+
+// redact removes the client's API token and any token-looking strings from s.
+func (c *Client) redact(s string) string {
+	if c.apiToken != "" {
+		s = strings.ReplaceAll(s, c.apiToken, redactedValue)
+	}
+	if token, _ := c.sourcedToken.Load().(string); token != "" {
+		s = strings.ReplaceAll(s, token, redactedValue)
+	}
+	for _, re := range tokenPatterns {
+		if re.NumSubexp() > 0 {
+			s = re.ReplaceAllString(s, "${1}"+redactedValue)
+		} else {
+			s = re.ReplaceAllString(s, redactedValue)
+		}
+	}
+	return s
+}
+
+// redactHeaders flattens h for logging, redacting sensitive header values.
+func (c *Client) redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			out[name] = redactedValue
+			continue
+		}
+		out[name] = c.redact(strings.Join(values, ", "))
+	}
+	return out
+}
+
+// loggableBody returns a redacted, size-capped rendering of body for TRACE
+// logs. Non-JSON bodies (e.g. multipart uploads) are summarized by size only.
+func (c *Client) loggableBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !strings.Contains(contentType, "json") && !strings.HasPrefix(contentType, "text/") {
+		return fmt.Sprintf("<%s body, %d bytes>", contentType, len(body))
+	}
+	if len(body) > maxLoggedBodySize {
+		return c.redact(string(body[:maxLoggedBodySize])) + "...<truncated>"
+	}
+	return c.redact(string(body))
+}
+
+// logRequest emits a DEBUG summary and a TRACE entry with redacted headers
+// and body for an outgoing request, through the logger set by WithLogger.
+func (c *Client) logRequest(ctx context.Context, req *http.Request) {
+	c.logger.DebugContext(ctx, "Sending Kosli API request",
+		"http_method", req.Method,
+		"http_path", req.URL.Path,
+		"request_id", req.Header.Get(RequestIDHeader),
+	)
+
+	// Details are only rendered when they will be logged
+	if !c.logger.Enabled(ctx, LevelTrace) {
+		return
+	}
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(io.LimitReader(rc, maxLoggedBodySize+1))
+			rc.Close()
+		}
+	}
+	c.logger.Log(ctx, LevelTrace, "Kosli API request details",
+		"http_method", req.Method,
+		"http_url", c.redact(req.URL.String()),
+		"http_req_headers", c.redactHeaders(req.Header),
+		"http_req_body", c.loggableBody(req.Header.Get("Content-Type"), body),
+	)
+}
+
+// logResponse emits a DEBUG summary and a TRACE entry with redacted headers
+// and body for a completed request. When TRACE entries are enabled, the
+// response body is buffered so it can be logged and still be read by the
+// caller; otherwise it is left untouched.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	fields := []any{
+		"http_method", req.Method,
+		"http_path", req.URL.Path,
+		"request_id", req.Header.Get(RequestIDHeader),
+		"duration_ms", elapsed.Milliseconds(),
+	}
+	if err != nil {
+		c.logger.DebugContext(ctx, "Kosli API request failed", append(fields, "error", c.redact(err.Error()))...)
+		return
+	}
+	c.logger.DebugContext(ctx, "Received Kosli API response", append(fields, "http_status", resp.StatusCode)...)
+
+	// Buffering the body costs memory and latency on every response, so
+	// only do it when the body will be logged
+	if !c.logger.Enabled(ctx, LevelTrace) {
+		return
+	}
+	body, _ := bufferBody(resp)
+	c.logger.Log(ctx, LevelTrace, "Kosli API response details",
+		"http_method", req.Method,
+		"http_path", req.URL.Path,
+		"http_status", resp.StatusCode,
+		"http_resp_headers", c.redactHeaders(resp.Header),
+		"http_resp_body", c.loggableBody(resp.Header.Get("Content-Type"), body),
+	)
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// jsonLogger returns a logger that writes every entry, down to LevelTrace,
+// to w as JSON lines.
+func jsonLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: LevelTrace}))
+}
+
+// decodeLogEntries decodes the JSON lines written by jsonLogger.
+func decodeLogEntries(t *testing.T, r io.Reader) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var entry map[string]any
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatalf("failed to decode log output: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// TestClient_Logging tests that requests and responses are logged with secrets redacted.
+func TestClient_Logging(t *testing.T) {
+	const token = "super-secret-token-value"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "prod", "api_token": "leaked-value"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	client, err := NewClient(token, "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithLogger(jsonLogger(&output)),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Post(context.Background(), "/environments/test-org", map[string]any{
+		"name":     "prod",
+		"password": "hunter2",
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	// The response body must still be readable after being logged.
+	var result map[string]any
+	if err := ParseResponse(resp, &result); err != nil {
+		t.Fatalf("failed to parse response after logging: %v", err)
+	}
+	if result["name"] != "prod" {
+		t.Errorf("expected name prod, got %v", result["name"])
+	}
+
+	logged := output.String()
+	messages := map[string]bool{}
+	for _, entry := range decodeLogEntries(t, &output) {
+		messages[entry["msg"].(string)] = true
+	}
+	for _, want := range []string{
+		"Sending Kosli API request",
+		"Kosli API request details",
+		"Received Kosli API response",
+		"Kosli API response details",
+	} {
+		if !messages[want] {
+			t.Errorf("expected log entry %q, got %v", want, messages)
+		}
+	}
+
+	for _, secret := range []string{token, "hunter2", "leaked-value"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q to be redacted from logs", secret)
+		}
+	}
+	if !strings.Contains(logged, "/environments/test-org") {
+		t.Error("expected request path in logs")
+	}
+}
+
+// TestClient_Redact tests redaction of token-looking strings.
+func TestClient_Redact(t *testing.T) {
+	client := &Client{apiToken: "abc123"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "own token", input: "token=abc123", expected: "token=[REDACTED]"},
+		{name: "bearer", input: "Authorization: Bearer xyz.789", expected: "Authorization: Bearer [REDACTED]"},
+		{name: "json secret field", input: `{"webhook_secret": "s3cr3t", "name": "a"}`, expected: `{"webhook_secret": "[REDACTED]", "name": "a"}`},
+		{name: "jwt", input: "id=eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl", expected: "id=[REDACTED]"},
+		{name: "plain text untouched", input: `{"name": "prod"}`, expected: `{"name": "prod"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.redact(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestClient_RedactHeaders tests that sensitive headers are masked.
+func TestClient_RedactHeaders(t *testing.T) {
+	client := &Client{apiToken: "abc123"}
+	h := http.Header{}
+	h.Set("Authorization", "Bearer abc123")
+	h.Set("User-Agent", "terraform-provider-kosli/test")
+
+	got := client.redactHeaders(h)
+	if got["Authorization"] != redactedValue {
+		t.Errorf("expected Authorization to be redacted, got %q", got["Authorization"])
+	}
+	if got["User-Agent"] != "terraform-provider-kosli/test" {
+		t.Errorf("expected User-Agent to be preserved, got %q", got["User-Agent"])
+	}
+}
+
+// TestClient_LoggableBody tests body rendering for different content types.
+func TestClient_LoggableBody(t *testing.T) {
+	client := &Client{}
+
+	if got := client.loggableBody("multipart/form-data; boundary=x", []byte("0123456789")); !strings.Contains(got, "10 bytes") {
+		t.Errorf("expected multipart body to be summarized, got %q", got)
+	}
+	large := bytes.Repeat([]byte("a"), maxLoggedBodySize+10)
+	if got := client.loggableBody("application/json", large); !strings.HasSuffix(got, "...<truncated>") {
+		t.Errorf("expected large body to be truncated, got %d bytes", len(got))
+	}
+	if got := client.loggableBody("application/json", nil); got != "" {
+		t.Errorf("expected empty body, got %q", got)
+	}
+}
+
+// TestWithLogger_Nil tests that a nil logger is rejected.
+func TestWithLogger_Nil(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithLogger(nil)); err == nil {
+		t.Error("expected an error for a nil logger")
+	}
+}
+
+// TestClient_LogResponse_NoBufferingWithoutTrace tests that response bodies
+// are only buffered when TRACE entries are logged.
+func TestClient_LogResponse_NoBufferingWithoutTrace(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      slog.Level
+		wantBuffer bool
+	}{
+		{name: "debug", level: slog.LevelDebug, wantBuffer: false},
+		{name: "trace", level: LevelTrace, wantBuffer: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			client := &Client{logger: slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: tt.level}))}
+			req := httptest.NewRequest(http.MethodGet, "/environments/test-org", nil)
+			body := io.NopCloser(strings.NewReader(`{"name": "prod"}`))
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body}
+
+			client.logResponse(context.Background(), req, resp, nil, 0)
+
+			if buffered := resp.Body != body; buffered != tt.wantBuffer {
+				t.Errorf("expected buffered %v, got %v", tt.wantBuffer, buffered)
+			}
+			if !strings.Contains(output.String(), "Received Kosli API response") {
+				t.Errorf("expected the DEBUG summary to be logged, got %q", output.String())
+			}
+		})
+	}
+}
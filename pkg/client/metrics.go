@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetricsRecorder receives one observation per API request, after any
+// retries. Implementations typically feed request counters keyed by
+// Method, Endpoint and StatusCode, a retry counter, and a latency
+// histogram. RecordRequest is called synchronously on the request path, so
+// it must be safe for concurrent use and should not block.
+type MetricsRecorder interface {
+	RecordRequest(ctx context.Context, m RequestMetrics)
+}
+
+// RequestMetrics describes a completed API request.
+type RequestMetrics struct {
+	// Method is the HTTP method.
+	Method string
+
+	// Endpoint is the request path relative to the API path with the
+	// organization and resource names replaced by placeholders, e.g.
+	// "/environments/{org}/{name}", so it is safe to use as a metric label.
+	Endpoint string
+
+	// StatusCode is the final HTTP status code, or 0 if no response was
+	// received.
+	StatusCode int
+
+	// Err is the transport error, if no response was received.
+	Err error
+
+	// Retries is the number of retries made before the final outcome.
+	Retries int
+
+	// Duration is the wall-clock time spent on the request, including
+	// retries and the waits between them.
+	Duration time.Duration
+}
+
+// WithMetricsRecorder reports every API request to r.
+func WithMetricsRecorder(r MetricsRecorder) ClientOption {
+	return func(c *Client) error {
+		if r == nil {
+			return fmt.Errorf("metrics recorder cannot be nil")
+		}
+		c.metrics = r
+		return nil
+	}
+}
+
+// endpointKeywords are fixed path segments kept verbatim when templating an
+// endpoint; any other segment after the organization is a resource name.
+var endpointKeywords = map[string]bool{
+	"archive":                    true,
+	"artifact":                   true,
+	"environment":                true,
+	"environments_notifications": true,
+	"flow":                       true,
+	"latest":                     true,
+	"policies":                   true,
+	"report":                     true,
+	"sonar":                      true,
+	"template_file":              true,
+	"trail":                      true,
+}
+
+// endpointTemplate returns the low-cardinality form of req's path described
+// on RequestMetrics.Endpoint.
+func (c *Client) endpointTemplate(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, c.apiPath)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	org := c.OrganizationFor(req.Context())
+	seenOrg := false
+	for i, segment := range segments {
+		switch {
+		case !seenOrg && segment == org:
+			segments[i] = "{org}"
+			seenOrg = true
+		case seenOrg && !endpointKeywords[segment]:
+			segments[i] = "{name}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// recordMetrics reports a completed request to the metrics recorder, if any.
+func (c *Client) recordMetrics(req *http.Request, resp *http.Response, err error, retries int, elapsed time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+	m := RequestMetrics{
+		Method:   req.Method,
+		Endpoint: c.endpointTemplate(req),
+		Err:      err,
+		Retries:  retries,
+		Duration: elapsed,
+	}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+	}
+	c.metrics.RecordRequest(req.Context(), m)
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a MetricsRecorder that keeps every observation.
+type recordingMetrics struct {
+	mu      sync.Mutex
+	records []RequestMetrics
+}
+
+func (r *recordingMetrics) RecordRequest(_ context.Context, m RequestMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, m)
+}
+
+// TestClient_Metrics tests that requests are reported with endpoint, status and retry count.
+func TestClient_Metrics(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(3, 10*time.Millisecond, 100*time.Millisecond),
+		WithMetricsRecorder(metrics),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/environments/test-org/production")
+	if err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+	resp.Body.Close()
+
+	if len(metrics.records) != 1 {
+		t.Fatalf("expected 1 observation, got %d", len(metrics.records))
+	}
+	m := metrics.records[0]
+	if m.Method != http.MethodGet {
+		t.Errorf("expected method GET, got %q", m.Method)
+	}
+	if m.Endpoint != "/environments/{org}/{name}" {
+		t.Errorf("expected templated endpoint, got %q", m.Endpoint)
+	}
+	if m.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", m.StatusCode)
+	}
+	if m.Retries != 2 {
+		t.Errorf("expected 2 retries, got %d", m.Retries)
+	}
+	if m.Duration <= 0 {
+		t.Errorf("expected positive duration, got %s", m.Duration)
+	}
+	if m.Err != nil {
+		t.Errorf("expected no error, got %v", m.Err)
+	}
+}
+
+// TestClient_MetricsTransportError tests that failed requests are reported with their error.
+func TestClient_MetricsTransportError(t *testing.T) {
+	metrics := &recordingMetrics{}
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL("http://127.0.0.1:1"),
+		WithAPIPath(""),
+		WithHTTPClient(&http.Client{}),
+		WithMetricsRecorder(metrics),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Get(context.Background(), "/environments/test-org"); err == nil {
+		t.Fatal("expected connection error")
+	}
+	if len(metrics.records) != 1 {
+		t.Fatalf("expected 1 observation, got %d", len(metrics.records))
+	}
+	if m := metrics.records[0]; m.StatusCode != 0 || m.Err == nil || m.Retries != 0 {
+		t.Errorf("expected a failed observation without status or retries, got %+v", m)
+	}
+}
+
+// TestClient_EndpointTemplate tests that paths are templated for use as metric labels.
+func TestClient_EndpointTemplate(t *testing.T) {
+	client := &Client{apiPath: DefaultAPIPath, organization: "acme"}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/api/v2/environments/acme", expected: "/environments/{org}"},
+		{path: "/api/v2/environments/acme/prod", expected: "/environments/{org}/{name}"},
+		{path: "/api/v2/environments/acme/prod/archive", expected: "/environments/{org}/{name}/archive"},
+		{path: "/api/v2/environments/acme/prod/policies", expected: "/environments/{org}/{name}/policies"},
+		{path: "/api/v2/organizations/acme/environments_notifications/3", expected: "/organizations/{org}/environments_notifications/{name}"},
+		{path: "/api/v2/tags/acme/environment/prod", expected: "/tags/{org}/environment/{name}"},
+		{path: "/api/v2/flows/acme/template_file", expected: "/flows/{org}/template_file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "https://app.kosli.com"+tt.path, nil)
+			if got := client.endpointTemplate(req); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestClient_MetricsEndpoints tests the endpoint reported for every path the client builds.
+func TestClient_MetricsEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client, err := NewClient("test-token", "acme", WithBaseURL(server.URL), WithMetricsRecorder(metrics))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() error
+		expected string
+	}{
+		{"ListActions", func() error { _, err := client.ListActions(ctx); return err }, "/organizations/{org}/environments_notifications"},
+		{"GetActionByNumber", func() error { _, err := client.GetActionByNumber(ctx, 3); return err }, "/organizations/{org}/environments_notifications/{name}"},
+		{"ReportArtifact", func() error { return client.ReportArtifact(ctx, &ArtifactRequest{FlowName: "backend"}) }, "/artifacts/{org}/{name}"},
+		{"CreateSonarAttestation", func() error {
+			return client.CreateSonarAttestation(ctx, &SonarAttestationRequest{FlowName: "backend", TrailName: "v1"})
+		}, "/attestations/{org}/{name}/trail/{name}/sonar"},
+		{"GetLatestAttestation", func() error {
+			_, err := client.GetLatestAttestation(ctx, "backend", "v1", "tests", nil)
+			return err
+		}, "/attestations/{org}/{name}/trail/{name}/{name}"},
+		{"GetLatestAttestation artifact", func() error {
+			_, err := client.GetLatestAttestation(ctx, "backend", "v1", "tests", &GetLatestAttestationOptions{ArtifactFingerprint: "abc123"})
+			return err
+		}, "/attestations/{org}/{name}/artifact/{name}/{name}"},
+		{"ListCustomAttestationTypes", func() error { _, err := client.ListCustomAttestationTypes(ctx, nil); return err }, "/custom-attestation-types/{org}"},
+		{"GetCustomAttestationType", func() error { _, err := client.GetCustomAttestationType(ctx, "coverage", nil); return err }, "/custom-attestation-types/{org}/{name}"},
+		{"ArchiveCustomAttestationType", func() error { return client.ArchiveCustomAttestationType(ctx, "coverage") }, "/custom-attestation-types/{org}/{name}/archive"},
+		{"ReportEnvironment", func() error { return client.ReportEnvironment(ctx, "prod", "K8S", nil) }, "/environments/{org}/{name}/report/{name}"},
+		{"ListEnvironments", func() error { _, err := client.ListEnvironments(ctx, nil); return err }, "/environments/{org}"},
+		{"GetEnvironment", func() error { _, err := client.GetEnvironment(ctx, "prod"); return err }, "/environments/{org}/{name}"},
+		{"ArchiveEnvironment", func() error { return client.ArchiveEnvironment(ctx, "prod") }, "/environments/{org}/{name}/archive"},
+		{"AttachPolicy", func() error { return client.AttachPolicy(ctx, "prod", "prod-policy") }, "/environments/{org}/{name}/policies"},
+		{"CreateFlow", func() error { return client.CreateFlow(ctx, &CreateFlowRequest{Name: "backend"}) }, "/flows/{org}/template_file"},
+		{"GetFlow", func() error { _, err := client.GetFlow(ctx, "backend"); return err }, "/flows/{org}/{name}"},
+		{"ListFlows", func() error { _, err := client.ListFlows(ctx); return err }, "/flows/{org}"},
+		{"ArchiveFlow", func() error { return client.ArchiveFlow(ctx, "backend") }, "/flows/{org}/{name}/archive"},
+		{"ListPolicies", func() error { _, err := client.ListPolicies(ctx); return err }, "/policies/{org}"},
+		{"GetPolicy", func() error { _, err := client.GetPolicy(ctx, "prod-policy"); return err }, "/policies/{org}/{name}"},
+		{"ListSnapshots", func() error { _, err := client.ListSnapshots(ctx, "prod", nil); return err }, "/snapshots/{org}/{name}"},
+		{"GetLatestSnapshot", func() error { _, err := client.GetLatestSnapshot(ctx, "prod"); return err }, "/snapshots/{org}/{name}/latest"},
+		{"GetSnapshot", func() error {
+			_, err := client.GetSnapshot(ctx, &SnapshotSelector{Environment: "prod", Index: 3})
+			return err
+		}, "/snapshots/{org}/{name}/{name}"},
+		{"SetEnvironmentTag", func() error { return client.SetEnvironmentTag(ctx, "prod", "tier", "prod") }, "/tags/{org}/environment/{name}"},
+		{"TagResource flow", func() error { return client.TagResource(ctx, "flow", "backend", &TagResourcePayload{}) }, "/tags/{org}/flow/{name}"},
+		{"GetTrail", func() error { _, err := client.GetTrail(ctx, "backend", "v1"); return err }, "/trails/{org}/{name}/{name}"},
+		{"ListTrails", func() error { _, err := client.ListTrails(ctx, "backend", nil); return err }, "/trails/{org}/{name}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics.mu.Lock()
+			metrics.records = nil
+			metrics.mu.Unlock()
+
+			// Only the path matters; responses that do not parse are fine
+			_ = tt.call()
+
+			metrics.mu.Lock()
+			defer metrics.mu.Unlock()
+			if len(metrics.records) == 0 {
+				t.Fatal("expected a request")
+			}
+			if got := metrics.records[0].Endpoint; got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestWithMetricsRecorder_Nil tests that a nil recorder is rejected.
+func TestWithMetricsRecorder_Nil(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithMetricsRecorder(nil)); err == nil {
+		t.Error("expected error for nil recorder")
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import "context"
+
+// orgOverrideKey is the context key for a per-call organization override.
+type orgOverrideKey struct{}
+
+// WithOrgOverride returns a context that makes client methods called with it
+// operate on org instead of the organization the client was created with.
+// This lets a single client serve resources in several organizations,
+// provided its API token has access to all of them. An empty org leaves the
+// client's organization in effect.
+//
+// Example:
+//
+//	ctx = client.WithOrgOverride(ctx, "other-org")
+//	env, err := c.GetEnvironment(ctx, "production")
+func WithOrgOverride(ctx context.Context, org string) context.Context {
+	return context.WithValue(ctx, orgOverrideKey{}, org)
+}
+
+// OrganizationFor returns the organization requests made with ctx act on:
+// the one set with WithOrgOverride, if any, or the client's organization.
+func (c *Client) OrganizationFor(ctx context.Context) string {
+	if org, ok := ctx.Value(orgOverrideKey{}).(string); ok && org != "" {
+		return org
+	}
+	return c.organization
+}
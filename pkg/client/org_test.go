@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestClient_OrgOverride tests that a per-call organization override changes the request path.
+func TestClient_OrgOverride(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production", "type": "K8S"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetEnvironment(ctx, "production"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.GetEnvironment(WithOrgOverride(ctx, "other-org"), "production"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.GetEnvironment(WithOrgOverride(ctx, ""), "production"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"/environments/test-org/production",
+		"/environments/other-org/production",
+		"/environments/test-org/production",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(paths))
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("request %d: expected path %q, got %q", i, expected[i], paths[i])
+		}
+	}
+
+	if client.Organization() != "test-org" {
+		t.Errorf("expected configured organization to be unchanged, got %q", client.Organization())
+	}
+}
+
+// TestClient_EndpointTemplateOrgOverride tests that metric endpoints template an overridden organization.
+func TestClient_EndpointTemplateOrgOverride(t *testing.T) {
+	client := &Client{apiPath: DefaultAPIPath, organization: "acme"}
+
+	req, _ := http.NewRequestWithContext(WithOrgOverride(context.Background(), "other"), http.MethodGet, "https://app.kosli.com/api/v2/environments/other/prod", nil)
+	if got := client.endpointTemplate(req); got != "/environments/{org}/{name}" {
+		t.Errorf("expected templated endpoint, got %q", got)
+	}
+}
+
+// TestClient_OrganizationFor tests that the effective organization follows the override.
+func TestClient_OrganizationFor(t *testing.T) {
+	client, err := NewClient("test-token", "test-org")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		ctx  context.Context
+		want string
+	}{
+		{ctx, "test-org"},
+		{WithOrgOverride(ctx, "other-org"), "other-org"},
+		{WithOrgOverride(ctx, ""), "test-org"},
+	} {
+		if got := client.OrganizationFor(tc.ctx); got != tc.want {
+			t.Errorf("expected %q, got %q", tc.want, got)
+		}
+	}
+}
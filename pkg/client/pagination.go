@@ -0,0 +1,32 @@
+package client
+
+import "fmt"
+
+// WithDefaultPageSize sets the page size of paginated calls whose options
+// don't set one: ListAllEnvironments, ListSnapshots and ListTrails. Larger
+// pages mean fewer requests when listing large organizations, smaller pages
+// less data held per response. Without it, each call uses its own default.
+func WithDefaultPageSize(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("default page size must be at least 1")
+		}
+		c.defaultPageSize = n
+		return nil
+	}
+}
+
+// DefaultPageSize returns the page size set with WithDefaultPageSize, or 0
+// if none was set.
+func (c *Client) DefaultPageSize() int {
+	return c.defaultPageSize
+}
+
+// pageSize returns perPage if set, and the client's default page size
+// otherwise. 0 means the API default.
+func (c *Client) pageSize(perPage int) int {
+	if perPage > 0 {
+		return perPage
+	}
+	return c.defaultPageSize
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestClient_DefaultPageSize tests that paginated calls without a page size
+// use the client's default page size, and that an explicit one wins
+func TestClient_DefaultPageSize(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithDefaultPageSize(500),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if got := client.DefaultPageSize(); got != 500 {
+		t.Errorf("expected DefaultPageSize 500, got %d", got)
+	}
+
+	ctx := context.Background()
+	if _, err := client.ListAllEnvironments(ctx, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.ListAllEnvironments(ctx, &ListEnvironmentsOptions{PerPage: 20}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.ListSnapshots(ctx, "production", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.ListTrails(ctx, "backend", &ListTrailsOptions{Page: 2}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.ListTrails(ctx, "backend", &ListTrailsOptions{PerPage: 1}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		"/environments/test-org?page=1&per_page=500",
+		"/environments/test-org?page=1&per_page=20",
+		"/snapshots/test-org/production?per_page=500",
+		"/trails/test-org/backend?page=2&per_page=500",
+		"/trails/test-org/backend?per_page=1",
+	}
+	if strings.Join(queries, " ") != strings.Join(want, " ") {
+		t.Errorf("expected requests %v, got %v", want, queries)
+	}
+}
+
+// TestClient_DefaultPageSize_Unset tests that without a default page size the
+// API's own default is used
+func TestClient_DefaultPageSize_Unset(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.ListSnapshots(context.Background(), "production", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if query != "" {
+		t.Errorf("expected no query, got %q", query)
+	}
+}
+
+func TestWithDefaultPageSize_Validation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithDefaultPageSize(0)); err == nil {
+		t.Error("expected error for zero default page size, got nil")
+	}
+}
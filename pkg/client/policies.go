@@ -54,7 +54,7 @@ func (c *Client) CreatePolicy(ctx context.Context, req *CreatePolicyRequest) err
 	}
 
 	// Build path: PUT /api/v2/policies/{org}
-	path := fmt.Sprintf("/policies/%s", c.Organization())
+	path := fmt.Sprintf("/policies/%s", c.OrganizationFor(ctx))
 
 	// Create custom HTTP request (multipart, not JSON)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, c.apiURL+path, body)
@@ -64,10 +64,12 @@ func (c *Client) CreatePolicy(ctx context.Context, req *CreatePolicyRequest) err
 
 	// doRequest only supports JSON bodies; set auth/UA headers manually for this multipart request.
 	httpReq.Header.Set("Content-Type", contentType)
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
+	if err := c.authorize(httpReq); err != nil {
+		return err
+	}
 	httpReq.Header.Set("User-Agent", c.userAgent)
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -82,7 +84,7 @@ func (c *Client) CreatePolicy(ctx context.Context, req *CreatePolicyRequest) err
 
 // GetPolicy retrieves a specific policy by name.
 func (c *Client) GetPolicy(ctx context.Context, name string) (*Policy, error) {
-	path := fmt.Sprintf("/policies/%s/%s", c.Organization(), name)
+	path := fmt.Sprintf("/policies/%s/%s", c.OrganizationFor(ctx), name)
 
 	resp, err := c.Get(ctx, path)
 	if err != nil {
@@ -97,7 +99,7 @@ func (c *Client) GetPolicy(ctx context.Context, name string) (*Policy, error) {
 	log.Printf("[DEBUG] GetPolicy: received response for policy %q", name)
 
 	var result Policy
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := decodeJSON(resp.Request.Context(), body, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
@@ -106,7 +108,7 @@ func (c *Client) GetPolicy(ctx context.Context, name string) (*Policy, error) {
 
 // ListPolicies retrieves all policies for the organization.
 func (c *Client) ListPolicies(ctx context.Context) ([]Policy, error) {
-	path := fmt.Sprintf("/policies/%s", c.Organization())
+	path := fmt.Sprintf("/policies/%s", c.OrganizationFor(ctx))
 
 	resp, err := c.Get(ctx, path)
 	if err != nil {
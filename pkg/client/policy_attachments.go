@@ -15,7 +15,7 @@ type AttachedPolicy struct {
 // AttachPolicy attaches a policy to an environment.
 // POST /api/v2/environments/{org}/{env}/policies
 func (c *Client) AttachPolicy(ctx context.Context, environmentName, policyName string) error {
-	path := fmt.Sprintf("/environments/%s/%s/policies", c.Organization(), environmentName)
+	path := fmt.Sprintf("/environments/%s/%s/policies", c.OrganizationFor(ctx), environmentName)
 	body := map[string]any{"policy_names": []string{policyName}}
 
 	resp, err := c.Post(ctx, path, body)
@@ -30,7 +30,7 @@ func (c *Client) AttachPolicy(ctx context.Context, environmentName, policyName s
 // DetachPolicy detaches a policy from an environment.
 // DELETE /api/v2/environments/{org}/{env}/policies (with JSON body)
 func (c *Client) DetachPolicy(ctx context.Context, environmentName, policyName string) error {
-	path := fmt.Sprintf("/environments/%s/%s/policies", c.Organization(), environmentName)
+	path := fmt.Sprintf("/environments/%s/%s/policies", c.OrganizationFor(ctx), environmentName)
 	body := map[string]any{"policy_names": []string{policyName}}
 
 	// Use doRequest directly (same package) because Delete() doesn't support a body.
@@ -45,13 +45,18 @@ func (c *Client) DetachPolicy(ctx context.Context, environmentName, policyName s
 
 // GetEnvironmentPolicies returns the list of policies attached to an environment.
 // Reads the policies from the standard GET /api/v2/environments/{org}/{env} response.
-// The API may return policies as strings ("policy-name") or objects ({"name": "policy-name"}).
 func (c *Client) GetEnvironmentPolicies(ctx context.Context, environmentName string) ([]AttachedPolicy, error) {
 	env, err := c.GetEnvironment(ctx, environmentName)
 	if err != nil {
 		return nil, err
 	}
 
+	return env.AttachedPolicies()
+}
+
+// AttachedPolicies decodes the policies attached to the environment.
+// The API may return policies as strings ("policy-name") or objects ({"name": "policy-name"}).
+func (env *Environment) AttachedPolicies() ([]AttachedPolicy, error) {
 	policies := make([]AttachedPolicy, 0, len(env.Policies))
 	for _, p := range env.Policies {
 		// Case 1: policy is a plain string — the API returns just the policy name.
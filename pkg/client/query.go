@@ -0,0 +1,59 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// query builds a URL query string from optional parameters. Zero values are
+// skipped, so callers can pass option fields through unconditionally and only
+// the ones that were set end up in the request.
+type query struct {
+	values url.Values
+}
+
+// newQuery returns an empty query.
+func newQuery() *query {
+	return &query{values: url.Values{}}
+}
+
+// String adds key=value if value is not empty.
+func (q *query) String(key, value string) *query {
+	if value != "" {
+		q.values.Add(key, value)
+	}
+	return q
+}
+
+// Strings adds key=value once for each non-empty value.
+func (q *query) Strings(key string, values []string) *query {
+	for _, v := range values {
+		q.String(key, v)
+	}
+	return q
+}
+
+// Bool adds key=true if value is true.
+func (q *query) Bool(key string, value bool) *query {
+	if value {
+		q.values.Add(key, "true")
+	}
+	return q
+}
+
+// Int adds key=value if value is greater than zero.
+func (q *query) Int(key string, value int) *query {
+	if value > 0 {
+		q.values.Add(key, strconv.Itoa(value))
+	}
+	return q
+}
+
+// Path returns path with the encoded query appended, or path unchanged if no
+// parameters were added. Parameters are sorted by key.
+func (q *query) Path(path string) string {
+	if len(q.values) == 0 {
+		return path
+	}
+	return path + "?" + q.values.Encode()
+}
@@ -0,0 +1,41 @@
+package client
+
+import "testing"
+
+// TestQuery tests that only set parameters are encoded.
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *query
+		expected string
+	}{
+		{
+			name:     "no parameters",
+			query:    newQuery().String("type", "").Bool("archived", false).Int("page", 0).Strings("tag", nil),
+			expected: "/environments/org",
+		},
+		{
+			name:     "string and bool",
+			query:    newQuery().String("type", "K8S").Bool("include_archived", true),
+			expected: "/environments/org?include_archived=true&type=K8S",
+		},
+		{
+			name:     "repeated values",
+			query:    newQuery().Strings("tag", []string{"a:1", "", "b:2"}),
+			expected: "/environments/org?tag=a%3A1&tag=b%3A2",
+		},
+		{
+			name:     "negative int skipped",
+			query:    newQuery().Int("page", -1).Int("per_page", 10),
+			expected: "/environments/org?per_page=10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Path("/environments/org"); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
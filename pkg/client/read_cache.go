@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// readCache holds successful GET responses for the life of the client. A
+// Terraform provider process, and so its client, lives for a single plan or
+// apply, which bounds how stale an entry can get; any write clears the cache
+// so a read following it always goes to the API.
+type readCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedRead
+
+	// generation is bumped by every invalidation, so a read that was in
+	// flight across a write isn't cached.
+	generation uint64
+}
+
+// cachedRead is a cached GET response.
+type cachedRead struct {
+	resp *http.Response
+	body []byte
+}
+
+// WithReadCache caches successful GET responses by path in memory until the
+// client next sends a request other than GET. This avoids repeated API calls
+// when several data sources or resources read the same object during one
+// operation. Only use it for short-lived clients: changes made outside the
+// client are not seen while an entry is cached.
+func WithReadCache() ClientOption {
+	return func(c *Client) error {
+		c.reads = &readCache{entries: make(map[string]*cachedRead)}
+		return nil
+	}
+}
+
+// freshReadKey marks a context whose GETs bypass the read cache.
+type freshReadKey struct{}
+
+// WithFreshRead returns a context whose GETs always go to the API instead of
+// being served from the read cache, or shared with a GET already in flight.
+// The response still replaces any cached entry. Use it to re-read an object
+// that may have been cached before a write became visible, such as when
+// polling for an eventually consistent change.
+//
+// Example:
+//
+//	env, err := c.GetEnvironment(client.WithFreshRead(ctx), "production")
+func WithFreshRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadKey{}, true)
+}
+
+// isFreshRead reports whether ctx was returned by WithFreshRead.
+func isFreshRead(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshReadKey{}).(bool)
+	return fresh
+}
+
+// get returns a copy of the cached response for path.
+func (rc *readCache) get(path string) (*http.Response, bool) {
+	rc.mu.Lock()
+	entry, ok := rc.entries[path]
+	rc.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	resp := *entry.resp
+	resp.Header = entry.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(entry.body))
+	return &resp, true
+}
+
+// current returns the generation to pass to put for a read starting now.
+func (rc *readCache) current() uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.generation
+}
+
+// put caches resp for path, buffering its body so it can still be read by
+// the caller. The response is not cached if its body couldn't be read in full,
+// for example because it exceeds the maximum response size, or if the cache
+// was invalidated since generation was taken.
+func (rc *readCache) put(path string, generation uint64, resp *http.Response) {
+	body, err := bufferBody(resp)
+	if err != nil {
+		return
+	}
+	entry := &cachedRead{resp: resp, body: body}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if generation == rc.generation {
+		rc.entries[path] = entry
+	}
+}
+
+// invalidate drops every cached response.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	clear(rc.entries)
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestClient_ReadCache tests that GETs are served from the cache until a write.
+func TestClient_ReadCache(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithReadCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	get := func() string {
+		t.Helper()
+		resp, err := client.Get(context.Background(), "/environments/test-org/production")
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	for i := 0; i < 3; i++ {
+		if body := get(); body != `{"name": "production"}` {
+			t.Errorf("read %d: unexpected body %q", i, body)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("expected 1 GET before a write, got %d", gets)
+	}
+
+	resp, err := client.Put(context.Background(), "/environments/test-org", map[string]string{"name": "production"})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	resp.Body.Close()
+
+	get()
+	if gets != 2 {
+		t.Errorf("expected write to invalidate the cache, got %d GETs", gets)
+	}
+}
+
+// TestClient_ReadCacheSkipsErrors tests that failed reads are not cached.
+func TestClient_ReadCacheSkipsErrors(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithReadCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "/environments/test-org/missing"); !IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("expected every failed read to reach the API, got %d GETs", gets)
+	}
+}
+
+// TestClient_ReadCacheSkipsTruncated tests that a body cut off by the maximum
+// response size isn't cached and later served as a complete response.
+func TestClient_ReadCacheSkipsTruncated(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithReadCache(),
+		WithMaxResponseSize(8),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(context.Background(), "/environments/test-org/production")
+		if err != nil {
+			t.Fatalf("read %d: expected the request to succeed, got %v", i, err)
+		}
+		_, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("read %d: expected ErrResponseTooLarge, got %v", i, err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("expected every truncated read to reach the API, got %d GETs", gets)
+	}
+}
+
+// TestReadCache_StaleGeneration tests that a read overlapping a write isn't cached.
+func TestReadCache_StaleGeneration(t *testing.T) {
+	cache := &readCache{entries: make(map[string]*cachedRead)}
+	newResp := func() *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(http.NoBody)}
+	}
+
+	generation := cache.current()
+	cache.invalidate()
+	cache.put("/environments/test-org", generation, newResp())
+	if _, ok := cache.get("/environments/test-org"); ok {
+		t.Error("expected read from before the invalidation not to be cached")
+	}
+
+	cache.put("/environments/test-org", cache.current(), newResp())
+	if _, ok := cache.get("/environments/test-org"); !ok {
+		t.Error("expected current read to be cached")
+	}
+}
+
+// TestClient_ReadCacheFreshRead tests that a fresh read skips the cache and
+// replaces the cached response.
+func TestClient_ReadCacheFreshRead(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"version": %d}`, gets)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithReadCache(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	get := func(ctx context.Context) string {
+		t.Helper()
+		resp, err := client.Get(ctx, "/environments/test-org/production")
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	get(context.Background())
+	if body := get(WithFreshRead(context.Background())); body != `{"version": 2}` {
+		t.Errorf("expected the fresh read to reach the API, got %q", body)
+	}
+	if body := get(context.Background()); body != `{"version": 2}` {
+		t.Errorf("expected the fresh read to replace the cached response, got %q", body)
+	}
+	if gets != 2 {
+		t.Errorf("expected 2 GETs, got %d", gets)
+	}
+}
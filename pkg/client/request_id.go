@@ -0,0 +1,25 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader is the request header carrying the client-generated ID of
+// each API call, so a failed call can be matched with the Kosli server logs.
+// Retries of a call reuse its ID.
+const RequestIDHeader = "X-Request-ID"
+
+// setRequestID gives req a new random (version 4 UUID) request ID, unless the
+// caller already set one.
+func setRequestID(req *http.Request) {
+	if req.Header.Get(RequestIDHeader) != "" {
+		return
+	}
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 9562 variant
+	req.Header.Set(RequestIDHeader, fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// TestClient_RequestID tests that every call carries its own request ID, and
+// that failed calls report it.
+func TestClient_RequestID(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "invalid"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var apiErrs []*APIError
+	for range 2 {
+		_, err := client.Put(context.Background(), "/test", nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected an APIError, got %v", err)
+		}
+		apiErrs = append(apiErrs, apiErr)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(received))
+	}
+	for i, id := range received {
+		if !uuidV4.MatchString(id) {
+			t.Errorf("expected a UUID request ID, got %q", id)
+		}
+		if apiErrs[i].ClientRequestID != id {
+			t.Errorf("expected APIError to carry request ID %q, got %q", id, apiErrs[i].ClientRequestID)
+		}
+	}
+	if received[0] == received[1] {
+		t.Errorf("expected each call to get its own request ID, got %q twice", received[0])
+	}
+}
+
+// TestClient_RequestID_CallerSet tests that a request ID set by the caller is
+// kept.
+func TestClient_RequestID_CallerSet(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(RequestIDHeader)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org", WithBaseURL(server.URL), WithAPIPath(""))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/test", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set(RequestIDHeader, "support-case-42")
+
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if received != "support-case-42" {
+		t.Errorf("expected the caller's request ID, got %q", received)
+	}
+}
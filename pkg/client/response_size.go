@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxResponseSize is the default cap on the size of a response body;
+// see WithMaxResponseSize.
+const DefaultMaxResponseSize = 50 << 20
+
+// ErrResponseTooLarge is returned when reading a response body larger than
+// the client's maximum response size.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// WithMaxResponseSize caps the number of bytes read from any response body,
+// so that a misbehaving proxy returning a huge page can't exhaust the
+// provider's memory. Reading past the cap fails with ErrResponseTooLarge.
+func WithMaxResponseSize(n int64) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("max response size must be at least 1 byte")
+		}
+		c.maxResponseSize = n
+		return nil
+	}
+}
+
+// limitedBody fails reads of a response body beyond limit bytes.
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+// newLimitedBody caps body at limit bytes.
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{ReadCloser: body, limit: limit, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Probe past the limit to tell a body of exactly limit bytes from a
+		// larger one
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: exceeds %d bytes, which is more than the Kosli API returns; check for a proxy between the provider and the API", ErrResponseTooLarge, b.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
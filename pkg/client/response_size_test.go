@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestClient_MaxResponseSize tests that response bodies are read up to the
+// maximum response size and no further.
+func TestClient_MaxResponseSize(t *testing.T) {
+	const limit = 64
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantTooLong bool
+	}{
+		{name: "within limit", status: http.StatusOK, body: `{"name": "production"}`},
+		{name: "exactly at limit", status: http.StatusOK, body: `{"name": "` + strings.Repeat("a", limit-12) + `"}`},
+		{name: "over limit", status: http.StatusOK, body: `{"name": "` + strings.Repeat("a", limit) + `"}`, wantTooLong: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+				WithMaxResponseSize(limit),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			resp, err := client.Get(context.Background(), "/test")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var result struct{ Name string }
+			err = ParseResponse(resp, &result)
+
+			if got := errors.Is(err, ErrResponseTooLarge); got != tt.wantTooLong {
+				t.Errorf("expected ErrResponseTooLarge %v, got %v", tt.wantTooLong, err)
+			}
+			if !tt.wantTooLong && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestClient_MaxResponseSize_ErrorResponse tests that an oversized error
+// page is reported from its beginning.
+func TestClient_MaxResponseSize_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>" + strings.Repeat("proxy error ", 1000) + "</html>"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithHTTPClient(&http.Client{}),
+		WithMaxResponseSize(100),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Get(context.Background(), "/test")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "Bad Gateway" {
+		t.Errorf("expected a 502 with its status text, got %+v", apiErr)
+	}
+	if len(apiErr.Body) != 100 || !strings.HasPrefix(apiErr.Body, "<html>proxy error") {
+		t.Errorf("expected the first 100 bytes of the body, got %q", apiErr.Body)
+	}
+}
+
+func TestWithMaxResponseSize_Validation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithMaxResponseSize(0)); err == nil {
+		t.Error("expected error for zero max response size, got nil")
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// IdempotencyKeyHeader is the request header that marks a non-idempotent
+// request (POST, PATCH) as safe to retry like an idempotent one, for
+// endpoints where the server deduplicates requests carrying the same key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// retrySafetyKey is the context key recording whether the request being sent
+// may be retried after the server could have acted on it.
+type retrySafetyKey struct{}
+
+// withRetrySafety returns a context recording whether req is safe to retry.
+func withRetrySafety(ctx context.Context, req *http.Request) context.Context {
+	return context.WithValue(ctx, retrySafetyKey{}, isIdempotent(req))
+}
+
+// isIdempotent reports whether repeating req has the same effect as sending
+// it once: the idempotent methods of RFC 9110, or any request carrying an
+// idempotency key.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// checkRetry is the retry policy. It applies retryablehttp's default policy
+// (connection errors, 429 and 5xx other than 501) to idempotent requests.
+// Non-idempotent requests, such as the POST that creates a new custom
+// attestation type version, are only retried when the server can't have
+// acted on them: the connection was never established, or the server
+// rejected the request with 429 Too Many Requests.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, policyErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !retry {
+		return retry, policyErr
+	}
+
+	if idempotent, ok := ctx.Value(retrySafetyKey{}).(bool); ok && !idempotent {
+		if err != nil {
+			return isConnectFailure(err), nil
+		}
+		return resp.StatusCode == http.StatusTooManyRequests, nil
+	}
+	return true, nil
+}
+
+// isConnectFailure reports whether err occurred while connecting, i.e.
+// before any part of the request was sent.
+func isConnectFailure(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// giveUp is called when a request has used all its retries. The last
+// response, if any, is returned as is so that callers report the API's
+// status and message rather than only that the retries ran out.
+func giveUp(resp *http.Response, err error, attempts int) (*http.Response, error) {
+	if resp != nil {
+		return resp, nil
+	}
+	return nil, fmt.Errorf("giving up after %d attempt(s): %w", attempts, err)
+}
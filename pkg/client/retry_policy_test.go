@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClient_RetryByMethod tests that non-idempotent requests are not retried after reaching the server.
+func TestClient_RetryByMethod(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		idempotencyKey string
+		status         int
+		wantAttempts   int
+	}{
+		{name: "GET retried on 500", method: http.MethodGet, status: http.StatusInternalServerError, wantAttempts: 3},
+		{name: "PUT retried on 503", method: http.MethodPut, status: http.StatusServiceUnavailable, wantAttempts: 3},
+		{name: "DELETE retried on 502", method: http.MethodDelete, status: http.StatusBadGateway, wantAttempts: 3},
+		{name: "POST not retried on 500", method: http.MethodPost, status: http.StatusInternalServerError, wantAttempts: 1},
+		{name: "PATCH not retried on 503", method: http.MethodPatch, status: http.StatusServiceUnavailable, wantAttempts: 1},
+		{name: "POST retried on 429", method: http.MethodPost, status: http.StatusTooManyRequests, wantAttempts: 3},
+		{name: "POST with idempotency key retried on 500", method: http.MethodPost, idempotencyKey: "abc", status: http.StatusInternalServerError, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"message": "failed"}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+				WithRetryPolicy(2, time.Millisecond, 5*time.Millisecond),
+				WithRetryAfter(false),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, server.URL+"/test", nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tt.idempotencyKey != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.idempotencyKey)
+			}
+
+			resp, err := client.do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+		})
+	}
+}
+
+// TestCheckRetry_ConnectionErrors tests that non-idempotent requests are only retried when the connection failed.
+func TestCheckRetry_ConnectionErrors(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "https://app.kosli.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	readErr := &url.Error{Op: "Post", URL: "https://app.kosli.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	post, _ := http.NewRequest(http.MethodPost, "https://app.kosli.com", nil)
+	put, _ := http.NewRequest(http.MethodPut, "https://app.kosli.com", nil)
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		err  error
+		want bool
+	}{
+		{name: "POST dial error", req: post, err: dialErr, want: true},
+		{name: "POST read error", req: post, err: readErr, want: false},
+		{name: "PUT read error", req: put, err: readErr, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := withRetrySafety(context.Background(), tt.req)
+			retry, err := checkRetry(ctx, nil, tt.err)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if retry != tt.want {
+				t.Errorf("expected retry=%v, got %v", tt.want, retry)
+			}
+		})
+	}
+}
+
+// TestClient_RetriesExhausted tests that the last failure is reported once
+// the retries run out.
+func TestClient_RetriesExhausted(t *testing.T) {
+	t.Run("API error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "down for maintenance"}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient("test-token", "test-org",
+			WithBaseURL(server.URL),
+			WithAPIPath(""),
+			WithRetryPolicy(2, time.Millisecond, 5*time.Millisecond),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		_, err = client.Get(context.Background(), "/test")
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected an APIError, got %v", err)
+		}
+		if apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.Message != "down for maintenance" {
+			t.Errorf("expected the last response's status and message, got %+v", apiErr)
+		}
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		client, err := NewClient("test-token", "test-org",
+			WithBaseURL(server.URL),
+			WithAPIPath(""),
+			WithRetryPolicy(2, time.Millisecond, 5*time.Millisecond),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		_, err = client.Get(context.Background(), "/test")
+		if err == nil || !strings.Contains(err.Error(), "giving up after 3 attempt(s)") {
+			t.Errorf("expected the number of attempts in the error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// sharedResponse is the outcome of a deduplicated GET, buffered so that each
+// waiting caller can be handed its own copy.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// WithGetDeduplication makes concurrent GETs of the same path share a single
+// API request. During a plan many data sources may read the same object at
+// once; with this option only one request goes out and every caller receives
+// its own copy of the response (or the same error). GETs that start after
+// the shared request has completed are sent as usual.
+func WithGetDeduplication() ClientOption {
+	return func(c *Client) error {
+		c.inflight = &singleflight.Group{}
+		return nil
+	}
+}
+
+// sharedGet performs a GET through the singleflight group. The shared request
+// is detached from the first caller's cancellation so that one caller giving
+// up doesn't fail the others; each caller still stops waiting when its own
+// context is done.
+func (c *Client) sharedGet(ctx context.Context, path string) (*http.Response, error) {
+	ch := c.inflight.DoChan(http.MethodGet+" "+path, func() (any, error) {
+		resp, err := c.doRequest(context.WithoutCancel(ctx), http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &sharedResponse{resp: resp, body: body}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		shared := result.Val.(*sharedResponse)
+		resp := *shared.resp
+		resp.Header = shared.resp.Header.Clone()
+		resp.Body = io.NopCloser(bytes.NewReader(shared.body))
+		return &resp, nil
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_GetDeduplication tests that concurrent identical GETs share one request.
+func TestClient_GetDeduplication(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithGetDeduplication(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.Get(context.Background(), "/environments/test-org/production")
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+		}(i)
+	}
+
+	// Give every caller time to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Errorf("caller %d: expected success, got %v", i, errs[i])
+		}
+		if bodies[i] != `{"name": "production"}` {
+			t.Errorf("caller %d: unexpected body %q", i, bodies[i])
+		}
+	}
+}
+
+// TestClient_GetDeduplicationCancel tests that a cancelled caller doesn't fail the others.
+func TestClient_GetDeduplicationCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithGetDeduplication(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.Get(ctx, "/environments/test-org")
+		firstErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	secondErr := make(chan error, 1)
+	go func() {
+		resp, err := client.Get(context.Background(), "/environments/test-org")
+		if err == nil {
+			resp.Body.Close()
+		}
+		secondErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled caller to get context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("expected other caller to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SnapshotSelector identifies one snapshot of an environment using Kosli's
+// snapshot selector syntax:
+//
+//	env             the latest snapshot
+//	env#N           snapshot number N (1-based)
+//	env~N           the snapshot N snapshots before the latest
+//	env@{timestamp} the snapshot that was running at timestamp
+//
+// At most one of Index, Behind and At is set; none set selects the latest
+// snapshot.
+type SnapshotSelector struct {
+	Environment string
+	Index       int    // #N; 0 when unset
+	Behind      int    // ~N; 0 when unset
+	At          string // @{timestamp}, e.g. a Unix timestamp or an ISO 8601 date; empty when unset
+}
+
+// ParseSnapshotSelector parses a snapshot selector such as "production#42",
+// "production~3" or "production@{1700000000}".
+func ParseSnapshotSelector(s string) (*SnapshotSelector, error) {
+	i := strings.IndexAny(s, "#~@")
+	if i == -1 {
+		if s == "" {
+			return nil, fmt.Errorf("snapshot selector cannot be empty")
+		}
+		return &SnapshotSelector{Environment: s}, nil
+	}
+	if i == 0 {
+		return nil, fmt.Errorf("invalid snapshot selector %q: missing environment name", s)
+	}
+
+	sel := &SnapshotSelector{Environment: s[:i]}
+	operator, arg := s[i], s[i+1:]
+	switch operator {
+	case '#':
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid snapshot selector %q: snapshot number must be a positive integer", s)
+		}
+		sel.Index = n
+	case '~':
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid snapshot selector %q: number of snapshots behind the latest must be a non-negative integer", s)
+		}
+		sel.Behind = n
+	case '@':
+		if !strings.HasPrefix(arg, "{") || !strings.HasSuffix(arg, "}") || len(arg) < 3 {
+			return nil, fmt.Errorf("invalid snapshot selector %q: timestamp must be written as @{timestamp}", s)
+		}
+		sel.At = arg[1 : len(arg)-1]
+	}
+
+	return sel, nil
+}
+
+// String returns the selector in Kosli's snapshot selector syntax.
+func (s *SnapshotSelector) String() string {
+	switch {
+	case s.Index > 0:
+		return fmt.Sprintf("%s#%d", s.Environment, s.Index)
+	case s.Behind > 0:
+		return fmt.Sprintf("%s~%d", s.Environment, s.Behind)
+	case s.At != "":
+		return fmt.Sprintf("%s@{%s}", s.Environment, s.At)
+	default:
+		return s.Environment
+	}
+}
+
+// expression returns the snapshot part of the selector as the last segment
+// of the snapshots endpoint path: "latest", "N", "~N" or "@{timestamp}".
+func (s *SnapshotSelector) expression() string {
+	switch {
+	case s.Index > 0:
+		return strconv.Itoa(s.Index)
+	case s.Behind > 0:
+		return fmt.Sprintf("~%d", s.Behind)
+	case s.At != "":
+		return url.PathEscape(fmt.Sprintf("@{%s}", s.At))
+	default:
+		return "latest"
+	}
+}
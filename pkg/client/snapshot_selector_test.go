@@ -0,0 +1,65 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestParseSnapshotSelector(t *testing.T) {
+	tests := []struct {
+		input string
+		want  SnapshotSelector
+	}{
+		{"production", SnapshotSelector{Environment: "production"}},
+		{"production#42", SnapshotSelector{Environment: "production", Index: 42}},
+		{"production~3", SnapshotSelector{Environment: "production", Behind: 3}},
+		{"production~0", SnapshotSelector{Environment: "production"}},
+		{"production@{1700000000}", SnapshotSelector{Environment: "production", At: "1700000000"}},
+		{"production@{2024-01-31T12:00:00}", SnapshotSelector{Environment: "production", At: "2024-01-31T12:00:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSnapshotSelector(tt.input)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestParseSnapshotSelector_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"#3",
+		"production#",
+		"production#0",
+		"production#-1",
+		"production#abc",
+		"production~",
+		"production~-1",
+		"production@1700000000",
+		"production@{}",
+		"production@{1700000000",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseSnapshotSelector(input); err == nil {
+				t.Errorf("expected an error for %q", input)
+			}
+		})
+	}
+}
+
+func TestSnapshotSelector_String(t *testing.T) {
+	for _, input := range []string{"production", "production#42", "production~3", "production@{1700000000}"} {
+		sel, err := ParseSnapshotSelector(input)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", input, err)
+		}
+		if sel.String() != input {
+			t.Errorf("expected %q, got %q", input, sel.String())
+		}
+	}
+}
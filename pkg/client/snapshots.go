@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Snapshot represents an environment snapshot as returned by the API: the
+// set of artifacts running in the environment at a point in time.
+type Snapshot struct {
+	Index     int     `json:"index"`     // 1-based position in the environment's history
+	Timestamp float64 `json:"timestamp"` // Unix timestamp of when the snapshot was reported
+	Compliant bool    `json:"compliant"`
+	// Logical environments only: the physical environments aggregated
+	// into the snapshot.
+	IncludedEnvironments []string `json:"included_environments,omitempty"`
+	// Artifacts running in the environment when the snapshot was reported.
+	Artifacts []SnapshotArtifact `json:"artifacts,omitempty"`
+}
+
+// SnapshotArtifact is an artifact running in an environment snapshot.
+type SnapshotArtifact struct {
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+	// Unix timestamps of when each running instance of the artifact, e.g.
+	// each pod replica, was started.
+	CreationTimestamps []float64 `json:"creationTimestamp"`
+}
+
+// ListSnapshotsOptions contains optional pagination for ListSnapshots.
+type ListSnapshotsOptions struct {
+	Page    int // 1-based page number; 0 returns the first page
+	PerPage int // Page size; 0 uses the client's default page size
+}
+
+// ListSnapshots retrieves the snapshots of an environment, newest first. Pass
+// nil opts to retrieve the first page with the client's default page size.
+func (c *Client) ListSnapshots(ctx context.Context, envName string, opts *ListSnapshotsOptions) ([]Snapshot, error) {
+	// Build path: GET /api/v2/snapshots/{org}/{env_name}
+	path := fmt.Sprintf("/snapshots/%s/%s", c.OrganizationFor(ctx), envName)
+
+	// Add optional pagination query parameters
+	page, perPage := 0, c.pageSize(0)
+	if opts != nil {
+		page, perPage = opts.Page, c.pageSize(opts.PerPage)
+	}
+	path = newQuery().
+		Int("page", page).
+		Int("per_page", perPage).
+		Path(path)
+
+	// Call API
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result []Snapshot
+	if err := ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetLatestSnapshot retrieves the most recent snapshot of an environment. It
+// returns a not found error if the environment has never reported.
+func (c *Client) GetLatestSnapshot(ctx context.Context, envName string) (*Snapshot, error) {
+	// Build path: GET /api/v2/snapshots/{org}/{env_name}/latest
+	path := fmt.Sprintf("/snapshots/%s/%s/latest", c.OrganizationFor(ctx), envName)
+
+	// Call API
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result Snapshot
+	if err := ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetSnapshot retrieves the snapshot of an environment identified by sel. It
+// returns a not found error if the environment or the snapshot does not
+// exist.
+func (c *Client) GetSnapshot(ctx context.Context, sel *SnapshotSelector) (*Snapshot, error) {
+	// Build path: GET /api/v2/snapshots/{org}/{env_name}/{latest|N|~N|@{timestamp}}
+	path := fmt.Sprintf("/snapshots/%s/%s/%s", c.OrganizationFor(ctx), sel.Environment, sel.expression())
+
+	// Call API
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result Snapshot
+	if err := ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestListSnapshots_Success tests listing snapshots with pagination
+func TestListSnapshots_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/snapshots/test-org/production" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.RawQuery; got != "page=2&per_page=10" {
+			t.Errorf("unexpected query: %s", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"index": 12, "timestamp": 1700000200.5, "compliant": true},
+			{"index": 11, "timestamp": 1700000100.5, "compliant": false}
+		]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	result, err := client.ListSnapshots(context.Background(), "production", &ListSnapshotsOptions{Page: 2, PerPage: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(result))
+	}
+	if result[0].Index != 12 || result[0].Timestamp != 1700000200.5 || !result[0].Compliant {
+		t.Errorf("unexpected first snapshot: %+v", result[0])
+	}
+	if result[1].Compliant {
+		t.Error("expected second snapshot to be non-compliant")
+	}
+}
+
+// TestGetLatestSnapshot_Success tests fetching the latest snapshot of a logical environment
+func TestGetLatestSnapshot_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/snapshots/test-org/production-all/latest" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"index": 3, "timestamp": 1700000300, "compliant": true, "included_environments": ["prod-k8s", "prod-ecs"]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	snapshot, err := client.GetLatestSnapshot(context.Background(), "production-all")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if snapshot.Index != 3 {
+		t.Errorf("expected index 3, got %d", snapshot.Index)
+	}
+	if len(snapshot.IncludedEnvironments) != 2 || snapshot.IncludedEnvironments[1] != "prod-ecs" {
+		t.Errorf("unexpected included environments: %v", snapshot.IncludedEnvironments)
+	}
+}
+
+// TestGetLatestSnapshot_Artifacts tests decoding the artifacts running in a snapshot
+func TestGetLatestSnapshot_Artifacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"index": 7, "timestamp": 1700000300, "compliant": false, "artifacts": [
+			{"name": "acme/backend:1.4.2", "fingerprint": "3b7f9a1c", "creationTimestamp": [1700000100, 1700000050]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	snapshot, err := client.GetLatestSnapshot(context.Background(), "prod-k8s")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(snapshot.Artifacts) != 1 {
+		t.Fatalf("expected 1 artifact, got %d", len(snapshot.Artifacts))
+	}
+	artifact := snapshot.Artifacts[0]
+	if artifact.Name != "acme/backend:1.4.2" || artifact.Fingerprint != "3b7f9a1c" || len(artifact.CreationTimestamps) != 2 {
+		t.Errorf("unexpected artifact: %+v", artifact)
+	}
+}
+
+func TestGetSnapshot_Selectors(t *testing.T) {
+	tests := []struct {
+		selector string
+		wantPath string
+	}{
+		{"prod-k8s", "/snapshots/test-org/prod-k8s/latest"},
+		{"prod-k8s#42", "/snapshots/test-org/prod-k8s/42"},
+		{"prod-k8s~3", "/snapshots/test-org/prod-k8s/~3"},
+		{"prod-k8s~0", "/snapshots/test-org/prod-k8s/latest"},
+		{"prod-k8s@{1700000000}", "/snapshots/test-org/prod-k8s/@%7B1700000000%7D"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.EscapedPath() != tt.wantPath {
+					t.Errorf("expected path %s, got %s", tt.wantPath, r.URL.EscapedPath())
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"index": 42, "timestamp": 1700000000, "compliant": true}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			sel, err := ParseSnapshotSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("failed to parse selector: %v", err)
+			}
+			snapshot, err := client.GetSnapshot(context.Background(), sel)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if snapshot.Index != 42 {
+				t.Errorf("expected index 42, got %d", snapshot.Index)
+			}
+		})
+	}
+}
@@ -15,7 +15,7 @@ type TagResourcePayload struct {
 // It uses PATCH /api/v2/tags/{org}/{resourceType}/{resourceID}.
 // SetTags adds or updates key-value tag pairs; RemoveTags removes tags by key.
 func (c *Client) TagResource(ctx context.Context, resourceType, resourceID string, payload *TagResourcePayload) error {
-	path := fmt.Sprintf("/tags/%s/%s/%s", c.Organization(), resourceType, resourceID)
+	path := fmt.Sprintf("/tags/%s/%s/%s", c.OrganizationFor(ctx), resourceType, resourceID)
 
 	resp, err := c.Patch(ctx, path, payload)
 	if err != nil {
@@ -25,3 +25,36 @@ func (c *Client) TagResource(ctx context.Context, resourceType, resourceID strin
 
 	return nil
 }
+
+// SetEnvironmentTag sets one tag of an environment, leaving its other tags
+// alone.
+func (c *Client) SetEnvironmentTag(ctx context.Context, environmentName, key, value string) error {
+	return c.TagResource(ctx, "environment", environmentName, &TagResourcePayload{
+		SetTags:    map[string]string{key: value},
+		RemoveTags: []string{},
+	})
+}
+
+// DeleteEnvironmentTag removes one tag from an environment, leaving its other
+// tags alone.
+func (c *Client) DeleteEnvironmentTag(ctx context.Context, environmentName, key string) error {
+	return c.TagResource(ctx, "environment", environmentName, &TagResourcePayload{
+		SetTags:    map[string]string{},
+		RemoveTags: []string{key},
+	})
+}
+
+// ListEnvironmentTags returns the tags of an environment, read from the
+// standard GET /api/v2/environments/{org}/{env} response. An environment
+// without tags returns an empty map.
+func (c *Client) ListEnvironmentTags(ctx context.Context, environmentName string) (map[string]string, error) {
+	env, err := c.GetEnvironment(ctx, environmentName)
+	if err != nil {
+		return nil, err
+	}
+
+	if env.Tags == nil {
+		return map[string]string{}, nil
+	}
+	return env.Tags, nil
+}
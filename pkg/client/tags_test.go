@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -230,3 +231,88 @@ func TestTagResource_ResourceTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestEnvironmentTag_SetAndDelete verifies that single tags are set and
+// removed without touching the others
+func TestEnvironmentTag_SetAndDelete(t *testing.T) {
+	var bodies []TagResourcePayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/tags/test-org/environment/prod-k8s" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body TagResourcePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.Write([]byte(`"OK"`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := c.SetEnvironmentTag(context.Background(), "prod-k8s", "tier", "prod"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := c.DeleteEnvironmentTag(context.Background(), "prod-k8s", "team"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bodies))
+	}
+	if len(bodies[0].SetTags) != 1 || bodies[0].SetTags["tier"] != "prod" || len(bodies[0].RemoveTags) != 0 {
+		t.Errorf("expected only tier=prod to be set, got %+v", bodies[0])
+	}
+	if len(bodies[1].SetTags) != 0 || len(bodies[1].RemoveTags) != 1 || bodies[1].RemoveTags[0] != "team" {
+		t.Errorf("expected only team to be removed, got %+v", bodies[1])
+	}
+}
+
+// TestListEnvironmentTags verifies tags are read from the environment, and
+// that an environment without tags has an empty map
+func TestListEnvironmentTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     map[string]string
+	}{
+		{"tagged", `{"name": "prod-k8s", "type": "K8S", "tags": {"tier": "prod"}}`, map[string]string{"tier": "prod"}},
+		{"untagged", `{"name": "prod-k8s", "type": "K8S", "tags": null}`, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/environments/test-org/prod-k8s" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			c, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			tags, err := c.ListEnvironmentTags(context.Background(), "prod-k8s")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if tags == nil || !maps.Equal(tags, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, tags)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultReadTimeout is the default deadline for a read (GET or HEAD)
+	// operation, including retries.
+	DefaultReadTimeout = 2 * time.Minute
+
+	// DefaultWriteTimeout is the default deadline for a write operation,
+	// including retries. It is longer than DefaultReadTimeout to leave room
+	// for multipart uploads such as flow templates and attestation schemas.
+	DefaultWriteTimeout = 5 * time.Minute
+)
+
+// WithOperationTimeouts sets the deadlines for read (GET and HEAD) and write
+// (all other methods) operations. A deadline covers the whole operation:
+// rate limiting, every retry and the waits between them, and reading the
+// response body. The client-wide timeout (WithTimeout) still limits each
+// individual HTTP attempt, so a deadline longer than it only gives room for
+// retries.
+func WithOperationTimeouts(read, write time.Duration) ClientOption {
+	return func(c *Client) error {
+		if read <= 0 {
+			return fmt.Errorf("read timeout must be greater than 0")
+		}
+		if write <= 0 {
+			return fmt.Errorf("write timeout must be greater than 0")
+		}
+		c.readTimeout = read
+		c.writeTimeout = write
+		return nil
+	}
+}
+
+// WithAttemptTimeouts replaces the client-wide timeout (WithTimeout) as the
+// limit of each individual HTTP attempt of reads (GET and HEAD) and writes
+// (all other methods) respectively, e.g. so that slow multipart uploads are
+// not cut short by a timeout suited to quick GETs. Zero keeps the client-wide
+// timeout for that kind of request. Like WithTimeout, it must precede
+// WithRetryPolicy, and it has no effect with WithHTTPClient.
+func WithAttemptTimeouts(read, write time.Duration) ClientOption {
+	return func(c *Client) error {
+		if read < 0 || write < 0 {
+			return fmt.Errorf("attempt timeouts must not be negative")
+		}
+		c.readAttemptTimeout = read
+		c.writeAttemptTimeout = write
+		return nil
+	}
+}
+
+// attemptTimeoutTransport limits each HTTP attempt sent through it to the
+// timeout for its kind of request, including reading the response body.
+type attemptTimeoutTransport struct {
+	base        http.RoundTripper
+	read, write time.Duration
+}
+
+func (t *attemptTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.write
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		timeout = t.read
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// operationTimeout returns the deadline for req's operation.
+func (c *Client) operationTimeout(req *http.Request) time.Duration {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return c.readTimeout
+	}
+	return c.writeTimeout
+}
+
+// withOperationDeadline sends req through send under the operation deadline.
+// The deadline stays in force until the caller closes the response body.
+func (c *Client) withOperationDeadline(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	timeout := c.operationTimeout(req)
+	if timeout <= 0 {
+		return send(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := send(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		// Only blame the operation deadline if the caller's own context is
+		// still live.
+		if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+			return nil, fmt.Errorf("%s operation timed out after %s: %w", req.Method, timeout, err)
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a context when the response body it guards is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClient_OperationTimeouts tests that reads and writes get their own deadlines.
+func TestClient_OperationTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithHTTPClient(&http.Client{}),
+		WithOperationTimeouts(20*time.Millisecond, time.Second),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Get(context.Background(), "/test")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected read to hit its deadline, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "GET operation timed out after 20ms") {
+		t.Errorf("expected error to name the operation deadline, got %v", err)
+	}
+
+	resp, err := client.Put(context.Background(), "/test", map[string]string{})
+	if err != nil {
+		t.Fatalf("expected write to complete within its deadline, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+// TestClient_OperationTimeoutBodyRead tests that the deadline stays usable while the body is read.
+func TestClient_OperationTimeoutBodyRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithOperationTimeouts(time.Second, time.Second),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected body to be readable after do returned, got %v", err)
+	}
+	if string(body) != `{"name": "production"}` {
+		t.Errorf("unexpected body %q", body)
+	}
+	if err := resp.Body.Close(); err != nil {
+		t.Errorf("unexpected close error: %v", err)
+	}
+}
+
+// TestWithOperationTimeouts_Validation tests that non-positive timeouts are rejected.
+func TestWithOperationTimeouts_Validation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithOperationTimeouts(0, time.Second)); err == nil {
+		t.Error("expected error for zero read timeout")
+	}
+	if _, err := NewClient("test-token", "test-org", WithOperationTimeouts(time.Second, -time.Second)); err == nil {
+		t.Error("expected error for negative write timeout")
+	}
+}
+
+// TestClient_AttemptTimeouts tests that writes can outlast the client-wide
+// timeout of each attempt while reads are still bound by it.
+func TestClient_AttemptTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "production"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithTimeout(20*time.Millisecond),
+		WithAttemptTimeouts(0, time.Second),
+		WithRetryPolicy(0, time.Millisecond, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Get(context.Background(), "/test"); err == nil {
+		t.Fatal("expected read to exceed the client-wide timeout")
+	}
+
+	resp, err := client.Put(context.Background(), "/test", map[string]string{})
+	if err != nil {
+		t.Fatalf("expected write to complete within its attempt timeout, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The attempt timeout stays in force until the body is read
+	var result struct{ Name string }
+	if err := ParseResponse(resp, &result); err != nil || result.Name != "production" {
+		t.Errorf("expected the body to be readable, got %q, %v", result.Name, err)
+	}
+}
+
+func TestWithAttemptTimeouts_Validation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithAttemptTimeouts(-time.Second, 0)); err == nil {
+		t.Error("expected error for negative read attempt timeout")
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TokenSource returns the API token to authenticate a request with. It is
+// called before every request, so sources that exchange or lease tokens,
+// e.g. through an OIDC token exchange or a Vault lease, should cache a token
+// until shortly before it expires.
+type TokenSource func(ctx context.Context) (string, error)
+
+// WithTokenSource authenticates each request with a token from source
+// instead of the token passed to NewClient, so tokens can be refreshed
+// during a long run. The token passed to NewClient may then be empty.
+//
+// Example:
+//
+//	client, err := NewClient("", "org-name", WithTokenSource(func(ctx context.Context) (string, error) {
+//	    return vault.KosliToken(ctx)
+//	}))
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) error {
+		if source == nil {
+			return fmt.Errorf("token source cannot be nil")
+		}
+		c.tokenSource = source
+		return nil
+	}
+}
+
+// authorize sets the Authorization header of req to the client's token, or
+// to a token from its token source.
+func (c *Client) authorize(req *http.Request) error {
+	token := c.apiToken
+	if c.tokenSource != nil {
+		var err error
+		token, err = c.tokenSource(req.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get API token: %w", err)
+		}
+		if token == "" {
+			return fmt.Errorf("failed to get API token: token source returned an empty token")
+		}
+		// Remember the token so it is redacted from logs like a fixed one
+		c.sourcedToken.Store(token)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestClient_TokenSource tests that every request is authenticated with a fresh token from the token source.
+func TestClient_TokenSource(t *testing.T) {
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	calls := 0
+	client, err := NewClient("", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithTokenSource(func(ctx context.Context) (string, error) {
+			calls++
+			return fmt.Sprintf("token-%d", calls), nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for range 2 {
+		resp, err := client.Put(context.Background(), "/test-path", map[string]string{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	expected := []string{"Bearer token-1", "Bearer token-2"}
+	if strings.Join(auths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected Authorization headers %v, got %v", expected, auths)
+	}
+	if got := client.redact("token=token-2"); got != "token=[REDACTED]" {
+		t.Errorf("expected the sourced token to be redacted, got %q", got)
+	}
+}
+
+// TestClient_TokenSourceErrors tests that a request is not sent when the token source fails.
+func TestClient_TokenSourceErrors(t *testing.T) {
+	errVault := errors.New("vault sealed")
+	tests := []struct {
+		name        string
+		source      TokenSource
+		expectedErr string
+	}{
+		{
+			name:        "source error",
+			source:      func(ctx context.Context) (string, error) { return "", errVault },
+			expectedErr: "failed to get API token: vault sealed",
+		},
+		{
+			name:        "empty token",
+			source:      func(ctx context.Context) (string, error) { return "", nil },
+			expectedErr: "token source returned an empty token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+			}))
+			defer server.Close()
+
+			client, err := NewClient("", "test-org", WithBaseURL(server.URL), WithTokenSource(tt.source))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.Get(context.Background(), "/test-path")
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.expectedErr, err)
+			}
+			if requests != 0 {
+				t.Errorf("expected no request to be sent, got %d", requests)
+			}
+		})
+	}
+}
+
+// TestWithTokenSource_Nil tests that a nil token source is rejected.
+func TestWithTokenSource_Nil(t *testing.T) {
+	_, err := NewClient("test-token", "test-org", WithTokenSource(nil))
+	if err == nil || !strings.Contains(err.Error(), "token source cannot be nil") {
+		t.Errorf("expected nil token source error, got %v", err)
+	}
+}
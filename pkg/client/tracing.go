@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope name used for client spans.
+const tracerName = "github.com/kosli-dev/terraform-provider-kosli/pkg/client"
+
+// Span attribute keys. The HTTP keys follow the OpenTelemetry semantic
+// conventions; retry count is Kosli-specific.
+const (
+	attrHTTPMethod     = attribute.Key("http.request.method")
+	attrURLPath        = attribute.Key("url.path")
+	attrHTTPStatusCode = attribute.Key("http.response.status_code")
+	attrRetryCount     = attribute.Key("kosli.retry_count")
+	attrOrganization   = attribute.Key("kosli.org")
+)
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider used to create a
+// span for every API request. When not set, the global TracerProvider
+// (otel.GetTracerProvider) is used, which is a no-op unless the embedding
+// program installs one.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) error {
+		if tp == nil {
+			return fmt.Errorf("tracer provider cannot be nil")
+		}
+		c.tracerProvider = tp
+		return nil
+	}
+}
+
+// WithTraceParent sets a W3C traceparent header value (for example from the
+// TRACEPARENT environment variable of a CI job) used as the parent of request
+// spans whose context doesn't already carry a span. This lets spans join an
+// existing distributed trace even though Terraform doesn't propagate one.
+func WithTraceParent(traceparent string) ClientOption {
+	return func(c *Client) error {
+		carrier := propagation.MapCarrier{"traceparent": traceparent}
+		ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return fmt.Errorf("invalid traceparent %q", traceparent)
+		}
+		c.traceParent = sc
+		return nil
+	}
+}
+
+// tracer returns the tracer for client spans.
+func (c *Client) tracer() trace.Tracer {
+	tp := c.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// attemptCounterKey is the context key for the per-request attempt counter
+// incremented by the retry policy's request hook.
+type attemptCounterKey struct{}
+
+// withAttemptCounter returns a context carrying a fresh attempt counter.
+func withAttemptCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := &atomic.Int64{}
+	return context.WithValue(ctx, attemptCounterKey{}, counter), counter
+}
+
+// countAttempt records that attempt (0-based) of the request is being sent.
+// It is installed as the retry client's RequestLogHook.
+func countAttempt(req *http.Request, attempt int) {
+	if counter, ok := req.Context().Value(attemptCounterKey{}).(*atomic.Int64); ok {
+		counter.Store(int64(attempt) + 1)
+	}
+}
+
+// retries returns the number of retries recorded by an attempt counter.
+// Without the retry policy the hook never runs; the single attempt still
+// counts as one.
+func retries(attempts *atomic.Int64) int {
+	return int(max(attempts.Load()-1, 0))
+}
+
+// startSpan starts a client span for req and returns the request to send,
+// bound to the span's context with trace headers injected, together with a
+// function that ends the span with the outcome of the request. The span's
+// retry count is read from attempts.
+func (c *Client) startSpan(req *http.Request, attempts *atomic.Int64) (*http.Request, func(*http.Response, error)) {
+	ctx := req.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() && c.traceParent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, c.traceParent)
+	}
+
+	ctx, span := c.tracer().Start(ctx, "kosli "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attrHTTPMethod.String(req.Method),
+			attrURLPath.String(req.URL.Path),
+			attrOrganization.String(c.OrganizationFor(ctx)),
+		),
+	)
+
+	req = req.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, func(resp *http.Response, err error) {
+		defer span.End()
+
+		span.SetAttributes(attrRetryCount.Int(retries(attempts)))
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		span.SetAttributes(attrHTTPStatusCode.Int(resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanAttr returns the value of key on span, or an empty value if absent.
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestClient_Tracing tests that requests produce spans with method, path, status and retry count.
+func TestClient_Tracing(t *testing.T) {
+	attempts := 0
+	var gotTraceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		gotTraceparent = r.Header.Get("traceparent")
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath("/api/v2"),
+		WithTracerProvider(tp),
+		WithRetryPolicy(3, time.Millisecond, 10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/environments/test-org")
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name() != "kosli GET" {
+		t.Errorf("expected span name %q, got %q", "kosli GET", span.Name())
+	}
+	if got := spanAttr(span, attrHTTPMethod).AsString(); got != http.MethodGet {
+		t.Errorf("expected method GET, got %q", got)
+	}
+	if got := spanAttr(span, attrURLPath).AsString(); got != "/api/v2/environments/test-org" {
+		t.Errorf("expected path /api/v2/environments/test-org, got %q", got)
+	}
+	if got := spanAttr(span, attrHTTPStatusCode).AsInt64(); got != http.StatusOK {
+		t.Errorf("expected status 200, got %d", got)
+	}
+	if got := spanAttr(span, attrRetryCount).AsInt64(); got != 2 {
+		t.Errorf("expected retry count 2, got %d", got)
+	}
+	if gotTraceparent == "" {
+		t.Error("expected traceparent header to be propagated")
+	}
+}
+
+// TestClient_TracingErrorStatus tests that failed requests mark the span as an error.
+func TestClient_TracingErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithTracerProvider(tp),
+		WithHTTPClient(&http.Client{Timeout: 10 * time.Second}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Get(context.Background(), "/missing"); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", spans[0].Status().Code)
+	}
+	if got := spanAttr(spans[0], attrRetryCount).AsInt64(); got != 0 {
+		t.Errorf("expected retry count 0 without retry policy, got %d", got)
+	}
+}
+
+// TestClient_TracingMultipart tests that multipart requests are traced too.
+func TestClient_TracingMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithTracerProvider(tp),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.CreateCustomAttestationType(context.Background(), &CreateCustomAttestationTypeRequest{
+		Name:   "coverage",
+		Schema: `{"type": "object"}`,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if got := spanAttr(spans[0], attrURLPath).AsString(); got != "/custom-attestation-types/test-org" {
+		t.Errorf("expected multipart path, got %q", got)
+	}
+}
+
+// TestClient_TraceParent tests that spans join a remote parent when configured.
+func TestClient_TraceParent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithTracerProvider(tp),
+		WithTraceParent("00-"+traceID+"-00f067aa0ba902b7-01"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if got := spans[0].SpanContext().TraceID().String(); got != traceID {
+		t.Errorf("expected trace ID %s, got %s", traceID, got)
+	}
+
+	if _, err := NewClient("test-token", "test-org", WithTraceParent("garbage")); err == nil {
+		t.Error("expected error for invalid traceparent, got nil")
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Trail compliance statuses reported by the API.
+const (
+	TrailStatusCompliant    = "COMPLIANT"
+	TrailStatusNonCompliant = "NON-COMPLIANT"
+	TrailStatusIncomplete   = "INCOMPLETE"
+)
+
+// AttestationStatusMissing is the status of an attestation the flow template
+// expects but that has not been reported to the trail.
+const AttestationStatusMissing = "MISSING"
+
+// Trail represents a Kosli trail as returned by the API: a single run of a
+// flow's process, e.g. one commit or one change request.
+type Trail struct {
+	Name             string                `json:"name"`
+	Description      string                `json:"description"`
+	CreatedAt        float64               `json:"created_at"` // Unix timestamp
+	ComplianceStatus TrailComplianceStatus `json:"compliance_status"`
+}
+
+// TrailComplianceStatus is the compliance of a trail against its flow
+// template.
+type TrailComplianceStatus struct {
+	Status      string `json:"status"` // COMPLIANT, NON-COMPLIANT or INCOMPLETE
+	IsCompliant bool   `json:"is_compliant"`
+	// Attestations on the trail itself, including the expected ones that
+	// are missing.
+	AttestationsStatuses []AttestationStatus `json:"attestations_statuses"`
+	// Attestations of each artifact, keyed by the artifact's template name.
+	ArtifactsStatuses map[string]ArtifactStatus `json:"artifacts_statuses"`
+}
+
+// ArtifactStatus is the compliance of an artifact on a trail.
+type ArtifactStatus struct {
+	Fingerprint          string              `json:"fingerprint"` // Empty if the artifact has not been reported
+	AttestationsStatuses []AttestationStatus `json:"attestations_statuses"`
+}
+
+// AttestationStatus is the status of one attestation on a trail.
+type AttestationStatus struct {
+	Name        string `json:"attestation_name"`
+	Type        string `json:"attestation_type"`
+	Status      string `json:"status"` // COMPLETE or MISSING
+	IsCompliant bool   `json:"is_compliant"`
+}
+
+// ListTrailsOptions contains optional pagination for ListTrails.
+type ListTrailsOptions struct {
+	Page    int // 1-based page number; 0 returns the first page
+	PerPage int // Page size; 0 uses the client's default page size
+}
+
+// GetTrail retrieves a trail of a flow by name.
+func (c *Client) GetTrail(ctx context.Context, flowName, trailName string) (*Trail, error) {
+	// Build path: GET /api/v2/trails/{org}/{flow_name}/{trail_name}
+	path := fmt.Sprintf("/trails/%s/%s/%s", c.OrganizationFor(ctx), flowName, trailName)
+
+	// Call API
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result Trail
+	if err := ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListTrails retrieves the trails of a flow, newest first. Pass nil opts to
+// retrieve the first page with the client's default page size.
+func (c *Client) ListTrails(ctx context.Context, flowName string, opts *ListTrailsOptions) ([]Trail, error) {
+	// Build path: GET /api/v2/trails/{org}/{flow_name}
+	path := fmt.Sprintf("/trails/%s/%s", c.OrganizationFor(ctx), flowName)
+
+	// Add optional pagination query parameters
+	page, perPage := 0, c.pageSize(0)
+	if opts != nil {
+		page, perPage = opts.Page, c.pageSize(opts.PerPage)
+	}
+	path = newQuery().
+		Int("page", page).
+		Int("per_page", perPage).
+		Path(path)
+
+	// Call API
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var result []Trail
+	if err := ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
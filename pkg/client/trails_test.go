@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetTrail_Success tests fetching a trail and its compliance status
+func TestGetTrail_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/trails/test-org/backend/abc123" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"name": "abc123",
+			"created_at": 1700000000.5,
+			"compliance_status": {
+				"status": "INCOMPLETE",
+				"is_compliant": false,
+				"attestations_statuses": [
+					{"attestation_name": "pull-request", "attestation_type": "pull_request", "status": "COMPLETE", "is_compliant": true},
+					{"attestation_name": "risk-review", "attestation_type": "generic", "status": "MISSING", "is_compliant": false}
+				],
+				"artifacts_statuses": {
+					"backend-image": {
+						"fingerprint": "sha256-backend",
+						"attestations_statuses": [
+							{"attestation_name": "unit-tests", "attestation_type": "junit", "status": "COMPLETE", "is_compliant": false}
+						]
+					}
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	trail, err := client.GetTrail(context.Background(), "backend", "abc123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	status := trail.ComplianceStatus
+	if trail.Name != "abc123" || status.Status != TrailStatusIncomplete || status.IsCompliant {
+		t.Errorf("unexpected trail: %+v", trail)
+	}
+	if len(status.AttestationsStatuses) != 2 || status.AttestationsStatuses[1].Status != AttestationStatusMissing {
+		t.Errorf("unexpected trail attestations: %+v", status.AttestationsStatuses)
+	}
+	artifact, ok := status.ArtifactsStatuses["backend-image"]
+	if !ok || artifact.Fingerprint != "sha256-backend" || len(artifact.AttestationsStatuses) != 1 {
+		t.Errorf("unexpected artifact statuses: %+v", status.ArtifactsStatuses)
+	}
+}
+
+// TestGetTrail_NotFound tests that a missing trail is reported as not found
+func TestGetTrail_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Trail 'abc123' not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetTrail(context.Background(), "backend", "abc123")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+// TestListTrails_Success tests listing trails with pagination
+func TestListTrails_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/trails/test-org/backend" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.RawQuery; got != "per_page=1" {
+			t.Errorf("unexpected query: %s", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name": "def456", "compliance_status": {"status": "COMPLIANT", "is_compliant": true}}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	trails, err := client.ListTrails(context.Background(), "backend", &ListTrailsOptions{PerPage: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(trails) != 1 || trails[0].Name != "def456" || !trails[0].ComplianceStatus.IsCompliant {
+		t.Errorf("unexpected trails: %+v", trails)
+	}
+}
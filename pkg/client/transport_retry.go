@@ -0,0 +1,92 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// DefaultTransportRetries is the default number of times an idempotent
+// request is resent after a low-level network error; see
+// WithTransportRetries.
+const DefaultTransportRetries = 2
+
+// transportRetryWait is the pause before resending a request after a
+// low-level network error.
+const transportRetryWait = 100 * time.Millisecond
+
+// WithTransportRetries sets how many times an idempotent request (GET, PUT,
+// DELETE, or one carrying an idempotency key) is resent straight away after
+// a connection reset, an unexpected EOF or a failed DNS lookup. These resends
+// happen within a single attempt of the retry policy, independently of the
+// retries on HTTP status codes, so network noise is absorbed even when those
+// are disabled. Set to 0 to disable. Like WithTimeout, it must precede
+// WithRetryPolicy, and it has no effect with WithHTTPClient.
+func WithTransportRetries(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("transport retries must be 0 or greater")
+		}
+		c.transportRetries = n
+		return nil
+	}
+}
+
+// transportRetrier resends idempotent requests that failed with a transient
+// network error.
+type transportRetrier struct {
+	base    http.RoundTripper
+	retries int
+	wait    time.Duration
+}
+
+func (t *transportRetrier) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	for retry := 1; retry <= t.retries && err != nil && isTransientNetworkError(err) && isIdempotent(req); retry++ {
+		// A request whose body can't be replayed is not resent
+		next := req.Clone(req.Context())
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				break
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				break
+			}
+			next.Body = body
+		}
+
+		contextLogger(req.Context()).DebugContext(req.Context(), "Resending Kosli API request after a network error",
+			"method", req.Method,
+			"path", req.URL.Path,
+			"retry", retry,
+			"error", err.Error(),
+		)
+
+		select {
+		case <-time.After(t.wait):
+		case <-req.Context().Done():
+			return nil, err
+		}
+		resp, err = t.base.RoundTrip(next)
+	}
+	return resp, err
+}
+
+// isTransientNetworkError reports whether err is network noise worth
+// resending a request for: the connection was reset or closed mid-exchange,
+// or the host name briefly failed to resolve. Timeouts are not, as the
+// request may still be in progress; they are left to the retry policy.
+func isTransientNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		(errors.As(err, &dnsErr) && !dnsErr.IsTimeout)
+}
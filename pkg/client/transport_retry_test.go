@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// flakyServer drops the connection of the first failures requests, with a
+// reset if reset is set and by closing it otherwise, and answers the rest.
+// It returns the server and a pointer to the number of requests received.
+func flakyServer(t *testing.T, failures int64, reset bool) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			body = []byte("null")
+		}
+		if requests.Add(1) <= failures {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %v", err)
+				return
+			}
+			if tcp, ok := conn.(*net.TCPConn); ok && reset {
+				tcp.SetLinger(0)
+			}
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"body": ` + string(body) + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// TestClient_TransportRetries tests that idempotent requests are resent
+// after dropped connections, even with status retries disabled
+func TestClient_TransportRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		failures     int64
+		reset        bool
+		wantErr      bool
+		wantRequests int64
+	}{
+		{name: "GET after connection closed", method: http.MethodGet, failures: 2, wantRequests: 3},
+		{name: "GET after connection reset", method: http.MethodGet, failures: 1, reset: true, wantRequests: 2},
+		{name: "PUT resent with its body", method: http.MethodPut, failures: 1, reset: true, wantRequests: 2},
+		{name: "GET gives up", method: http.MethodGet, failures: 3, wantErr: true, wantRequests: 3},
+		{name: "PATCH not resent", method: http.MethodPatch, failures: 1, reset: true, wantErr: true, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := flakyServer(t, tt.failures, tt.reset)
+
+			client, err := NewClient("test-token", "test-org",
+				WithBaseURL(server.URL),
+				WithAPIPath(""),
+				WithRetryPolicy(0, time.Millisecond, time.Millisecond),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			var body any
+			if tt.method != http.MethodGet {
+				body = map[string]string{"name": "production"}
+			}
+			resp, err := client.doRequest(context.Background(), tt.method, "/test", body)
+
+			if tt.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Error("expected an error, got nil")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				var result struct {
+					Body map[string]string `json:"body"`
+				}
+				if err := ParseResponse(resp, &result); err != nil {
+					t.Fatalf("failed to parse response: %v", err)
+				}
+				if body != nil && result.Body["name"] != "production" {
+					t.Errorf("expected the body to be resent, got %v", result.Body)
+				}
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestClient_TransportRetries_Disabled(t *testing.T) {
+	server, requests := flakyServer(t, 1, false)
+
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithTransportRetries(0),
+		WithRetryPolicy(0, time.Millisecond, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Get(context.Background(), "/test"); err == nil {
+		t.Error("expected an error, got nil")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestIsTransientNetworkError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"EOF", io.EOF, true},
+		{"connection reset", &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, false},
+		{"DNS not found", &net.DNSError{Err: "no such host", Name: "app.kosli.com", IsNotFound: true}, true},
+		{"DNS timeout", &net.DNSError{Err: "i/o timeout", Name: "app.kosli.com", IsTimeout: true}, false},
+		{"deadline", context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientNetworkError(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithTransportRetries_Validation(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithTransportRetries(-1)); err == nil {
+		t.Error("expected error for negative transport retries, got nil")
+	}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// wireDumper writes full, redacted request/response exchanges to a writer.
+// It is meant for support escalations and is independent of tflog, so a dump
+// can be collected without the rest of Terraform's TRACE output.
+type wireDumper struct {
+	mu  sync.Mutex
+	w   io.Writer
+	seq atomic.Int64
+}
+
+// WithWireDump writes every request and response, with headers and complete
+// bodies, to w. Multipart bodies are broken down into their parts with each
+// part's name, filename, content type and size. The API token, sensitive
+// headers and token-looking values are redacted as in the tflog output.
+//
+// Each exchange is written in a single Write call once the response has
+// arrived, so concurrent requests don't interleave. The caller owns w.
+func WithWireDump(w io.Writer) ClientOption {
+	return func(c *Client) error {
+		if w == nil {
+			return fmt.Errorf("wire dump writer cannot be nil")
+		}
+		c.wireDump = &wireDumper{w: w}
+		return nil
+	}
+}
+
+// dumpRequest renders req for the wire dump and returns a function that
+// appends the outcome of the request and writes the complete exchange.
+// When wire dumping is disabled it returns a no-op.
+func (c *Client) dumpRequest(req *http.Request) func(*http.Response, error, time.Duration) {
+	if c.wireDump == nil {
+		return func(*http.Response, error, time.Duration) {}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "=== Kosli API exchange #%d at %s\n", c.wireDump.seq.Add(1), c.now().UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(&buf, "--- request\n%s %s\n", req.Method, c.redact(req.URL.String()))
+	c.dumpHeaders(&buf, req.Header)
+
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	c.dumpBody(&buf, req.Header.Get("Content-Type"), body)
+
+	return func(resp *http.Response, err error, elapsed time.Duration) {
+		if err != nil {
+			fmt.Fprintf(&buf, "--- error after %s\n%s\n", elapsed.Round(time.Millisecond), c.redact(err.Error()))
+		} else {
+			fmt.Fprintf(&buf, "--- response after %s\n%s\n", elapsed.Round(time.Millisecond), resp.Status)
+			c.dumpHeaders(&buf, resp.Header)
+			body, _ := bufferBody(resp)
+			c.dumpBody(&buf, resp.Header.Get("Content-Type"), body)
+		}
+		buf.WriteString("\n")
+
+		c.wireDump.mu.Lock()
+		defer c.wireDump.mu.Unlock()
+		c.wireDump.w.Write(buf.Bytes())
+	}
+}
+
+// dumpHeaders writes h to buf in a stable order with sensitive values redacted.
+func (c *Client) dumpHeaders(buf *bytes.Buffer, h http.Header) {
+	headers := c.redactHeaders(h)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(buf, "%s: %s\n", name, headers[name])
+	}
+	buf.WriteString("\n")
+}
+
+// dumpBody writes body to buf, redacted. Multipart bodies are written part
+// by part; other non-text bodies are summarized by size.
+func (c *Client) dumpBody(buf *bytes.Buffer, contentType string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		c.dumpMultipart(buf, body, params["boundary"])
+	case strings.Contains(mediaType, "json"), strings.HasPrefix(mediaType, "text/"), mediaType == "":
+		buf.WriteString(c.redact(string(body)))
+		buf.WriteString("\n")
+	default:
+		fmt.Fprintf(buf, "<%s body, %d bytes>\n", mediaType, len(body))
+	}
+}
+
+// dumpMultipart writes each part of a multipart body with its metadata.
+func (c *Client) dumpMultipart(buf *bytes.Buffer, body []byte, boundary string) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for i := 1; ; i++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(buf, "<malformed multipart body: %s>\n", err)
+			return
+		}
+		content, _ := io.ReadAll(part)
+		partType := part.Header.Get("Content-Type")
+		fmt.Fprintf(buf, "[part %d] name=%q", i, part.FormName())
+		if part.FileName() != "" {
+			fmt.Fprintf(buf, " filename=%q", part.FileName())
+		}
+		if partType != "" {
+			fmt.Fprintf(buf, " content-type=%q", partType)
+		}
+		fmt.Fprintf(buf, " size=%d\n", len(content))
+		if partType == "" || partType == "application/octet-stream" {
+			// Form files default to octet-stream; the Kosli ones are schemas
+			// and templates, which are text.
+			partType = "text/plain"
+		}
+		c.dumpBody(buf, partType, content)
+	}
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestClient_WireDump tests that JSON exchanges are dumped in full with secrets redacted.
+func TestClient_WireDump(t *testing.T) {
+	const token = "super-secret-token-value"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-ID", "req-123")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "prod", "webhook_secret": "leaked-value"}`))
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client, err := NewClient(token, "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithWireDump(&dump),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.Post(context.Background(), "/environments/test-org", map[string]any{
+		"name":     "prod",
+		"password": "hunter2",
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "leaked-value") {
+		t.Errorf("expected caller to receive the unredacted body, got %q", body)
+	}
+
+	out := dump.String()
+	for _, want := range []string{
+		"=== Kosli API exchange #1",
+		"POST " + server.URL + "/environments/test-org",
+		"Authorization: [REDACTED]",
+		`"name":"prod"`,
+		"200 OK",
+		"X-Request-Id: req-123",
+		`"webhook_secret": "[REDACTED]"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dump to contain %q, got:\n%s", want, out)
+		}
+	}
+	for _, secret := range []string{token, "hunter2", "leaked-value"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted from dump", secret)
+		}
+	}
+}
+
+// TestClient_WireDumpMultipart tests that multipart bodies are dumped part by part.
+func TestClient_WireDumpMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL(server.URL),
+		WithAPIPath(""),
+		WithWireDump(&dump),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = client.CreateCustomAttestationType(context.Background(), &CreateCustomAttestationTypeRequest{
+		Name:   "coverage",
+		Schema: `{"type": "object"}`,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	out := dump.String()
+	for _, want := range []string{
+		`[part 1] name="data_json"`,
+		`"name":"coverage"`,
+		`[part 2] name="type_schema" filename="schema.json" content-type="application/octet-stream" size=18`,
+		`{"type": "object"}`,
+		"201 Created",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dump to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestClient_WireDumpError tests that transport errors are dumped.
+func TestClient_WireDumpError(t *testing.T) {
+	var dump bytes.Buffer
+	client, err := NewClient("test-token", "test-org",
+		WithBaseURL("http://127.0.0.1:1"),
+		WithAPIPath(""),
+		WithHTTPClient(&http.Client{}),
+		WithWireDump(&dump),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.Get(context.Background(), "/environments/test-org"); err == nil {
+		t.Fatal("expected connection error")
+	}
+	if !strings.Contains(dump.String(), "--- error after") {
+		t.Errorf("expected error to be dumped, got:\n%s", dump.String())
+	}
+}
+
+// TestWithWireDump_Nil tests that a nil writer is rejected.
+func TestWithWireDump_Nil(t *testing.T) {
+	if _, err := NewClient("test-token", "test-org", WithWireDump(nil)); err == nil {
+		t.Error("expected error for nil writer")
+	}
+}